@@ -0,0 +1,231 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+)
+
+const metadataImportPath = "github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+
+// parseContractDocs parses the non-test Go files in dir and returns the package name and
+// the documentation for the named contract type. Files named in skip are not parsed
+func parseContractDocs(dir string, typeName string, skip ...string) (string, metadata.ContractDocs, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", metadata.ContractDocs{}, err
+	}
+
+	fset := token.NewFileSet()
+	pkgName := ""
+	typeFound := false
+
+	docs := metadata.ContractDocs{}
+	docs.Transactions = make(map[string]metadata.TransactionDocs)
+
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") || isSkipped(file, skip) {
+			continue
+		}
+
+		parsed, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+		if err != nil {
+			return "", metadata.ContractDocs{}, err
+		}
+
+		if pkgName == "" {
+			pkgName = parsed.Name.Name
+		} else if pkgName != parsed.Name.Name {
+			return "", metadata.ContractDocs{}, fmt.Errorf("multiple packages found in %s: %s and %s", dir, pkgName, parsed.Name.Name)
+		}
+
+		for _, decl := range parsed.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				if description, ok := typeDescription(d, typeName); ok {
+					typeFound = true
+					docs.Description = description
+				}
+			case *ast.FuncDecl:
+				if d.Recv == nil || !d.Name.IsExported() || receiverTypeName(d.Recv) != typeName {
+					continue
+				}
+
+				docs.Transactions[d.Name.Name] = funcDocs(fset, parsed.Comments, d)
+			}
+		}
+	}
+
+	if !typeFound {
+		return "", metadata.ContractDocs{}, fmt.Errorf("type %s not found in %s", typeName, dir)
+	}
+
+	return pkgName, docs, nil
+}
+
+func isSkipped(file string, skip []string) bool {
+	for _, s := range skip {
+		if filepath.Base(file) == filepath.Base(s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func typeDescription(decl *ast.GenDecl, typeName string) (string, bool) {
+	if decl.Tok != token.TYPE {
+		return "", false
+	}
+
+	for _, spec := range decl.Specs {
+		typeSpec := spec.(*ast.TypeSpec)
+
+		if typeSpec.Name.Name != typeName {
+			continue
+		}
+
+		doc := typeSpec.Doc
+
+		if doc == nil && len(decl.Specs) == 1 {
+			doc = decl.Doc
+		}
+
+		return commentText(doc), true
+	}
+
+	return "", false
+}
+
+func receiverTypeName(recv *ast.FieldList) string {
+	if len(recv.List) != 1 {
+		return ""
+	}
+
+	expr := recv.List[0].Type
+
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+
+	return ""
+}
+
+func funcDocs(fset *token.FileSet, comments []*ast.CommentGroup, decl *ast.FuncDecl) metadata.TransactionDocs {
+	txDocs := metadata.TransactionDocs{}
+	txDocs.Description = commentText(decl.Doc)
+
+	params := decl.Type.Params
+	prevEnd := params.Opening
+
+	for i, field := range params.List {
+		nextPos := params.Closing
+		if i+1 < len(params.List) {
+			nextPos = params.List[i+1].Pos()
+		}
+
+		description := paramDescription(fset, comments, field, prevEnd, nextPos)
+		prevEnd = field.End()
+
+		if len(field.Names) == 0 {
+			txDocs.Parameters = append(txDocs.Parameters, metadata.ParameterDocs{Description: description})
+		}
+
+		for _, name := range field.Names {
+			txDocs.Parameters = append(txDocs.Parameters, metadata.ParameterDocs{Name: name.Name, Description: description})
+		}
+	}
+
+	return txDocs
+}
+
+// paramDescription returns the text of the comments placed on the lines before a parameter
+// or, if there are none, of the comments following it on the same line. The parser does not
+// associate comments with parameters so they are matched by position, and comments are taken
+// individually as those following the previous parameter on its line are grouped with those
+// on the lines after
+func paramDescription(fset *token.FileSet, comments []*ast.CommentGroup, field *ast.Field, prevEnd token.Pos, nextPos token.Pos) string {
+	leading := &ast.CommentGroup{}
+	trailing := &ast.CommentGroup{}
+
+	prevLine := fset.Position(prevEnd).Line
+	fieldLine := fset.Position(field.End()).Line
+
+	for _, group := range comments {
+		for _, comment := range group.List {
+			line := fset.Position(comment.Pos()).Line
+
+			if comment.Pos() > prevEnd && comment.End() < field.Pos() && line > prevLine {
+				leading.List = append(leading.List, comment)
+			}
+
+			if comment.Pos() > field.End() && comment.End() < nextPos && line == fieldLine {
+				trailing.List = append(trailing.List, comment)
+			}
+		}
+	}
+
+	if len(leading.List) > 0 {
+		return commentText(leading)
+	}
+
+	return commentText(trailing)
+}
+
+func commentText(group *ast.CommentGroup) string {
+	return strings.TrimSpace(group.Text())
+}
+
+// generateSource returns formatted Go source declaring a GetContractDocs method on the
+// contract type which returns the passed docs
+func generateSource(pkgName string, typeName string, docs metadata.ContractDocs) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by contractdocs. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	fmt.Fprintf(&buf, "import %q\n\n", metadataImportPath)
+	fmt.Fprintf(&buf, "// GetContractDocs returns the documentation of %s taken from its source\n", typeName)
+	fmt.Fprintf(&buf, "func (c *%s) GetContractDocs() metadata.ContractDocs {\n", typeName)
+	fmt.Fprintf(&buf, "return metadata.ContractDocs{\n")
+	fmt.Fprintf(&buf, "Description: %q,\n", docs.Description)
+	fmt.Fprintf(&buf, "Transactions: map[string]metadata.TransactionDocs{\n")
+
+	names := []string{}
+	for name := range docs.Transactions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		txDocs := docs.Transactions[name]
+
+		fmt.Fprintf(&buf, "%q: {\n", name)
+		fmt.Fprintf(&buf, "Description: %q,\n", txDocs.Description)
+		fmt.Fprintf(&buf, "Parameters: []metadata.ParameterDocs{\n")
+
+		for _, param := range txDocs.Parameters {
+			fmt.Fprintf(&buf, "{Name: %q, Description: %q},\n", param.Name, param.Description)
+		}
+
+		fmt.Fprintf(&buf, "},\n},\n")
+	}
+
+	fmt.Fprintf(&buf, "},\n}\n}\n")
+
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,236 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// HELPERS
+// ================================
+
+const contractSource = `package assets
+
+import "github.com/hyperledger/fabric-contract-api-go/v2/contractapi"
+
+// AssetContract manages assets
+type AssetContract struct {
+	contractapi.Contract
+}
+
+// CreateAsset adds a new asset
+// to the world state
+func (ac *AssetContract) CreateAsset(
+	ctx contractapi.TransactionContextInterface,
+	// the unique ID of the asset
+	id string,
+	value int, // the value of the asset
+) error {
+	return nil
+}
+
+// UpdateAsset changes the value of an asset
+func (ac *AssetContract) UpdateAsset(
+	ctx contractapi.TransactionContextInterface,
+	id string, // the ID of the asset
+	// the new value
+	value int,
+	owner string,
+) error {
+	return nil
+}
+
+func (ac AssetContract) ReadAsset(ctx contractapi.TransactionContextInterface, id, owner string, _ bool) string {
+	return id
+}
+
+func (ac *AssetContract) helper(id string) {}
+
+func (o *Other) Ignored(id string) {}
+
+type Other struct{}
+`
+
+func writeSource(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, src := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0600)
+		require.NoError(t, err)
+	}
+
+	return dir
+}
+
+// ================================
+// TESTS
+// ================================
+
+func TestParseContractDocs(t *testing.T) {
+	var pkgName string
+	var docs metadata.ContractDocs
+	var err error
+
+	dir := writeSource(t, map[string]string{
+		"contract.go":      contractSource,
+		"contract_test.go": "package assets\n\nfunc (ac *AssetContract) TestOnly() {}\n",
+	})
+
+	pkgName, docs, err = parseContractDocs(dir, "AssetContract")
+	require.NoError(t, err, "should not error for valid source")
+	require.Equal(t, "assets", pkgName, "should return the package name")
+
+	expectedDocs := metadata.ContractDocs{
+		Description: "AssetContract manages assets",
+		Transactions: map[string]metadata.TransactionDocs{
+			"CreateAsset": {
+				Description: "CreateAsset adds a new asset\nto the world state",
+				Parameters: []metadata.ParameterDocs{
+					{Name: "ctx"},
+					{Name: "id", Description: "the unique ID of the asset"},
+					{Name: "value", Description: "the value of the asset"},
+				},
+			},
+			"UpdateAsset": {
+				Description: "UpdateAsset changes the value of an asset",
+				Parameters: []metadata.ParameterDocs{
+					{Name: "ctx"},
+					{Name: "id", Description: "the ID of the asset"},
+					{Name: "value", Description: "the new value"},
+					{Name: "owner"},
+				},
+			},
+			"ReadAsset": {
+				Parameters: []metadata.ParameterDocs{
+					{Name: "ctx"},
+					{Name: "id"},
+					{Name: "owner"},
+					{Name: "_"},
+				},
+			},
+		},
+	}
+	require.Equal(t, expectedDocs, docs, "should return docs of exported methods of the type only")
+
+	_, _, err = parseContractDocs(dir, "Missing")
+	require.EqualError(t, err, "type Missing not found in "+dir, "should error when type does not exist")
+
+	dir = writeSource(t, map[string]string{
+		"contract.go": contractSource,
+		"other.go":    "package other\n",
+	})
+	_, _, err = parseContractDocs(dir, "AssetContract")
+	require.ErrorContains(t, err, "multiple packages found in "+dir, "should error when multiple packages exist")
+
+	_, _, err = parseContractDocs(dir, "AssetContract", "other.go")
+	require.NoError(t, err, "should not parse skipped files")
+
+	dir = writeSource(t, map[string]string{
+		"contract.go": "package bad\n\nfunc {",
+	})
+	_, _, err = parseContractDocs(dir, "AssetContract")
+	require.Error(t, err, "should error when source cannot be parsed")
+
+	dir = writeSource(t, map[string]string{
+		"contract.go": "package grouped\n\ntype (\n\t// Grouped is in a group\n\tGrouped struct{}\n\tOther struct{}\n)\n\nconst x = 1\n\nfunc (g *x.Grouped) Bad() {}\n\nfunc (a Grouped, b Grouped) Bad2() {}\n",
+	})
+	_, docs, err = parseContractDocs(dir, "Grouped")
+	require.NoError(t, err)
+	require.Equal(t, metadata.ContractDocs{Description: "Grouped is in a group", Transactions: map[string]metadata.TransactionDocs{}}, docs, "should use doc of type spec within group")
+
+	_, _, err = parseContractDocs("[", "AssetContract")
+	require.Error(t, err, "should error for bad directory pattern")
+}
+
+func TestGenerateSource(t *testing.T) {
+	docs := metadata.ContractDocs{
+		Description: "AssetContract manages \"assets\"",
+		Transactions: map[string]metadata.TransactionDocs{
+			"ReadAsset": {
+				Parameters: []metadata.ParameterDocs{{Name: "id"}},
+			},
+			"CreateAsset": {
+				Description: "CreateAsset adds an asset",
+				Parameters:  []metadata.ParameterDocs{{Name: "ctx"}, {Name: "id", Description: "the ID"}},
+			},
+		},
+	}
+
+	src, err := generateSource("assets", "AssetContract", docs)
+	require.NoError(t, err, "should generate valid source")
+
+	expected := `// Code generated by contractdocs. DO NOT EDIT.
+
+package assets
+
+import "github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+
+// GetContractDocs returns the documentation of AssetContract taken from its source
+func (c *AssetContract) GetContractDocs() metadata.ContractDocs {
+	return metadata.ContractDocs{
+		Description: "AssetContract manages \"assets\"",
+		Transactions: map[string]metadata.TransactionDocs{
+			"CreateAsset": {
+				Description: "CreateAsset adds an asset",
+				Parameters: []metadata.ParameterDocs{
+					{Name: "ctx", Description: ""},
+					{Name: "id", Description: "the ID"},
+				},
+			},
+			"ReadAsset": {
+				Description: "",
+				Parameters: []metadata.ParameterDocs{
+					{Name: "id", Description: ""},
+				},
+			},
+		},
+	}
+}
+`
+	require.Equal(t, expected, string(src), "should generate method returning docs")
+
+	_, err = parser.ParseFile(token.NewFileSet(), "", src, 0)
+	require.NoError(t, err, "should generate parsable source")
+}
+
+func TestRun(t *testing.T) {
+	var err error
+
+	err = run([]string{})
+	require.EqualError(t, err, "-type is required", "should error when type not passed")
+
+	err = run([]string{"-unknown"})
+	require.Error(t, err, "should error for unknown flags")
+
+	dir := writeSource(t, map[string]string{"contract.go": contractSource})
+
+	err = run([]string{"-type", "Missing", "-dir", dir})
+	require.EqualError(t, err, "type Missing not found in "+dir, "should error when parse fails")
+
+	err = run([]string{"-type", "AssetContract", "-dir", dir})
+	require.NoError(t, err, "should not error for valid contract")
+
+	generated, err := os.ReadFile(filepath.Join(dir, "assetcontract_docs.go"))
+	require.NoError(t, err, "should write to default output file")
+
+	pkgName, docs, _ := parseContractDocs(dir, "AssetContract", "assetcontract_docs.go")
+	expected, _ := generateSource(pkgName, "AssetContract", docs)
+	require.Equal(t, string(expected), string(generated), "should write generated source")
+
+	err = run([]string{"-type", "AssetContract", "-dir", dir})
+	require.NoError(t, err, "should skip existing output file when regenerating")
+
+	err = run([]string{"-type", "AssetContract", "-dir", dir, "-output", filepath.Join(dir, "missing", "docs.go")})
+	require.Error(t, err, "should error when output cannot be written")
+}
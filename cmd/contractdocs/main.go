@@ -0,0 +1,60 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command contractdocs generates a GetContractDocs method for a contract from the doc
+// comments in its source so that the generated metadata of chaincode using the contract
+// contains the real names and descriptions of transaction parameters. The description of
+// the contract is taken from the doc comment of its type, the description of each
+// transaction from the doc comment of its method and the description of each parameter
+// from a comment placed before or after it in the method signature. It is intended to be
+// used with go generate:
+//
+//	//go:generate go run github.com/hyperledger/fabric-contract-api-go/v2/cmd/contractdocs -type SmartContract
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "contractdocs: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("contractdocs", flag.ContinueOnError)
+	typeName := flags.String("type", "", "name of the contract type to document (required)")
+	dir := flags.String("dir", ".", "directory containing the source of the contract")
+	output := flags.String("output", "", "file to write the generated source to (default <type>_docs.go in dir)")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *typeName == "" {
+		return errors.New("-type is required")
+	}
+
+	if *output == "" {
+		*output = filepath.Join(*dir, strings.ToLower(*typeName)+"_docs.go")
+	}
+
+	pkgName, docs, err := parseContractDocs(*dir, *typeName, *output)
+	if err != nil {
+		return err
+	}
+
+	src, err := generateSource(pkgName, *typeName, docs)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(*output, src, 0644) // #nosec G306 -- generated source is not sensitive
+}
@@ -0,0 +1,213 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/go-openapi/spec"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+)
+
+const (
+	systemContractName = "org.hyperledger.fabric"
+	componentRefPrefix = "#/components/schemas/"
+)
+
+// reservedNames are the names of variables used in generated methods, which parameters
+// must not shadow
+var reservedNames = map[string]bool{
+	"c": true, "args": true, "err": true, "result": true, "value": true, "values": true,
+	"optional": true, "transient": true, "transientData": true, "transactor": true, "ok": true,
+}
+
+// initialisms are upper cased when they form a part of a name
+var initialisms = map[string]bool{"api": true, "http": true, "id": true, "json": true, "uri": true, "url": true}
+
+// contractNames returns the names of the contracts in the metadata other than the system
+// contract, sorted
+func contractNames(ccMetadata metadata.ContractChaincodeMetadata) []string {
+	names := []string{}
+
+	for name := range ccMetadata.Contracts {
+		if name != systemContractName {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// skipNonJSONTransactions returns the metadata without the transactions of contracts which use
+// an encoding other than JSON, as generated clients format args and parse results as JSON, with
+// a message reporting each transaction skipped
+func skipNonJSONTransactions(ccMetadata metadata.ContractChaincodeMetadata) (metadata.ContractChaincodeMetadata, []string) {
+	skipped := []string{}
+	contracts := make(map[string]metadata.ContractMetadata, len(ccMetadata.Contracts))
+
+	for name, contract := range ccMetadata.Contracts {
+		contracts[name] = contract
+	}
+
+	for _, name := range contractNames(ccMetadata) {
+		contract := contracts[name]
+		transactions := []metadata.TransactionMetadata{}
+
+		for _, tx := range contract.Transactions {
+			if tx.Encoding != "" && tx.Encoding != "json" {
+				skipped = append(skipped, fmt.Sprintf("skipping transaction %s of contract %s as it uses %s encoding. Clients can only be generated for transactions using json encoding", tx.Name, name, tx.Encoding))
+				continue
+			}
+
+			transactions = append(transactions, tx)
+		}
+
+		contract.Transactions = transactions
+		contracts[name] = contract
+	}
+
+	ccMetadata.Contracts = contracts
+
+	return ccMetadata, skipped
+}
+
+// componentNames returns the names of the schemas in the components of the metadata, sorted
+func componentNames(ccMetadata metadata.ContractChaincodeMetadata) []string {
+	names := []string{}
+
+	for name := range ccMetadata.Components.Schemas {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// propertyNames returns the names of the properties of a component, sorted
+func propertyNames(object metadata.ObjectMetadata) []string {
+	names := []string{}
+
+	for name := range object.Properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func isRequired(object metadata.ObjectMetadata, property string) bool {
+	for _, required := range object.Required {
+		if required == property {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRequiredParam reports whether a parameter must be passed, which it must unless its
+// metadata says otherwise
+func isRequiredParam(param metadata.ParameterMetadata) bool {
+	return param.Required == nil || *param.Required
+}
+
+// optionalIndex returns the index of the first of the trailing parameters of a transaction which
+// may be omitted, not counting a variadic parameter, or the index of the variadic parameter or
+// number of parameters if there are none
+func optionalIndex(params []metadata.ParameterMetadata) int {
+	index := len(params)
+
+	if index > 0 {
+		if _, ok := variadicItems(params[index-1]); ok {
+			index--
+		}
+	}
+
+	for index > 0 && !isRequiredParam(params[index-1]) {
+		index--
+	}
+
+	return index
+}
+
+// isEvaluate reports whether a transaction is tagged to be evaluated rather than submitted
+func isEvaluate(tx metadata.TransactionMetadata) bool {
+	for _, tag := range tx.Tag {
+		if strings.EqualFold(tag, "evaluate") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// refName returns the name of the component a schema references. References within
+// components are the bare name of the component
+func refName(schema *spec.Schema) (string, bool) {
+	ref := schema.Ref.String()
+
+	if ref == "" {
+		return "", false
+	}
+
+	return strings.TrimPrefix(ref, componentRefPrefix), true
+}
+
+// exportedName converts a name to an exported identifier by removing characters that may
+// not appear in identifiers and upper casing the first letter of each part they separated,
+// or the whole part if it is an initialism
+func exportedName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var identifier strings.Builder
+
+	for _, part := range parts {
+		if initialisms[strings.ToLower(part)] {
+			identifier.WriteString(strings.ToUpper(part))
+			continue
+		}
+
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		identifier.WriteString(string(runes))
+	}
+
+	result := identifier.String()
+
+	if result == "" || unicode.IsDigit([]rune(result)[0]) {
+		result = "X" + result
+	}
+
+	return result
+}
+
+// unexportedName converts a name to an unexported identifier that is not a keyword or the
+// name of a variable used in generated methods
+func unexportedName(name string) string {
+	identifier := exportedName(name)
+
+	if strings.ToUpper(identifier) == identifier {
+		identifier = strings.ToLower(identifier)
+	} else {
+		runes := []rune(identifier)
+		runes[0] = unicode.ToLower(runes[0])
+		identifier = string(runes)
+	}
+
+	if token.IsKeyword(identifier) || reservedNames[identifier] || tsReservedNames[identifier] {
+		identifier += "Arg"
+	}
+
+	return identifier
+}
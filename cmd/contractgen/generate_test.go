@@ -0,0 +1,373 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/spec"
+	"github.com/hyperledger/fabric-contract-api-go/v2/contractapi"
+	"github.com/hyperledger/fabric-contract-api-go/v2/contractapi/contracttest"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/hyperledger/fabric-contract-api-go/v2/serializer"
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// HELPERS
+// ================================
+
+type owner struct {
+	Name string `json:"name"`
+}
+
+type asset struct {
+	ID       string            `json:"id"`
+	Value    int               `json:"value"`
+	Tags     []string          `json:"tags" metadata:",optional"`
+	Owner    *owner            `json:"owner"`
+	Created  time.Time         `json:"created"`
+	Document []byte            `json:"document"`
+	Labels   map[string]string `json:"labels" metadata:",optional"`
+}
+
+type AssetContract struct {
+	contractapi.Contract
+}
+
+func (ac *AssetContract) Create(ctx contractapi.TransactionContextInterface, a asset) error {
+	return nil
+}
+
+func (ac *AssetContract) Read(ctx contractapi.TransactionContextInterface, id string) (*asset, error) {
+	return nil, nil
+}
+
+func (ac *AssetContract) Count(ctx contractapi.TransactionContextInterface, owners []string, ratio float32) (int, error) {
+	return 0, nil
+}
+
+func (ac *AssetContract) Document(ctx contractapi.TransactionContextInterface, value string, err bool) ([]byte, error) {
+	return nil, nil
+}
+
+func (ac *AssetContract) Delete(ctx contractapi.TransactionContextInterface, ids ...string) (int, error) {
+	return len(ids), nil
+}
+
+func (ac *AssetContract) Transfer(ctx contractapi.TransactionContextInterface, id string, owners ...owner) error {
+	return nil
+}
+
+func (ac *AssetContract) Update(ctx contractapi.TransactionContextInterface, id string, value int, note string) error {
+	return nil
+}
+
+func (ac *AssetContract) Tag(ctx contractapi.TransactionContextInterface, id string, owner *owner, tags ...string) error {
+	return nil
+}
+
+func (ac *AssetContract) GetDefaultArgs() map[string][]interface{} {
+	return map[string][]interface{}{"Update": {0, ""}}
+}
+
+func (ac *AssetContract) GetEvaluateTransactions() []string {
+	return []string{"Read", "Count"}
+}
+
+func (ac *AssetContract) GetContractDocs() metadata.ContractDocs {
+	return metadata.ContractDocs{
+		Transactions: map[string]metadata.TransactionDocs{
+			"Read": {Description: "Read returns the asset.\nIt errors if it does not exist", Parameters: []metadata.ParameterDocs{{Name: "ctx"}, {Name: "id"}}},
+		},
+	}
+}
+
+type CBORContract struct {
+	contractapi.Contract
+}
+
+func (cc *CBORContract) Read(ctx contractapi.TransactionContextInterface, id string) (*asset, error) {
+	return nil, nil
+}
+
+func (cc *CBORContract) Exists(ctx contractapi.TransactionContextInterface, id string) (bool, error) {
+	return false, nil
+}
+
+func (cc *CBORContract) GetFunctionSerializers() map[string]serializer.TransactionSerializer {
+	return map[string]serializer.TransactionSerializer{"Read": new(serializer.CBORSerializer)}
+}
+
+type PrivateContract struct {
+	contractapi.Contract
+}
+
+func (pc *PrivateContract) Create(ctx contractapi.TransactionContextInterface, id string, secret contractapi.Transient[string], previous contractapi.Transient[*owner]) error {
+	return nil
+}
+
+func (pc *PrivateContract) Read(ctx contractapi.TransactionContextInterface, secret contractapi.Transient[*owner]) (string, error) {
+	return "", nil
+}
+
+func (pc *PrivateContract) GetEvaluateTransactions() []string {
+	return []string{"Read"}
+}
+
+func (pc *PrivateContract) GetContractDocs() metadata.ContractDocs {
+	return metadata.ContractDocs{
+		Transactions: map[string]metadata.TransactionDocs{
+			"Create": {Parameters: []metadata.ParameterDocs{{Name: "id"}, {Name: "secret"}, {Name: "previous-owner"}}},
+		},
+	}
+}
+
+func writeMetadata(t *testing.T) string {
+	t.Helper()
+
+	return writeContractMetadata(t, new(AssetContract))
+}
+
+func writeContractMetadata(t *testing.T, contract contractapi.ContractInterface) string {
+	t.Helper()
+
+	cc, err := contractapi.NewChaincode(contract)
+	require.NoError(t, err)
+
+	result := contracttest.NewHarness(cc).Evaluate("org.hyperledger.fabric:GetMetadata")
+	require.NoError(t, result.Err())
+
+	file := filepath.Join(t.TempDir(), "metadata.json")
+	require.NoError(t, os.WriteFile(file, result.Response.Payload, 0600))
+
+	return file
+}
+
+func typeCheck(t *testing.T, src []byte) *types.Package {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "client.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := config.Check("client", fset, []*ast.File{file}, nil)
+	require.NoError(t, err, "generated source should type check")
+
+	return pkg
+}
+
+func methodSignature(t *testing.T, pkg *types.Package, typeName string, method string) string {
+	t.Helper()
+
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(pkg.Scope().Lookup(typeName).Type()), false, pkg, method)
+	require.NotNil(t, obj, "should generate method %s", method)
+
+	return types.TypeString(obj.Type(), types.RelativeTo(pkg))
+}
+
+// ================================
+// TESTS
+// ================================
+
+func TestNames(t *testing.T) {
+	require.Equal(t, "AssetContract", exportedName("asset-contract"))
+	require.Equal(t, "OrgExampleAssets", exportedName("org.example.assets"))
+	require.Equal(t, "X1st", exportedName("1st"))
+	require.Equal(t, "X", exportedName("_"))
+	require.Equal(t, "AssetID", exportedName("asset_id"), "should upper case initialisms")
+
+	require.Equal(t, "id", unexportedName("ID"))
+	require.Equal(t, "assetID", unexportedName("AssetID"))
+	require.Equal(t, "typeArg", unexportedName("type"), "should not use keywords")
+	require.Equal(t, "errArg", unexportedName("err"), "should not use names of generated variables")
+	require.Equal(t, "deleteArg", unexportedName("delete"), "should not use TypeScript reserved words")
+
+	require.Equal(t, []string{"amount", "amount1", "param2"}, paramNames([]metadata.ParameterMetadata{{Name: "amount"}, {Name: "Amount"}, {Name: "param2"}}))
+
+	name, ok := refName(spec.RefSchema("#/components/schemas/asset"))
+	require.True(t, ok)
+	require.Equal(t, "asset", name, "should return name of component")
+	name, ok = refName(spec.RefSchema("owner"))
+	require.True(t, ok)
+	require.Equal(t, "owner", name, "should return bare name of component")
+	_, ok = refName(spec.StringProperty())
+	require.False(t, ok, "should return false when not a reference")
+
+	require.True(t, isEvaluate(metadata.TransactionMetadata{Tag: []string{"EVALUATE", "evaluate"}}))
+	require.False(t, isEvaluate(metadata.TransactionMetadata{Tag: []string{"SUBMIT", "submit"}}))
+}
+
+func TestGoType(t *testing.T) {
+	require.Equal(t, "interface{}", goType(nil))
+	require.Equal(t, "interface{}", goType(new(spec.Schema)))
+	require.Equal(t, "*Asset", goType(spec.RefSchema("#/components/schemas/asset")))
+	require.Equal(t, "string", goType(spec.StringProperty()))
+	require.Equal(t, "time.Time", goType(spec.DateTimeProperty()))
+	require.Equal(t, "[]byte", goType(spec.StrFmtProperty("byte")))
+	require.Equal(t, "int8", goType(spec.Int8Property()))
+	require.Equal(t, "int32", goType(spec.Int32Property()))
+	require.Equal(t, "int64", goType(spec.Int64Property()))
+	require.Equal(t, "int64", goType(new(spec.Schema).Typed("integer", "")))
+	require.Equal(t, "float32", goType(spec.Float32Property()))
+	require.Equal(t, "float64", goType(spec.Float64Property()))
+	require.Equal(t, "bool", goType(spec.BoolProperty()))
+	require.Equal(t, "[]string", goType(spec.ArrayProperty(spec.StringProperty())))
+	require.Equal(t, "[]interface{}", goType(spec.ArrayProperty(nil)))
+	require.Equal(t, "map[string]*Owner", goType(spec.MapProperty(spec.RefSchema("owner"))))
+	require.Equal(t, "map[string]interface{}", goType(new(spec.Schema).Typed("object", "")))
+	require.Equal(t, "interface{}", goType(new(spec.Schema).Typed("null", "")))
+}
+
+func TestTSType(t *testing.T) {
+	require.Equal(t, "unknown", tsType(nil, true))
+	require.Equal(t, "unknown", tsType(new(spec.Schema), true))
+	require.Equal(t, "Asset", tsType(spec.RefSchema("#/components/schemas/asset"), true))
+	require.Equal(t, "string", tsType(spec.DateTimeProperty(), true))
+	require.Equal(t, "Uint8Array", tsType(spec.StrFmtProperty("byte"), true))
+	require.Equal(t, "string", tsType(spec.StrFmtProperty("byte"), false), "should use base64 string for bytes within JSON")
+	require.Equal(t, "number", tsType(spec.Int64Property(), true))
+	require.Equal(t, "boolean", tsType(spec.BoolProperty(), true))
+	require.Equal(t, "string[]", tsType(spec.ArrayProperty(spec.StrFmtProperty("byte")), true))
+	require.Equal(t, "unknown[]", tsType(spec.ArrayProperty(nil), true))
+	require.Equal(t, "Record<string, Owner>", tsType(spec.MapProperty(spec.RefSchema("owner")), true))
+	require.Equal(t, "Record<string, unknown>", tsType(new(spec.Schema).Typed("object", ""), true))
+	require.Equal(t, "unknown", tsType(new(spec.Schema).Typed("null", ""), true))
+
+	require.Equal(t, "id", tsArg("id", spec.StringProperty()))
+	require.Equal(t, "JSON.stringify(value)", tsArg("value", spec.Int64Property()))
+	require.Equal(t, "result", tsResult(spec.StrFmtProperty("byte")))
+	require.Equal(t, "utf8Decoder.decode(result)", tsResult(spec.StringProperty()))
+	require.Equal(t, "JSON.parse(utf8Decoder.decode(result)) as Asset", tsResult(spec.RefSchema("asset")))
+	require.Equal(t, `"my-prop"`, tsPropertyName("my-prop"))
+}
+
+func TestRunGo(t *testing.T) {
+	metadataFile := writeMetadata(t)
+	output := filepath.Join(t.TempDir(), "client.go")
+
+	require.NoError(t, run([]string{"-metadata", metadataFile, "-package", "client", "-output", output}))
+
+	src, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	pkg := typeCheck(t, src)
+
+	require.Nil(t, pkg.Scope().Lookup("OrgHyperledgerFabricClient"), "should not generate client for system contract")
+	require.Equal(t, "struct{Created time.Time \"json:\\\"created\\\"\"; Document []byte \"json:\\\"document\\\"\"; ID string \"json:\\\"id\\\"\"; Labels map[string]string \"json:\\\"labels,omitempty\\\"\"; Owner *Owner \"json:\\\"owner\\\"\"; Tags []string \"json:\\\"tags,omitempty\\\"\"; Value int64 \"json:\\\"value\\\"\"}", types.TypeString(pkg.Scope().Lookup("Asset").Type().Underlying(), types.RelativeTo(pkg)), "should generate struct for component")
+
+	require.Equal(t, "func(transactor Transactor) *AssetContractClient", types.TypeString(pkg.Scope().Lookup("NewAssetContractClient").Type(), types.RelativeTo(pkg)))
+	require.Equal(t, "func(param0 *Asset) error", methodSignature(t, pkg, "AssetContractClient", "Create"))
+	require.Equal(t, "func(id string) (*Asset, error)", methodSignature(t, pkg, "AssetContractClient", "Read"))
+	require.Equal(t, "func(param0 []string, param1 float32) (int64, error)", methodSignature(t, pkg, "AssetContractClient", "Count"))
+	require.Equal(t, "func(param0 string, param1 bool) ([]byte, error)", methodSignature(t, pkg, "AssetContractClient", "Document"))
+	require.Equal(t, "func(param0 ...string) (int64, error)", methodSignature(t, pkg, "AssetContractClient", "Delete"))
+	require.Equal(t, "func(param0 string, param1 ...*Owner) error", methodSignature(t, pkg, "AssetContractClient", "Transfer"))
+	require.Contains(t, string(src), "values := []interface{}{param0}\n\tfor _, value := range param1 {\n\t\tvalues = append(values, value)\n\t}\n\n\targs, err := formatArgs(values...)", "should pass each value of variadic parameter as an arg")
+
+	require.Equal(t, "func(param0 string, param1 *int64, param2 *string) error", methodSignature(t, pkg, "AssetContractClient", "Update"), "should take optional parameters as nillable types")
+	require.Contains(t, string(src), "values := []interface{}{param0}\n\n\toptional, err := optionalArgs(false, param1, param2)\n\tif err != nil {\n\t\treturn err\n\t}\n\n\tvalues = append(values, optional...)\n\n\targs, err := formatArgs(values...)", "should omit optional args which are not set")
+	require.Equal(t, "func(param0 string, param1 *Owner, param2 ...string) error", methodSignature(t, pkg, "AssetContractClient", "Tag"))
+	require.Contains(t, string(src), "optional, err := optionalArgs(len(param2) > 0, param1)", "should require optional args preceding variadic values")
+	require.Contains(t, string(src), `c.transactor.EvaluateTransaction("AssetContract:Read", args...)`, "should evaluate transactions tagged evaluate")
+	require.Contains(t, string(src), `c.transactor.SubmitTransaction("AssetContract:Create", args...)`, "should submit transactions tagged submit")
+	require.Contains(t, string(src), "// Read evaluates the Read transaction of the AssetContract contract\n//\n// Read returns the asset.\n// It errors if it does not exist\n", "should include description of transaction")
+}
+
+func TestRunTypeScript(t *testing.T) {
+	metadataFile := writeMetadata(t)
+	output := filepath.Join(t.TempDir(), "client.ts")
+
+	require.NoError(t, run([]string{"-metadata", metadataFile, "-lang", "typescript", "-output", output}))
+
+	src, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	require.Contains(t, string(src), "export interface Asset {\n    created: string;\n    document: string;\n    id: string;\n    labels?: Record<string, string>;\n    owner: Owner;\n    tags?: string[];\n    value: number;\n}\n", "should generate interface for component")
+	require.Contains(t, string(src), "export class AssetContractClient {", "should generate class for contract")
+	require.Contains(t, string(src), "    async read(id: string): Promise<Asset> {\n        const result = await this.#contract.evaluateTransaction(\"AssetContract:Read\", id);\n        return JSON.parse(utf8Decoder.decode(result)) as Asset;\n    }\n", "should evaluate transactions tagged evaluate")
+	require.Contains(t, string(src), "    async create(param0: Asset): Promise<void> {\n        await this.#contract.submitTransaction(\"AssetContract:Create\", JSON.stringify(param0));\n    }\n", "should submit transactions tagged submit")
+	require.Contains(t, string(src), "     * It errors if it does not exist\n", "should include description of transaction")
+	require.Contains(t, string(src), "    async delete(...param0: string[]): Promise<number> {\n        const result = await this.#contract.submitTransaction(\"AssetContract:Delete\", ...param0);\n", "should pass each value of variadic parameter as an arg")
+	require.Contains(t, string(src), "    async transfer(param0: string, ...param1: Owner[]): Promise<void> {\n        await this.#contract.submitTransaction(\"AssetContract:Transfer\", param0, ...param1.map((value) => JSON.stringify(value)));\n", "should format each value of variadic parameter")
+	require.Contains(t, string(src), "    async update(param0: string, param1?: number, param2?: string): Promise<void> {\n        await this.#contract.submitTransaction(\"AssetContract:Update\", param0, ...optionalArgs(false, param1 === undefined ? undefined : JSON.stringify(param1), param2));\n", "should take optional parameters as optional")
+	require.Contains(t, string(src), "    async tag(param0: string, param1?: Owner, ...param2: string[]): Promise<void> {\n        await this.#contract.submitTransaction(\"AssetContract:Tag\", param0, ...optionalArgs(param2.length > 0, param1 === undefined ? undefined : JSON.stringify(param1)), ...param2);\n", "should require optional args preceding variadic values")
+	require.NotContains(t, string(src), "OrgHyperledgerFabric", "should not generate client for system contract")
+}
+
+func TestRunTransient(t *testing.T) {
+	metadataFile := writeContractMetadata(t, new(PrivateContract))
+	output := filepath.Join(t.TempDir(), "client.go")
+
+	require.NoError(t, run([]string{"-metadata", metadataFile, "-output", output}))
+
+	src, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	pkg := typeCheck(t, src)
+
+	require.Equal(t, "struct{Secret string; PreviousOwner *Owner}", types.TypeString(pkg.Scope().Lookup("PrivateContractCreateTransient").Type().Underlying(), types.RelativeTo(pkg)), "should generate struct for transient parameters with optional parameters nillable")
+	require.Equal(t, "func(transient PrivateContractCreateTransient, id string) error", methodSignature(t, pkg, "PrivateContractClient", "Create"))
+	require.Equal(t, "func(transient PrivateContractReadTransient) (string, error)", methodSignature(t, pkg, "PrivateContractClient", "Read"))
+	require.Contains(t, string(src), `transientData, err := formatTransient(map[string]interface{}{"secret": transient.Secret, "previous-owner": transient.PreviousOwner})`, "should pass transient parameters keyed by name")
+	require.Contains(t, string(src), `transactor.SubmitTransientTransaction("PrivateContract:Create", transientData, args...)`, "should submit with transient data")
+	require.Contains(t, string(src), `transactor.EvaluateTransientTransaction("PrivateContract:Read", transientData, args...)`, "should evaluate with transient data")
+
+	require.NoError(t, run([]string{"-metadata", metadataFile, "-lang", "typescript", "-output", output}))
+
+	src, err = os.ReadFile(output)
+	require.NoError(t, err)
+
+	require.Contains(t, string(src), "    async create(transient: { secret: string; \"previous-owner\"?: Owner }, id: string): Promise<void> {\n        await this.#contract.submit(\"PrivateContract:Create\", { arguments: [id], transientData: definedEntries({ secret: transient.secret, \"previous-owner\": transient[\"previous-owner\"] === undefined ? undefined : JSON.stringify(transient[\"previous-owner\"]) }) });\n", "should submit with transient data")
+	require.Contains(t, string(src), "        const result = await this.#contract.evaluate(\"PrivateContract:Read\", { arguments: [], transientData: definedEntries({ transient0: transient.transient0 === undefined ? undefined : JSON.stringify(transient.transient0) }) });\n", "should evaluate with transient data")
+}
+
+func TestRunSkipsNonJSONTransactions(t *testing.T) {
+	metadataFile := writeContractMetadata(t, new(CBORContract))
+	ccMetadata, err := readMetadata(metadataFile)
+	require.NoError(t, err)
+
+	filtered, skipped := skipNonJSONTransactions(ccMetadata)
+	require.Equal(t, []string{"skipping transaction Read of contract CBORContract as it uses cbor encoding. Clients can only be generated for transactions using json encoding"}, skipped, "should report skipped transactions")
+	require.Len(t, filtered.Contracts["CBORContract"].Transactions, 1)
+	require.Equal(t, "Exists", filtered.Contracts["CBORContract"].Transactions[0].Name, "should keep transactions using json encoding")
+	require.Len(t, ccMetadata.Contracts["CBORContract"].Transactions, 2, "should not modify metadata passed")
+
+	output := filepath.Join(t.TempDir(), "client.go")
+	require.NoError(t, run([]string{"-metadata", metadataFile, "-output", output}))
+
+	src, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	pkg := typeCheck(t, src)
+	require.Equal(t, "func(param0 string) (bool, error)", methodSignature(t, pkg, "CBORContractClient", "Exists"))
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(pkg.Scope().Lookup("CBORContractClient").Type()), false, pkg, "Read")
+	require.Nil(t, obj, "should not generate method for transaction using other encoding")
+}
+
+func TestRunErrors(t *testing.T) {
+	require.EqualError(t, run([]string{}), "-metadata is required")
+	require.Error(t, run([]string{"-unknown"}), "should error for unknown flags")
+
+	missing := filepath.Join(t.TempDir(), "missing.json")
+	require.ErrorContains(t, run([]string{"-metadata", missing}), "no such file", "should error when file cannot be read")
+
+	invalid := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte("bad"), 0600))
+	require.ErrorContains(t, run([]string{"-metadata", invalid}), "failed to parse metadata in "+invalid, "should error when metadata invalid")
+
+	require.EqualError(t, run([]string{"-metadata", writeMetadata(t), "-lang", "rust"}), "unknown language rust. Expected go or typescript")
+
+	require.ErrorContains(t, run([]string{"-metadata", writeMetadata(t), "-package", "bad package"}), "failed to format generated source.", "should error when source invalid")
+
+}
@@ -0,0 +1,465 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+)
+
+const goHelpers = `
+// Transactor submits and evaluates transactions of chaincode. The Contract type of the
+// Fabric Gateway client API meets this interface when it is created for the chaincode
+// without a contract name
+type Transactor interface {
+	SubmitTransaction(name string, args ...string) ([]byte, error)
+	EvaluateTransaction(name string, args ...string) ([]byte, error)
+}
+
+// TransientTransactor submits and evaluates transactions of chaincode passing transient data,
+// which transactions taking transient parameters require. The Contract type of the Fabric
+// Gateway client API can be adapted to this interface by calling its Submit and Evaluate
+// functions with the WithArguments and WithTransient options
+type TransientTransactor interface {
+	Transactor
+	SubmitTransientTransaction(name string, transient map[string][]byte, args ...string) ([]byte, error)
+	EvaluateTransientTransaction(name string, transient map[string][]byte, args ...string) ([]byte, error)
+}
+
+var errNotTransientTransactor = errors.New("transaction takes transient data which the transactor cannot pass as it is not a TransientTransactor")
+
+// formatArgs formats args in the form expected by the JSON transaction serializer
+func formatArgs(values ...interface{}) ([]string, error) {
+	args := make([]string, 0, len(values))
+
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			args = append(args, v)
+		case []byte:
+			args = append(args, string(v))
+		case time.Time:
+			args = append(args, v.Format(time.RFC3339))
+		default:
+			arg, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+
+			args = append(args, string(arg))
+		}
+	}
+
+	return args, nil
+}
+
+// formatTransient formats the values of transient parameters keyed by name in the form
+// expected by the JSON transaction serializer. Optional values which are not set are omitted
+func formatTransient(values map[string]interface{}) (map[string][]byte, error) {
+	transient := make(map[string][]byte, len(values))
+
+	for name, value := range values {
+		value, ok := setValue(value)
+		if !ok {
+			continue
+		}
+
+		formatted, err := formatArgs(value)
+		if err != nil {
+			return nil, err
+		}
+
+		transient[name] = []byte(formatted[0])
+	}
+
+	return transient, nil
+}
+
+// setValue returns the value of an optional parameter, with pointers dereferenced, and whether
+// it is set. Nil values are not set
+func setValue(value interface{}) (interface{}, bool) {
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil, false
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil, false
+		}
+
+		return v.Elem().Interface(), true
+	case reflect.Map, reflect.Slice:
+		return value, !v.IsNil()
+	}
+
+	return value, true
+}
+
+var errOptionalArgOmitted = errors.New("optional args may only be omitted if each following arg is omitted")
+
+// optionalArgs returns the values of optional parameters which are set, with pointers
+// dereferenced. As args are passed by position, an error is returned if a value which is
+// not set precedes one which is, or precedes further args when more is true
+func optionalArgs(more bool, optional ...interface{}) ([]interface{}, error) {
+	values := []interface{}{}
+	omitted := false
+
+	for _, value := range optional {
+		value, ok := setValue(value)
+
+		switch {
+		case !ok:
+			omitted = true
+		case omitted:
+			return nil, errOptionalArgOmitted
+		default:
+			values = append(values, value)
+		}
+	}
+
+	if more && omitted {
+		return nil, errOptionalArgOmitted
+	}
+
+	return values, nil
+}
+
+// parseResult parses a result formatted by the JSON transaction serializer into target
+func parseResult(result []byte, target interface{}) error {
+	switch t := target.(type) {
+	case *string:
+		*t = string(result)
+		return nil
+	case *[]byte:
+		*t = result
+		return nil
+	case *time.Time:
+		parsed, err := time.Parse(time.RFC3339, string(result))
+		*t = parsed
+		return err
+	default:
+		return json.Unmarshal(result, target)
+	}
+}
+`
+
+// generateGo returns formatted Go source for a client of the chaincode described by the metadata
+func generateGo(pkgName string, ccMetadata metadata.ContractChaincodeMetadata) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by contractgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	fmt.Fprintf(&buf, "import (\n%q\n%q\n%q\n%q\n)\n", "encoding/json", "errors", "reflect", "time")
+	buf.WriteString(goHelpers)
+
+	for _, name := range componentNames(ccMetadata) {
+		writeGoStruct(&buf, ccMetadata.Components.Schemas[name])
+	}
+
+	for _, name := range contractNames(ccMetadata) {
+		writeGoContract(&buf, name, ccMetadata.Contracts[name])
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated source. %s", err.Error())
+	}
+
+	return src, nil
+}
+
+func writeGoStruct(buf *bytes.Buffer, object metadata.ObjectMetadata) {
+	typeName := exportedName(object.ID)
+
+	fmt.Fprintf(buf, "\n// %s is the %s component of the chaincode metadata\n", typeName, object.ID)
+	fmt.Fprintf(buf, "type %s struct {\n", typeName)
+
+	for _, property := range propertyNames(object) {
+		schema := object.Properties[property]
+		tag := property
+
+		if !isRequired(object, property) {
+			tag += ",omitempty"
+		}
+
+		fmt.Fprintf(buf, "%s %s `json:%q`\n", exportedName(property), goType(&schema), tag)
+	}
+
+	fmt.Fprintf(buf, "}\n")
+}
+
+func writeGoContract(buf *bytes.Buffer, name string, contract metadata.ContractMetadata) {
+	typeName := exportedName(name) + "Client"
+
+	fmt.Fprintf(buf, "\n// %s calls the transactions of the %s contract\n", typeName, name)
+	fmt.Fprintf(buf, "type %s struct {\ntransactor Transactor\n}\n", typeName)
+	fmt.Fprintf(buf, "\n// New%s returns a client of the %s contract calling transactions using the transactor\n", typeName, name)
+	fmt.Fprintf(buf, "func New%s(transactor Transactor) *%s {\nreturn &%s{transactor: transactor}\n}\n", typeName, typeName, typeName)
+
+	for _, tx := range contract.Transactions {
+		if len(tx.Transient) > 0 {
+			writeGoTransientStruct(buf, name, tx)
+		}
+
+		writeGoMethod(buf, typeName, name, tx)
+	}
+}
+
+// writeGoTransientStruct writes the struct holding the values of the transient parameters of a
+// transaction. Optional parameters have types whose values may be nil, in which case they are
+// omitted from the transient data
+func writeGoTransientStruct(buf *bytes.Buffer, contractName string, tx metadata.TransactionMetadata) {
+	typeName := transientTypeName(contractName, tx)
+
+	fmt.Fprintf(buf, "\n// %s holds the transient data passed to the %s transaction of the %s contract\n", typeName, tx.Name, contractName)
+	fmt.Fprintf(buf, "type %s struct {\n", typeName)
+
+	for i, field := range transientFieldNames(tx.Transient) {
+		param := tx.Transient[i]
+		typ := goType(param.Schema)
+
+		if !isRequiredParam(param) {
+			typ = optionalGoType(param.Schema)
+		}
+
+		fmt.Fprintf(buf, "%s %s\n", field, typ)
+	}
+
+	fmt.Fprintf(buf, "}\n")
+}
+
+func transientTypeName(contractName string, tx metadata.TransactionMetadata) string {
+	return exportedName(contractName) + exportedName(tx.Name) + "Transient"
+}
+
+// transientFieldNames returns unique exported identifiers for the transient parameters of a
+// transaction
+func transientFieldNames(params []metadata.ParameterMetadata) []string {
+	names := []string{}
+	used := map[string]bool{}
+
+	for i, param := range params {
+		name := exportedName(param.Name)
+
+		if used[name] {
+			name = fmt.Sprintf("%s%d", name, i)
+		}
+
+		used[name] = true
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func writeGoMethod(buf *bytes.Buffer, typeName string, contractName string, tx metadata.TransactionMetadata) {
+	method := exportedName(tx.Name)
+	call, verb := "SubmitTransaction", "submits"
+
+	if isEvaluate(tx) {
+		call, verb = "EvaluateTransaction", "evaluates"
+	}
+
+	names := paramNames(tx.Parameters)
+	params := []string{}
+	optional := optionalIndex(tx.Parameters)
+	end, variadic := len(names), ""
+
+	if len(tx.Transient) > 0 {
+		params = append(params, "transient "+transientTypeName(contractName, tx))
+	}
+
+	for i, param := range tx.Parameters {
+		if items, ok := variadicItems(param); ok {
+			params = append(params, names[i]+" ..."+goType(items))
+			end, variadic = i, names[i]
+			continue
+		}
+
+		if i >= optional {
+			params = append(params, names[i]+" "+optionalGoType(param.Schema))
+			continue
+		}
+
+		params = append(params, names[i]+" "+goType(param.Schema))
+	}
+
+	returns := "error"
+	if tx.Returns.Schema != nil {
+		returns = fmt.Sprintf("(%s, error)", goType(tx.Returns.Schema))
+	}
+
+	fmt.Fprintf(buf, "\n// %s %s the %s transaction of the %s contract\n", method, verb, tx.Name, contractName)
+	writeComment(buf, tx.Description)
+	fmt.Fprintf(buf, "func (c *%s) %s(%s) %s {\n", typeName, method, strings.Join(params, ", "), returns)
+
+	zero := ""
+	if tx.Returns.Schema != nil {
+		fmt.Fprintf(buf, "var value %s\n\n", goType(tx.Returns.Schema))
+		zero = "value, "
+	}
+
+	formatted := strings.Join(names, ", ")
+
+	if optional < end || variadic != "" {
+		fmt.Fprintf(buf, "values := []interface{}{%s}\n", strings.Join(names[:optional], ", "))
+
+		if optional < end {
+			more := "false"
+			if variadic != "" {
+				more = fmt.Sprintf("len(%s) > 0", variadic)
+			}
+
+			fmt.Fprintf(buf, "\noptional, err := optionalArgs(%s, %s)\nif err != nil {\nreturn %serr\n}\n\nvalues = append(values, optional...)\n", more, strings.Join(names[optional:end], ", "), zero)
+		}
+
+		if variadic != "" {
+			fmt.Fprintf(buf, "for _, value := range %s {\nvalues = append(values, value)\n}\n", variadic)
+		}
+
+		buf.WriteString("\n")
+		formatted = "values..."
+	}
+
+	fmt.Fprintf(buf, "args, err := formatArgs(%s)\nif err != nil {\nreturn %serr\n}\n\n", formatted, zero)
+
+	transactor, callArgs := "c.transactor", "args..."
+
+	if len(tx.Transient) > 0 {
+		entries := []string{}
+
+		for i, field := range transientFieldNames(tx.Transient) {
+			entries = append(entries, fmt.Sprintf("%q: transient.%s", tx.Transient[i].Name, field))
+		}
+
+		fmt.Fprintf(buf, "transientData, err := formatTransient(map[string]interface{}{%s})\nif err != nil {\nreturn %serr\n}\n\n", strings.Join(entries, ", "), zero)
+		fmt.Fprintf(buf, "transactor, ok := c.transactor.(TransientTransactor)\nif !ok {\nreturn %serrNotTransientTransactor\n}\n\n", zero)
+		transactor, callArgs = "transactor", "transientData, args..."
+		call = strings.Replace(call, "Transaction", "TransientTransaction", 1)
+	}
+
+	if tx.Returns.Schema == nil {
+		fmt.Fprintf(buf, "_, err = %s.%s(%q, %s)\n\nreturn err\n}\n", transactor, call, contractName+":"+tx.Name, callArgs)
+		return
+	}
+
+	fmt.Fprintf(buf, "result, err := %s.%s(%q, %s)\nif err != nil {\nreturn value, err\n}\n\n", transactor, call, contractName+":"+tx.Name, callArgs)
+	fmt.Fprintf(buf, "err = parseResult(result, &value)\n\nreturn value, err\n}\n")
+}
+
+func writeComment(buf *bytes.Buffer, text string) {
+	if text == "" {
+		return
+	}
+
+	buf.WriteString("//\n")
+
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(buf, "// %s\n", line)
+	}
+}
+
+// paramNames returns unique identifiers for the parameters of a transaction
+func paramNames(params []metadata.ParameterMetadata) []string {
+	names := []string{}
+	used := map[string]bool{}
+
+	for i, param := range params {
+		name := unexportedName(param.Name)
+
+		if used[name] {
+			name = fmt.Sprintf("%s%d", name, i)
+		}
+
+		used[name] = true
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// variadicItems returns the schema of the items of a variadic parameter, each of which is
+// passed as a separate arg
+func variadicItems(param metadata.ParameterMetadata) (*spec.Schema, bool) {
+	if !param.Variadic || param.Schema == nil || param.Schema.Items == nil || param.Schema.Items.Schema == nil {
+		return nil, false
+	}
+
+	return param.Schema.Items.Schema, true
+}
+
+// optionalGoType returns the Go type of values described by a schema which may be nil, so
+// that nil can be used for values which are not passed
+func optionalGoType(schema *spec.Schema) string {
+	typ := goType(schema)
+
+	if strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") || typ == "interface{}" {
+		return typ
+	}
+
+	return "*" + typ
+}
+
+// goType returns the Go type of values described by a schema. Components are referenced
+// using pointers
+func goType(schema *spec.Schema) string {
+	if schema == nil {
+		return "interface{}"
+	}
+
+	if name, ok := refName(schema); ok {
+		return "*" + exportedName(name)
+	}
+
+	if len(schema.Type) == 0 {
+		return "interface{}"
+	}
+
+	switch schema.Type[0] {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			return "time.Time"
+		case "byte":
+			return "[]byte"
+		}
+
+		return "string"
+	case "integer":
+		switch schema.Format {
+		case "int8", "int16", "int32":
+			return schema.Format
+		}
+
+		return "int64"
+	case "number":
+		if schema.Format == "float" {
+			return "float32"
+		}
+
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if schema.Items != nil && schema.Items.Schema != nil {
+			return "[]" + goType(schema.Items.Schema)
+		}
+
+		return "[]interface{}"
+	case "object":
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			return "map[string]" + goType(schema.AdditionalProperties.Schema)
+		}
+
+		return "map[string]interface{}"
+	}
+
+	return "interface{}"
+}
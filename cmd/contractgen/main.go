@@ -0,0 +1,102 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Command contractgen generates a typed client for chaincode from its metadata, as returned
+// by the GetMetadata function of the org.hyperledger.fabric system contract or read from a
+// metadata file. The client has a type for each contract with a method for each transaction
+// which submits or evaluates the transaction depending on its tags, and a type for each of
+// the schemas in the components of the metadata. Go and TypeScript clients can be generated:
+//
+//	peer chaincode query -n mycc -c '{"Args":["org.hyperledger.fabric:GetMetadata"]}' -C mychannel > metadata.json
+//	go run github.com/hyperledger/fabric-contract-api-go/v2/cmd/contractgen -metadata metadata.json -package assets -output client.go
+//
+// The Go client calls transactions using a Transactor, which the Contract type of the Fabric
+// Gateway client API meets. The TypeScript client uses the Contract of the Fabric Gateway
+// client API for Node. Both format args and parse results as JSON, so transactions using
+// serializers of other encodings are skipped, with a message reporting each on standard error.
+// Trailing parameters which the metadata marks as not required may be omitted, by passing
+// nil in Go or leaving them undefined in TypeScript. Methods of transactions taking transient
+// parameters take the values of those parameters in a first argument, which the Go client
+// passes using a TransientTransactor.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "contractgen: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("contractgen", flag.ContinueOnError)
+	metadataFile := flags.String("metadata", "", "file containing the metadata of the chaincode (required)")
+	lang := flags.String("lang", "go", "language of the generated client, go or typescript")
+	pkgName := flags.String("package", "client", "package name of the generated Go client")
+	output := flags.String("output", "", "file to write the generated client to (default standard output)")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *metadataFile == "" {
+		return errors.New("-metadata is required")
+	}
+
+	ccMetadata, err := readMetadata(*metadataFile)
+	if err != nil {
+		return err
+	}
+
+	ccMetadata, skipped := skipNonJSONTransactions(ccMetadata)
+
+	for _, message := range skipped {
+		fmt.Fprintf(os.Stderr, "contractgen: %s\n", message)
+	}
+
+	var src []byte
+
+	switch *lang {
+	case "go":
+		src, err = generateGo(*pkgName, ccMetadata)
+	case "typescript", "ts":
+		src, err = generateTypeScript(ccMetadata)
+	default:
+		return fmt.Errorf("unknown language %s. Expected go or typescript", *lang)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+
+	return os.WriteFile(*output, src, 0644) // #nosec G306 -- generated source is not sensitive
+}
+
+func readMetadata(file string) (metadata.ContractChaincodeMetadata, error) {
+	ccMetadata := metadata.ContractChaincodeMetadata{}
+
+	data, err := os.ReadFile(file) // #nosec G304 -- reading the file named by the user is the purpose of the command
+	if err != nil {
+		return ccMetadata, err
+	}
+
+	if err := json.Unmarshal(data, &ccMetadata); err != nil {
+		return ccMetadata, fmt.Errorf("failed to parse metadata in %s. %s", file, err.Error())
+	}
+
+	return ccMetadata, nil
+}
@@ -0,0 +1,345 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-openapi/spec"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+)
+
+// tsReservedNames are reserved words of TypeScript which parameters must not use
+var tsReservedNames = map[string]bool{
+	"class": true, "const": true, "delete": true, "enum": true, "export": true, "extends": true,
+	"false": true, "finally": true, "function": true, "in": true, "instanceof": true, "let": true,
+	"new": true, "null": true, "super": true, "this": true, "throw": true, "true": true, "try": true,
+	"typeof": true, "var": true, "void": true, "while": true, "with": true, "yield": true,
+	"await": true, "catch": true, "do": true, "contract": true,
+}
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+const tsHelpers = `
+/** optionalArgs returns the args of optional parameters which are defined, throwing if one which is not precedes one which is, or precedes further args when more is true */
+function optionalArgs(more: boolean, ...optional: (string | Uint8Array | undefined)[]): (string | Uint8Array)[] {
+    const args: (string | Uint8Array)[] = [];
+    let omitted = false;
+    for (const arg of optional) {
+        if (arg === undefined) {
+            omitted = true;
+        } else if (omitted) {
+            throw new Error('optional args may only be omitted if each following arg is omitted');
+        } else {
+            args.push(arg);
+        }
+    }
+    if (more && omitted) {
+        throw new Error('optional args may only be omitted if each following arg is omitted');
+    }
+    return args;
+}
+
+/** definedEntries returns the entries of transient data whose values are defined */
+function definedEntries(values: Record<string, string | Uint8Array | undefined>): Record<string, string | Uint8Array> {
+    const defined: Record<string, string | Uint8Array> = {};
+    for (const [key, value] of Object.entries(values)) {
+        if (value !== undefined) {
+            defined[key] = value;
+        }
+    }
+    return defined;
+}
+`
+
+// generateTypeScript returns TypeScript source for a client of the chaincode described by the
+// metadata using the Fabric Gateway client API for Node
+func generateTypeScript(ccMetadata metadata.ContractChaincodeMetadata) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by contractgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "import { Contract } from '@hyperledger/fabric-gateway';\n\n")
+	fmt.Fprintf(&buf, "const utf8Decoder = new TextDecoder();\n")
+	buf.WriteString(tsHelpers)
+
+	for _, name := range componentNames(ccMetadata) {
+		writeTSInterface(&buf, ccMetadata.Components.Schemas[name])
+	}
+
+	for _, name := range contractNames(ccMetadata) {
+		writeTSClass(&buf, name, ccMetadata.Contracts[name])
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeTSInterface(buf *bytes.Buffer, object metadata.ObjectMetadata) {
+	typeName := exportedName(object.ID)
+
+	fmt.Fprintf(buf, "\n/** %s is the %s component of the chaincode metadata */\n", typeName, object.ID)
+	fmt.Fprintf(buf, "export interface %s {\n", typeName)
+
+	for _, property := range propertyNames(object) {
+		schema := object.Properties[property]
+		optional := ""
+
+		if !isRequired(object, property) {
+			optional = "?"
+		}
+
+		fmt.Fprintf(buf, "    %s%s: %s;\n", tsPropertyName(property), optional, tsType(&schema, false))
+	}
+
+	fmt.Fprintf(buf, "}\n")
+}
+
+func writeTSClass(buf *bytes.Buffer, name string, contract metadata.ContractMetadata) {
+	className := exportedName(name) + "Client"
+
+	fmt.Fprintf(buf, "\n/** %s calls the transactions of the %s contract */\n", className, name)
+	fmt.Fprintf(buf, "export class %s {\n", className)
+	fmt.Fprintf(buf, "    readonly #contract: Contract;\n\n")
+	fmt.Fprintf(buf, "    /** Creates a client of the %s contract using a contract created without a contract name */\n", name)
+	fmt.Fprintf(buf, "    constructor(contract: Contract) {\n        this.#contract = contract;\n    }\n")
+
+	for _, tx := range contract.Transactions {
+		writeTSMethod(buf, name, tx)
+	}
+
+	fmt.Fprintf(buf, "}\n")
+}
+
+func writeTSMethod(buf *bytes.Buffer, contractName string, tx metadata.TransactionMetadata) {
+	call, verb := "submitTransaction", "submits"
+
+	if isEvaluate(tx) {
+		call, verb = "evaluateTransaction", "evaluates"
+	}
+
+	names := paramNames(tx.Parameters)
+	params := []string{}
+	args := []string{}
+
+	if len(tx.Transient) > 0 {
+		params = append(params, "transient: "+tsTransientType(tx.Transient))
+	}
+
+	optional := optionalIndex(tx.Parameters)
+	optionalArgs := []string{}
+	more := "false"
+
+	for i, param := range tx.Parameters {
+		if items, ok := variadicItems(param); ok {
+			params = append(params, "..."+names[i]+": "+tsType(items, true)+"[]")
+			more = names[i] + ".length > 0"
+
+			if len(optionalArgs) > 0 {
+				args = append(args, "...optionalArgs("+more+", "+strings.Join(optionalArgs, ", ")+")")
+				optionalArgs = nil
+			}
+
+			args = append(args, tsVariadicArg(names[i], items))
+			continue
+		}
+
+		if i >= optional {
+			params = append(params, names[i]+"?: "+tsType(param.Schema, true))
+			optionalArgs = append(optionalArgs, tsOptionalArg(names[i], param.Schema))
+			continue
+		}
+
+		params = append(params, names[i]+": "+tsType(param.Schema, true))
+		args = append(args, tsArg(names[i], param.Schema))
+	}
+
+	if len(optionalArgs) > 0 {
+		args = append(args, "...optionalArgs("+more+", "+strings.Join(optionalArgs, ", ")+")")
+	}
+
+	returns := "void"
+	if tx.Returns.Schema != nil {
+		returns = tsType(tx.Returns.Schema, true)
+	}
+
+	fmt.Fprintf(buf, "\n    /**\n     * %s %s the %s transaction of the %s contract\n", lowerFirst(tx.Name), verb, tx.Name, contractName)
+
+	if tx.Description != "" {
+		fmt.Fprintf(buf, "     *\n")
+
+		for _, line := range strings.Split(tx.Description, "\n") {
+			fmt.Fprintf(buf, "     * %s\n", line)
+		}
+	}
+
+	fmt.Fprintf(buf, "     */\n")
+	fmt.Fprintf(buf, "    async %s(%s): Promise<%s> {\n", lowerFirst(tx.Name), strings.Join(params, ", "), returns)
+
+	callArgs := strings.Join(append([]string{strconv.Quote(contractName + ":" + tx.Name)}, args...), ", ")
+
+	if len(tx.Transient) > 0 {
+		call = strings.TrimSuffix(call, "Transaction")
+		callArgs = fmt.Sprintf("%q, { arguments: [%s], transientData: definedEntries({ %s }) }", contractName+":"+tx.Name, strings.Join(args, ", "), tsTransientEntries(tx.Transient))
+	}
+
+	if tx.Returns.Schema == nil {
+		fmt.Fprintf(buf, "        await this.#contract.%s(%s);\n    }\n", call, callArgs)
+		return
+	}
+
+	fmt.Fprintf(buf, "        const result = await this.#contract.%s(%s);\n", call, callArgs)
+	fmt.Fprintf(buf, "        return %s;\n    }\n", tsResult(tx.Returns.Schema))
+}
+
+// tsArg returns the expression formatting a parameter in the form expected by the JSON
+// transaction serializer
+func tsArg(name string, schema *spec.Schema) string {
+	if isRawString(schema) {
+		return name
+	}
+
+	return "JSON.stringify(" + name + ")"
+}
+
+// tsTransientType returns the type of the object holding the values of transient parameters.
+// Optional parameters may be left undefined, in which case they are omitted from the transient data
+func tsTransientType(params []metadata.ParameterMetadata) string {
+	properties := []string{}
+
+	for _, param := range params {
+		optional := ""
+
+		if !isRequiredParam(param) {
+			optional = "?"
+		}
+
+		properties = append(properties, tsPropertyName(param.Name)+optional+": "+tsType(param.Schema, true))
+	}
+
+	return "{ " + strings.Join(properties, "; ") + " }"
+}
+
+// tsTransientEntries returns the entries of the transient data formatting the value of each
+// transient parameter in the form expected by the JSON transaction serializer
+func tsTransientEntries(params []metadata.ParameterMetadata) string {
+	entries := []string{}
+
+	for _, param := range params {
+		value := "transient." + param.Name
+		if !tsIdentifier.MatchString(param.Name) {
+			value = "transient[" + strconv.Quote(param.Name) + "]"
+		}
+
+		arg := tsArg(value, param.Schema)
+		if !isRequiredParam(param) {
+			arg = tsOptionalArg(value, param.Schema)
+		}
+
+		entries = append(entries, tsPropertyName(param.Name)+": "+arg)
+	}
+
+	return strings.Join(entries, ", ")
+}
+
+// tsOptionalArg returns the expression formatting an optional parameter, which is undefined
+// if the parameter is
+func tsOptionalArg(name string, schema *spec.Schema) string {
+	arg := tsArg(name, schema)
+
+	if arg == name {
+		return name
+	}
+
+	return name + " === undefined ? undefined : " + arg
+}
+
+// tsVariadicArg returns the expression formatting each of the values of a variadic parameter
+// as a separate arg
+func tsVariadicArg(name string, items *spec.Schema) string {
+	if isRawString(items) {
+		return "..." + name
+	}
+
+	return "..." + name + ".map((value) => " + tsArg("value", items) + ")"
+}
+
+// tsResult returns the expression parsing a result formatted by the JSON transaction serializer
+func tsResult(schema *spec.Schema) string {
+	if isRawString(schema) && schema.Format == "byte" {
+		return "result"
+	}
+
+	if isRawString(schema) {
+		return "utf8Decoder.decode(result)"
+	}
+
+	return "JSON.parse(utf8Decoder.decode(result)) as " + tsType(schema, true)
+}
+
+// isRawString reports whether values of the schema are passed as strings without JSON encoding
+func isRawString(schema *spec.Schema) bool {
+	return schema != nil && schema.Ref.String() == "" && len(schema.Type) > 0 && schema.Type[0] == "string"
+}
+
+// tsType returns the TypeScript type of values described by a schema. Bytes are Uint8Array
+// when passed directly and base64 strings when within JSON
+func tsType(schema *spec.Schema, direct bool) string {
+	if schema == nil {
+		return "unknown"
+	}
+
+	if name, ok := refName(schema); ok {
+		return exportedName(name)
+	}
+
+	if len(schema.Type) == 0 {
+		return "unknown"
+	}
+
+	switch schema.Type[0] {
+	case "string":
+		if schema.Format == "byte" && direct {
+			return "Uint8Array"
+		}
+
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		if schema.Items != nil && schema.Items.Schema != nil {
+			return tsType(schema.Items.Schema, false) + "[]"
+		}
+
+		return "unknown[]"
+	case "object":
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			return "Record<string, " + tsType(schema.AdditionalProperties.Schema, false) + ">"
+		}
+
+		return "Record<string, unknown>"
+	}
+
+	return "unknown"
+}
+
+func tsPropertyName(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+
+	return strconv.Quote(name)
+}
+
+func lowerFirst(name string) string {
+	runes := []rune(exportedName(name))
+	runes[0] = unicode.ToLower(runes[0])
+
+	return string(runes)
+}
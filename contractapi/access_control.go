@@ -0,0 +1,67 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contractapi
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hyperledger/fabric-chaincode-go/v2/pkg/cid"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+)
+
+// ErrAccessDenied is the code of the ChaincodeError returned by Invoke when the client identity
+// does not satisfy the access policy of the function called. Use it as the target of errors.Is
+// to match the error
+const ErrAccessDenied ErrorCode = "ACCESS_DENIED"
+
+// accessDenied returns a ChaincodeError with the code ErrAccessDenied and its message formatted
+// according to the format specifier
+func accessDenied(format string, a ...interface{}) *ChaincodeError {
+	return NewChaincodeError(403, string(ErrAccessDenied), fmt.Sprintf(format, a...))
+}
+
+// checkAccess returns an error matching ErrAccessDenied if the client identity does not
+// satisfy the policy. Errors reading the client identity are wrapped by the returned error
+func checkAccess(policy metadata.AccessPolicy, ci cid.ClientIdentity, fn string) error {
+	if len(policy.MSPIDs) > 0 {
+		mspID, err := ci.GetMSPID()
+		if err != nil {
+			return fmt.Errorf("%w. %w", accessDenied("client MSP ID could not be read. Cannot call function %s", fn), err)
+		}
+
+		if !slices.Contains(policy.MSPIDs, mspID) {
+			return accessDenied("client MSP ID %s is not permitted to call function %s", mspID, fn)
+		}
+	}
+
+	if len(policy.OUs) > 0 {
+		cert, err := ci.GetX509Certificate()
+		if err != nil {
+			return fmt.Errorf("%w. %w", accessDenied("client certificate could not be read. Cannot call function %s", fn), err)
+		}
+
+		if cert == nil || !slices.ContainsFunc(cert.Subject.OrganizationalUnit, func(ou string) bool { return slices.Contains(policy.OUs, ou) }) {
+			return accessDenied("client does not have an organizational unit permitted to call function %s", fn)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(policy.Attributes)) {
+		value, found, err := ci.GetAttributeValue(name)
+		if err != nil {
+			return fmt.Errorf("%w. %w", accessDenied("client attribute %s could not be read. Cannot call function %s", name, fn), err)
+		}
+
+		if !found {
+			return accessDenied("client does not have attribute %s required to call function %s", name, fn)
+		}
+
+		if policy.Attributes[name] != "" && policy.Attributes[name] != value {
+			return accessDenied("client attribute %s does not have the value required to call function %s", name, fn)
+		}
+	}
+
+	return nil
+}
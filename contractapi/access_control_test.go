@@ -0,0 +1,193 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contractapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/hyperledger/fabric-protos-go-apiv2/msp"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// ================================
+// HELPERS
+// ================================
+
+type policyClientIdentity struct {
+	mockClientIdentity
+	mspID string
+	ous   []string
+	attrs map[string]string
+	err   error
+}
+
+func (pci *policyClientIdentity) GetMSPID() (string, error) {
+	return pci.mspID, pci.err
+}
+
+func (pci *policyClientIdentity) GetAttributeValue(name string) (string, bool, error) {
+	value, ok := pci.attrs[name]
+	return value, ok, pci.err
+}
+
+func (pci *policyClientIdentity) GetX509Certificate() (*x509.Certificate, error) {
+	return &x509.Certificate{Subject: pkix.Name{OrganizationalUnit: pci.ous}}, pci.err
+}
+
+func newSerializedIdentity(t *testing.T, mspID string, ous []string, attrs map[string]string) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	attrsJSON, err := json.Marshal(map[string]interface{}{"attrs": attrs})
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "user1", OrganizationalUnit: ous},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtraExtensions: []pkix.Extension{
+			{Id: asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 7, 8, 1}, Value: attrsJSON},
+		},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	creator, err := proto.Marshal(&msp.SerializedIdentity{
+		Mspid:   mspID,
+		IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	})
+	require.NoError(t, err)
+
+	return creator
+}
+
+type accessControlledContract struct {
+	myContract
+}
+
+func (acc *accessControlledContract) AdminOnly(value int) string {
+	return "admin function called"
+}
+
+func (acc *accessControlledContract) GetAccessPolicies() map[string]metadata.AccessPolicy {
+	return map[string]metadata.AccessPolicy{
+		"AdminOnly": {MSPIDs: []string{"Org1MSP"}, OUs: []string{"admin"}, Attributes: map[string]string{"role": "manager"}},
+	}
+}
+
+type badAccessControlledContract struct {
+	myContract
+}
+
+func (bacc *badAccessControlledContract) GetAccessPolicies() map[string]metadata.AccessPolicy {
+	return map[string]metadata.AccessPolicy{
+		"Missing": {MSPIDs: []string{"Org1MSP"}},
+	}
+}
+
+// ================================
+// TESTS
+// ================================
+
+func TestCheckAccess(t *testing.T) {
+	var err error
+
+	ci := &policyClientIdentity{mspID: "Org1MSP", ous: []string{"client", "department1"}, attrs: map[string]string{"role": "manager", "level": "3"}}
+
+	err = checkAccess(metadata.AccessPolicy{}, ci, "Fn")
+	require.NoError(t, err, "should allow any client for empty policy")
+
+	err = checkAccess(metadata.AccessPolicy{MSPIDs: []string{"Org2MSP", "Org1MSP"}, OUs: []string{"client"}, Attributes: map[string]string{"role": "manager", "level": ""}}, ci, "Fn")
+	require.NoError(t, err, "should allow client satisfying policy")
+
+	err = checkAccess(metadata.AccessPolicy{MSPIDs: []string{"Org2MSP"}}, ci, "Fn")
+	require.EqualError(t, err, "ACCESS_DENIED: client MSP ID Org1MSP is not permitted to call function Fn", "should deny client from other MSP")
+	require.ErrorIs(t, err, ErrAccessDenied, "should return access denied error")
+
+	err = checkAccess(metadata.AccessPolicy{OUs: []string{"admin"}}, ci, "Fn")
+	require.EqualError(t, err, "ACCESS_DENIED: client does not have an organizational unit permitted to call function Fn", "should deny client without OU")
+
+	err = checkAccess(metadata.AccessPolicy{Attributes: map[string]string{"other": ""}}, ci, "Fn")
+	require.EqualError(t, err, "ACCESS_DENIED: client does not have attribute other required to call function Fn", "should deny client without attribute")
+
+	err = checkAccess(metadata.AccessPolicy{Attributes: map[string]string{"role": "admin"}}, ci, "Fn")
+	require.EqualError(t, err, "ACCESS_DENIED: client attribute role does not have the value required to call function Fn", "should deny client with wrong attribute value")
+
+	ci.err = errors.New("identity error")
+
+	err = checkAccess(metadata.AccessPolicy{MSPIDs: []string{"Org1MSP"}}, ci, "Fn")
+	require.EqualError(t, err, "ACCESS_DENIED: client MSP ID could not be read. Cannot call function Fn. identity error", "should deny when MSP ID cannot be read")
+	require.ErrorIs(t, err, ErrAccessDenied, "should return access denied error when MSP ID cannot be read")
+	require.ErrorIs(t, err, ci.err, "should wrap error reading MSP ID")
+
+	err = checkAccess(metadata.AccessPolicy{OUs: []string{"client"}}, ci, "Fn")
+	require.EqualError(t, err, "ACCESS_DENIED: client certificate could not be read. Cannot call function Fn. identity error", "should deny when certificate cannot be read")
+	require.ErrorIs(t, err, ci.err, "should wrap error reading certificate")
+
+	err = checkAccess(metadata.AccessPolicy{Attributes: map[string]string{"role": ""}}, ci, "Fn")
+	require.EqualError(t, err, "ACCESS_DENIED: client attribute role could not be read. Cannot call function Fn. identity error", "should deny when attribute cannot be read")
+	require.ErrorIs(t, err, ci.err, "should wrap error reading attribute")
+}
+
+func TestAccessControlledContract(t *testing.T) {
+	_, err := NewChaincode(new(badAccessControlledContract))
+	require.EqualError(t, err, "access policy defined for Missing which is not a transaction function of contract badAccessControlledContract", "should error when policy defined for unknown function")
+
+	cc, err := NewChaincode(new(accessControlledContract))
+	require.NoError(t, err, "should not error for access controlled contract")
+
+	_, ok := cc.contracts["accessControlledContract"].functions["GetAccessPolicies"]
+	require.False(t, ok, "should not include GetAccessPolicies as a transaction")
+
+	for _, tx := range cc.metadata.Contracts["accessControlledContract"].Transactions {
+		if tx.Name == "AdminOnly" {
+			require.Equal(t, &metadata.AccessPolicy{MSPIDs: []string{"Org1MSP"}, OUs: []string{"admin"}, Attributes: map[string]string{"role": "manager"}}, tx.Access, "should include policy in metadata")
+		} else {
+			require.Nil(t, tx.Access, "should not include policy for functions without one")
+		}
+	}
+
+	invoke := func(creator []byte, args ...string) *peer.Response {
+		mockStub := NewMockChaincodeStub(t)
+		mockStub.EXPECT().GetFunctionAndParameters().Return(args[0], args[1:])
+		mockStub.EXPECT().GetArgs().Maybe().Return(toByteArgs(args...))
+		mockStub.EXPECT().GetCreator().Return(creator, nil)
+		return cc.Invoke(mockStub)
+	}
+
+	allowed := newSerializedIdentity(t, "Org1MSP", []string{"admin"}, map[string]string{"role": "manager"})
+	denied := newSerializedIdentity(t, "Org2MSP", []string{"admin"}, map[string]string{"role": "manager"})
+
+	response := invoke(allowed, "accessControlledContract:AdminOnly", "1")
+	require.Equal(t, "admin function called", string(response.Payload), "should call function when client satisfies policy")
+
+	response = invoke(denied, "accessControlledContract:AdminOnly", "not an int")
+	require.Equal(t, int32(403), response.Status, "should deny client not satisfying policy before decoding args")
+	require.Equal(t, "ACCESS_DENIED: client MSP ID Org2MSP is not permitted to call function AdminOnly", response.Message)
+
+	response = invoke(nil, "accessControlledContract:adminOnly", "1")
+	require.Equal(t, int32(403), response.Status, "should deny when client identity cannot be read")
+	require.Contains(t, response.Message, "ACCESS_DENIED: client identity could not be read. Cannot call function adminOnly. failed to get transaction invoker's identity", "should include error reading client identity")
+
+	response = invoke(denied, "accessControlledContract:ReturnsString")
+	require.Equal(t, int32(200), response.Status, "should allow any client to call functions without policies")
+}
@@ -0,0 +1,189 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contractapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/hyperledger/fabric-chaincode-go/v2/shim"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/hyperledger/fabric-contract-api-go/v2/serializer"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+)
+
+// ChaincodeError is an error that contract functions and transaction handlers can return to
+// control the response of a failed transaction. Status is used as the status of the response
+// and should be in the range 400-499 for errors caused by the client and 500-599 for errors
+// caused by the chaincode, values outside of 400-599 are replaced with 500. Code is a machine
+// readable identifier of the error that clients can branch on and Details an optional value
+// giving more information about the error.
+type ChaincodeError struct {
+	Status  int32
+	Code    string
+	Message string
+	Details interface{}
+}
+
+// ErrorCode is the code of a ChaincodeError. An ErrorCode used as the target of errors.Is
+// matches chaincode errors with that code
+type ErrorCode string
+
+// Error returns the code
+func (code ErrorCode) Error() string {
+	return string(code)
+}
+
+// chaincodeErrorPayload is the JSON form of a ChaincodeError sent as the payload of a response
+type chaincodeErrorPayload struct {
+	Status  int32           `json:"status"`
+	Code    string          `json:"code,omitempty"`
+	Message string          `json:"message"`
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+// NewChaincodeError creates a new chaincode error with the given status, code and message
+func NewChaincodeError(status int32, code string, message string) *ChaincodeError {
+	return &ChaincodeError{
+		Status:  status,
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error returns the message of the error prefixed with its code if it has one
+func (ce *ChaincodeError) Error() string {
+	if ce.Code == "" {
+		return ce.Message
+	}
+
+	return fmt.Sprintf("%s: %s", ce.Code, ce.Message)
+}
+
+// Is reports whether target is a ChaincodeError or ErrorCode with the same non-blank code, so
+// that errors created using WithDetails or WithMessage match the error they were created from
+// when using errors.Is
+func (ce *ChaincodeError) Is(target error) bool {
+	if code, ok := target.(ErrorCode); ok {
+		return code != "" && string(code) == ce.Code
+	}
+
+	var targetErr *ChaincodeError
+	if !errors.As(target, &targetErr) {
+		return false
+	}
+
+	return targetErr.Code != "" && targetErr.Code == ce.Code
+}
+
+// WithDetails returns a copy of the error with details set to the passed value. The value
+// must be JSON serializable
+func (ce *ChaincodeError) WithDetails(details interface{}) *ChaincodeError {
+	copied := *ce
+	copied.Details = details
+
+	return &copied
+}
+
+// WithMessage returns a copy of the error with its message formatted according to the
+// format specifier
+func (ce *ChaincodeError) WithMessage(format string, a ...interface{}) *ChaincodeError {
+	copied := *ce
+	copied.Message = fmt.Sprintf(format, a...)
+
+	return &copied
+}
+
+// GetStatus returns the status to use in the response for the error
+func (ce *ChaincodeError) GetStatus() int32 {
+	if ce.Status < shim.ERRORTHRESHOLD || ce.Status > 599 {
+		return shim.ERROR
+	}
+
+	return ce.Status
+}
+
+// reflectMetadata returns the metadata describing the error. If details is set then its
+// type is used to generate a schema for the details of the error with the options. An error
+// is returned if no schema can be generated for the type
+func (ce *ChaincodeError) reflectMetadata(components *metadata.ComponentMetadata, options metadata.SchemaOptions) (metadata.ErrorMetadata, error) {
+	errorMetadata := metadata.ErrorMetadata{}
+	errorMetadata.Status = ce.GetStatus()
+	errorMetadata.Code = ce.Code
+	errorMetadata.Description = ce.Message
+
+	if ce.Details != nil {
+		var err error
+		errorMetadata.Schema, err = metadata.GetSchemaWithOptions(reflect.TypeOf(ce.Details), components, options)
+
+		if err != nil {
+			return metadata.ErrorMetadata{}, fmt.Errorf("details of error have invalid type. %s", err.Error())
+		}
+	}
+
+	return errorMetadata, nil
+}
+
+// validateErrors checks that the errors declared by a contract are for its transaction functions
+// and have details of types that can be described in the metadata
+func validateErrors(transactionErrors map[string][]*ChaincodeError, ccn contractChaincodeContract, ns string) error {
+	for fn, ccErrs := range transactionErrors {
+		if _, ok := ccn.functions[fn]; !ok {
+			return fmt.Errorf("errors defined for %s which is not a transaction function of contract %s", fn, ns)
+		}
+
+		for _, ccErr := range ccErrs {
+			if ccErr == nil {
+				return fmt.Errorf("errors defined for %s of contract %s must not be nil", fn, ns)
+			}
+
+			if _, err := ccErr.reflectMetadata(new(metadata.ComponentMetadata), metadata.SchemaOptions{}); err != nil {
+				return fmt.Errorf("error %s of %s in contract %s is not valid. %s", ccErr.Code, fn, ns, err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// errorResponse converts an error into a response. If the error is or wraps a ChaincodeError
+// then the status of that error is used and the payload is the JSON form of that error, with
+// its details formatted to match the schema generated for them with the options. Otherwise a
+// response with status 500 is returned
+func errorResponse(err error, options metadata.SchemaOptions) *peer.Response {
+	var ccErr *ChaincodeError
+	if !errors.As(err, &ccErr) {
+		return shim.Error(err.Error())
+	}
+
+	errPayload := chaincodeErrorPayload{
+		Status:  ccErr.GetStatus(),
+		Code:    ccErr.Code,
+		Message: ccErr.Message,
+	}
+
+	var marshalErr error
+
+	if ccErr.Details != nil {
+		errPayload.Details, marshalErr = serializer.MarshalJSONWithOptions(ccErr.Details, options)
+	}
+
+	var payload []byte
+
+	if marshalErr == nil {
+		payload, marshalErr = json.Marshal(errPayload)
+	}
+
+	if marshalErr != nil {
+		return shim.Error(fmt.Sprintf("%s. Error details could not be marshalled. %s", err.Error(), marshalErr.Error()))
+	}
+
+	return &peer.Response{
+		Status:  ccErr.GetStatus(),
+		Message: err.Error(),
+		Payload: payload,
+	}
+}
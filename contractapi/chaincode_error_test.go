@@ -0,0 +1,125 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contractapi
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/hyperledger/fabric-chaincode-go/v2/shim"
+	"github.com/hyperledger/fabric-contract-api-go/v2/internal"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// TESTS
+// ================================
+
+func TestNewChaincodeError(t *testing.T) {
+	ccErr := NewChaincodeError(404, "NOT_FOUND", "not found")
+	require.Equal(t, &ChaincodeError{Status: 404, Code: "NOT_FOUND", Message: "not found"}, ccErr, "should set fields")
+}
+
+func TestChaincodeErrorError(t *testing.T) {
+	require.Equal(t, "NOT_FOUND: not found", NewChaincodeError(404, "NOT_FOUND", "not found").Error(), "should prefix message with code")
+	require.Equal(t, "not found", NewChaincodeError(404, "", "not found").Error(), "should use message when no code")
+}
+
+func TestChaincodeErrorIs(t *testing.T) {
+	ccErr := NewChaincodeError(404, "NOT_FOUND", "not found")
+
+	require.True(t, errors.Is(ccErr.WithMessage("asset %s not found", "asset1"), ccErr), "should match error with same code")
+	require.True(t, errors.Is(fmt.Errorf("wrapped. %w", ccErr.WithDetails("asset1")), ccErr), "should match wrapped error with same code")
+	require.False(t, errors.Is(NewChaincodeError(404, "OTHER", "not found"), ccErr), "should not match error with different code")
+	require.False(t, errors.Is(NewChaincodeError(404, "", "not found"), NewChaincodeError(404, "", "not found")), "should not match errors without codes")
+	require.False(t, ccErr.Is(errors.New("not found")), "should not match other errors")
+	require.True(t, errors.Is(fmt.Errorf("wrapped. %w", ccErr), ErrorCode("NOT_FOUND")), "should match error code")
+	require.False(t, errors.Is(ccErr, ErrorCode("OTHER")), "should not match different error code")
+	require.False(t, errors.Is(NewChaincodeError(404, "", "not found"), ErrorCode("")), "should not match blank error code")
+}
+
+func TestChaincodeErrorWithDetails(t *testing.T) {
+	ccErr := NewChaincodeError(404, "NOT_FOUND", "not found")
+	detailed := ccErr.WithDetails("asset1")
+
+	require.Equal(t, "asset1", detailed.Details, "should set details")
+	require.Nil(t, ccErr.Details, "should not update original error")
+}
+
+func TestChaincodeErrorWithMessage(t *testing.T) {
+	ccErr := NewChaincodeError(404, "NOT_FOUND", "not found")
+	formatted := ccErr.WithMessage("asset %s not found", "asset1")
+
+	require.Equal(t, "asset asset1 not found", formatted.Message, "should format message")
+	require.Equal(t, "not found", ccErr.Message, "should not update original error")
+}
+
+func TestChaincodeErrorGetStatus(t *testing.T) {
+	require.Equal(t, int32(404), NewChaincodeError(404, "", "").GetStatus(), "should use status in error range")
+	require.Equal(t, int32(599), NewChaincodeError(599, "", "").GetStatus(), "should use status in error range")
+	require.Equal(t, int32(shim.ERROR), NewChaincodeError(200, "", "").GetStatus(), "should use 500 for status below error range")
+	require.Equal(t, int32(shim.ERROR), NewChaincodeError(600, "", "").GetStatus(), "should use 500 for status above error range")
+}
+
+func TestChaincodeErrorReflectMetadata(t *testing.T) {
+	components := new(metadata.ComponentMetadata)
+
+	errorMetadata, err := NewChaincodeError(0, "UNKNOWN", "unknown").reflectMetadata(components, metadata.SchemaOptions{})
+	require.NoError(t, err)
+	require.Equal(t, metadata.ErrorMetadata{Status: 500, Code: "UNKNOWN", Description: "unknown"}, errorMetadata, "should return metadata without schema when no details")
+
+	errorMetadata, err = NewChaincodeError(400, "BAD", "bad").WithDetails([]string{}).reflectMetadata(components, metadata.SchemaOptions{})
+	require.NoError(t, err)
+	require.Equal(t, metadata.ErrorMetadata{Status: 400, Code: "BAD", Description: "bad", Schema: spec.ArrayProperty(spec.StringProperty())}, errorMetadata, "should return metadata with schema of details")
+
+	errorMetadata, err = NewChaincodeError(400, "BAD", "bad").WithDetails(int64(0)).reflectMetadata(components, metadata.SchemaOptions{Int64AsString: true})
+	require.NoError(t, err)
+	require.Equal(t, []string{"string"}, []string(errorMetadata.Schema.Type), "should describe details using schema options")
+
+	_, err = NewChaincodeError(400, "BAD", "bad").WithDetails(make(chan int)).reflectMetadata(components, metadata.SchemaOptions{})
+	require.ErrorContains(t, err, "details of error have invalid type. ", "should error when details cannot be described")
+}
+
+func TestValidateErrors(t *testing.T) {
+	ccn := contractChaincodeContract{functions: map[string]*internal.ContractFunction{"ReadAsset": nil}}
+
+	require.NoError(t, validateErrors(map[string][]*ChaincodeError{"ReadAsset": {NewChaincodeError(404, "NOT_FOUND", "not found").WithDetails("")}}, ccn, "myContract"))
+	require.EqualError(t, validateErrors(map[string][]*ChaincodeError{"Missing": nil}, ccn, "myContract"), "errors defined for Missing which is not a transaction function of contract myContract")
+	require.EqualError(t, validateErrors(map[string][]*ChaincodeError{"ReadAsset": {nil}}, ccn, "myContract"), "errors defined for ReadAsset of contract myContract must not be nil")
+	require.ErrorContains(t, validateErrors(map[string][]*ChaincodeError{"ReadAsset": {NewChaincodeError(400, "BAD", "bad").WithDetails(make(chan int))}}, ccn, "myContract"), "error BAD of ReadAsset in contract myContract is not valid. details of error have invalid type. ")
+}
+
+func TestErrorResponse(t *testing.T) {
+	AssertProtoEqual(t, shim.Error("some error"), errorResponse(errors.New("some error"), metadata.SchemaOptions{}))
+
+	ccErr := NewChaincodeError(403, "FORBIDDEN", "not allowed")
+	expected := &peer.Response{
+		Status:  403,
+		Message: "FORBIDDEN: not allowed",
+		Payload: []byte(`{"status":403,"code":"FORBIDDEN","message":"not allowed"}`),
+	}
+	AssertProtoEqual(t, expected, errorResponse(ccErr, metadata.SchemaOptions{}))
+
+	expected = &peer.Response{
+		Status:  500,
+		Message: "wrapped. not allowed",
+		Payload: []byte(`{"status":500,"message":"not allowed","details":{"user":"bob"}}`),
+	}
+	AssertProtoEqual(t, expected, errorResponse(fmt.Errorf("wrapped. %w", NewChaincodeError(0, "", "not allowed").WithDetails(map[string]string{"user": "bob"})), metadata.SchemaOptions{}))
+
+	expected = &peer.Response{
+		Status:  403,
+		Message: "FORBIDDEN: not allowed",
+		Payload: []byte(`{"status":403,"code":"FORBIDDEN","message":"not allowed","details":{"count":"1"}}`),
+	}
+	AssertProtoEqual(t, expected, errorResponse(ccErr.WithDetails(map[string]int64{"count": 1}), metadata.SchemaOptions{Int64AsString: true}))
+
+	response := errorResponse(ccErr.WithDetails(make(chan int)), metadata.SchemaOptions{})
+	require.Equal(t, int32(shim.ERROR), response.Status, "should return error when details cannot be marshalled")
+	require.Contains(t, response.Message, "FORBIDDEN: not allowed. Error details could not be marshalled. ", "should return message explaining details could not be marshalled")
+}
@@ -0,0 +1,156 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contractapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-chaincode-go/v2/shim"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+)
+
+// ImplicitOrgCollectionPrefix is the prefix of the names of the implicit private data collections
+// of organizations, which are followed by the MSP ID of the organization
+const ImplicitOrgCollectionPrefix = "_implicit_org_"
+
+// ImplicitOrgCollection returns the name of the implicit private data collection of the organization
+// with the MSP ID. Every organization has an implicit collection which only its peers hold
+func ImplicitOrgCollection(mspID string) string {
+	return ImplicitOrgCollectionPrefix + mspID
+}
+
+// Collection reads and writes the values of a private data collection. A collection returned by
+// a transaction context refuses to read or write values when the function called declares that it
+// only writes or only reads the collection
+type Collection struct {
+	name  string
+	stub  shim.ChaincodeStubInterface
+	read  bool
+	write bool
+}
+
+// NewCollection creates a collection which reads and writes the values of the named private data
+// collection using the stub without checking how the function called uses the collection
+func NewCollection(stub shim.ChaincodeStubInterface, name string) *Collection {
+	return &Collection{name: name, stub: stub, read: true, write: true}
+}
+
+// Name returns the name of the private data collection
+func (c *Collection) Name() string {
+	return c.name
+}
+
+// GetData returns the value stored under the key in the collection, or nil if there is none
+func (c *Collection) GetData(key string) ([]byte, error) {
+	if err := c.checkRead(); err != nil {
+		return nil, err
+	}
+
+	return c.stub.GetPrivateData(c.name, key)
+}
+
+// GetDataHash returns the hash of the value stored under the key in the collection. Unlike the
+// value, the hash can be read by peers of organizations which do not hold the collection
+func (c *Collection) GetDataHash(key string) ([]byte, error) {
+	if err := c.checkRead(); err != nil {
+		return nil, err
+	}
+
+	return c.stub.GetPrivateDataHash(c.name, key)
+}
+
+// GetDataByRange returns an iterator over the values stored under keys in the range startKey
+// (inclusive) to endKey (exclusive) in the collection
+func (c *Collection) GetDataByRange(startKey string, endKey string) (shim.StateQueryIteratorInterface, error) {
+	if err := c.checkRead(); err != nil {
+		return nil, err
+	}
+
+	return c.stub.GetPrivateDataByRange(c.name, startKey, endKey)
+}
+
+// PutData stores the value under the key in the collection
+func (c *Collection) PutData(key string, value []byte) error {
+	if err := c.checkWrite(); err != nil {
+		return err
+	}
+
+	return c.stub.PutPrivateData(c.name, key, value)
+}
+
+// DelData deletes the value stored under the key in the collection
+func (c *Collection) DelData(key string) error {
+	if err := c.checkWrite(); err != nil {
+		return err
+	}
+
+	return c.stub.DelPrivateData(c.name, key)
+}
+
+// PurgeData deletes the value stored under the key in the collection along with its history
+func (c *Collection) PurgeData(key string) error {
+	if err := c.checkWrite(); err != nil {
+		return err
+	}
+
+	return c.stub.PurgePrivateData(c.name, key)
+}
+
+func (c *Collection) checkRead() error {
+	if !c.read {
+		return fmt.Errorf("private data collection %s is not declared to be read by the transaction", c.name)
+	}
+
+	return nil
+}
+
+func (c *Collection) checkWrite() error {
+	if !c.write {
+		return fmt.Errorf("private data collection %s is not declared to be written by the transaction", c.name)
+	}
+
+	return nil
+}
+
+func isImplicitOrgCollection(name string) bool {
+	return strings.HasPrefix(name, ImplicitOrgCollectionPrefix) && len(name) > len(ImplicitOrgCollectionPrefix)
+}
+
+// validateCollections checks the collections declared by a contract and how its functions use them.
+// Functions may only use collections declared by the contract or implicit org collections, which
+// are not declared
+func validateCollections(collections []metadata.CollectionMetadata, usage map[string][]metadata.CollectionUsage, ccn contractChaincodeContract, ns string) error {
+	declared := make(map[string]bool)
+
+	for _, collection := range collections {
+		if collection.Name == "" || isImplicitOrgCollection(collection.Name) {
+			return fmt.Errorf("contract %s declares a collection with invalid name %q", ns, collection.Name)
+		}
+
+		if declared[collection.Name] {
+			return fmt.Errorf("contract %s declares collection %s more than once", ns, collection.Name)
+		}
+
+		declared[collection.Name] = true
+	}
+
+	for fn, fnUsage := range usage {
+		if _, ok := ccn.functions[fn]; !ok {
+			return fmt.Errorf("collections defined for %s which is not a transaction function of contract %s", fn, ns)
+		}
+
+		for _, collectionUsage := range fnUsage {
+			if !declared[collectionUsage.Name] && !isImplicitOrgCollection(collectionUsage.Name) {
+				return fmt.Errorf("%s of contract %s uses collection %s which is not declared by the contract", fn, ns, collectionUsage.Name)
+			}
+
+			if !collectionUsage.Read && !collectionUsage.Write {
+				return fmt.Errorf("%s of contract %s must read or write collection %s", fn, ns, collectionUsage.Name)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contractapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/v2/shim"
+	"github.com/hyperledger/fabric-contract-api-go/v2/internal"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// HELPERS
+// ================================
+
+var sharedCollection = metadata.CollectionMetadata{
+	Name:              "Org1AndOrg2",
+	Description:       "Shared by Org1 and Org2",
+	Policy:            "OR('Org1MSP.member', 'Org2MSP.member')",
+	RequiredPeerCount: 1,
+	MaxPeerCount:      2,
+}
+
+type collectionsContract struct {
+	Contract
+	collections []metadata.CollectionMetadata
+	usage       map[string][]metadata.CollectionUsage
+}
+
+func (cc *collectionsContract) Put(ctx CollectionTransactionContextInterface, collection string, key string, value string) error {
+	privateCollection, err := ctx.GetCollection(collection)
+	if err != nil {
+		return err
+	}
+
+	return privateCollection.PutData(key, []byte(value))
+}
+
+func (cc *collectionsContract) Get(ctx CollectionTransactionContextInterface, collection string, key string) (string, error) {
+	privateCollection, err := ctx.GetCollection(collection)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := privateCollection.GetData(key)
+
+	return string(value), err
+}
+
+func (cc *collectionsContract) GetCollections() []metadata.CollectionMetadata {
+	return cc.collections
+}
+
+func (cc *collectionsContract) GetTransactionCollections() map[string][]metadata.CollectionUsage {
+	return cc.usage
+}
+
+type namesakeCollectionsContract struct {
+	Contract
+}
+
+func (nc *namesakeCollectionsContract) GetCollections(ctx TransactionContextInterface, owner string) string {
+	return "collections of " + owner
+}
+
+func (nc *namesakeCollectionsContract) GetTransactionCollections(ctx TransactionContextInterface) string {
+	return "transaction collections"
+}
+
+// ================================
+// TESTS
+// ================================
+
+func TestImplicitOrgCollection(t *testing.T) {
+	require.Equal(t, "_implicit_org_Org1MSP", ImplicitOrgCollection("Org1MSP"), "should prefix MSP ID")
+	require.True(t, isImplicitOrgCollection("_implicit_org_Org1MSP"), "should be implicit org collection")
+	require.False(t, isImplicitOrgCollection(ImplicitOrgCollectionPrefix), "should not be implicit org collection without MSP ID")
+	require.False(t, isImplicitOrgCollection("Org1AndOrg2"), "should not be implicit org collection without prefix")
+}
+
+func TestCollection(t *testing.T) {
+	stub := NewMockChaincodeStub(t)
+
+	collection := NewCollection(stub, "Org1AndOrg2")
+	require.Equal(t, "Org1AndOrg2", collection.Name())
+
+	stub.EXPECT().GetPrivateData("Org1AndOrg2", "key").Return([]byte("value"), nil).Once()
+	value, err := collection.GetData("key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value, "should read value from collection")
+
+	stub.EXPECT().GetPrivateDataHash("Org1AndOrg2", "key").Return([]byte("hash"), nil).Once()
+	hash, err := collection.GetDataHash("key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("hash"), hash, "should read hash from collection")
+
+	stub.EXPECT().GetPrivateDataByRange("Org1AndOrg2", "a", "b").Return(nil, errors.New("range error")).Once()
+	_, err = collection.GetDataByRange("a", "b")
+	require.EqualError(t, err, "range error", "should read range from collection")
+
+	stub.EXPECT().PutPrivateData("Org1AndOrg2", "key", []byte("value")).Return(nil).Once()
+	require.NoError(t, collection.PutData("key", []byte("value")), "should write value to collection")
+
+	stub.EXPECT().DelPrivateData("Org1AndOrg2", "key").Return(nil).Once()
+	require.NoError(t, collection.DelData("key"), "should delete value from collection")
+
+	stub.EXPECT().PurgePrivateData("Org1AndOrg2", "key").Return(nil).Once()
+	require.NoError(t, collection.PurgeData("key"), "should purge value from collection")
+
+	collection.write = false
+	require.EqualError(t, collection.PutData("key", nil), "private data collection Org1AndOrg2 is not declared to be written by the transaction", "should refuse write when not declared")
+	require.EqualError(t, collection.DelData("key"), "private data collection Org1AndOrg2 is not declared to be written by the transaction", "should refuse delete when write not declared")
+	require.EqualError(t, collection.PurgeData("key"), "private data collection Org1AndOrg2 is not declared to be written by the transaction", "should refuse purge when write not declared")
+
+	collection.read = false
+	_, err = collection.GetData("key")
+	require.EqualError(t, err, "private data collection Org1AndOrg2 is not declared to be read by the transaction", "should refuse read when not declared")
+	_, err = collection.GetDataHash("key")
+	require.EqualError(t, err, "private data collection Org1AndOrg2 is not declared to be read by the transaction", "should refuse read of hash when not declared")
+	_, err = collection.GetDataByRange("a", "b")
+	require.EqualError(t, err, "private data collection Org1AndOrg2 is not declared to be read by the transaction", "should refuse read of range when not declared")
+}
+
+func TestValidateCollections(t *testing.T) {
+	ccn := contractChaincodeContract{functions: map[string]*internal.ContractFunction{"Put": nil}}
+
+	require.NoError(t, validateCollections([]metadata.CollectionMetadata{sharedCollection}, map[string][]metadata.CollectionUsage{
+		"Put": {{Name: "Org1AndOrg2", Write: true}, {Name: ImplicitOrgCollection("Org1MSP"), Read: true}},
+	}, ccn, "myContract"))
+
+	require.EqualError(t, validateCollections([]metadata.CollectionMetadata{{}}, nil, ccn, "myContract"), `contract myContract declares a collection with invalid name ""`)
+	require.EqualError(t, validateCollections([]metadata.CollectionMetadata{{Name: "_implicit_org_Org1MSP"}}, nil, ccn, "myContract"), `contract myContract declares a collection with invalid name "_implicit_org_Org1MSP"`)
+	require.EqualError(t, validateCollections([]metadata.CollectionMetadata{sharedCollection, sharedCollection}, nil, ccn, "myContract"), "contract myContract declares collection Org1AndOrg2 more than once")
+	require.EqualError(t, validateCollections(nil, map[string][]metadata.CollectionUsage{"Missing": nil}, ccn, "myContract"), "collections defined for Missing which is not a transaction function of contract myContract")
+	require.EqualError(t, validateCollections(nil, map[string][]metadata.CollectionUsage{"Put": {{Name: "Org1AndOrg2", Read: true}}}, ccn, "myContract"), "Put of contract myContract uses collection Org1AndOrg2 which is not declared by the contract")
+	require.EqualError(t, validateCollections([]metadata.CollectionMetadata{sharedCollection}, map[string][]metadata.CollectionUsage{"Put": {{Name: "Org1AndOrg2"}}}, ccn, "myContract"), "Put of contract myContract must read or write collection Org1AndOrg2")
+}
+
+func TestCollections(t *testing.T) {
+	cc := new(collectionsContract)
+	cc.collections = []metadata.CollectionMetadata{sharedCollection, {Name: "Org1Only", Policy: "OR('Org1MSP.member')"}}
+	cc.usage = map[string][]metadata.CollectionUsage{
+		"Get": {{Name: "Org1AndOrg2", Read: true}},
+	}
+
+	chaincode, err := NewChaincode(cc)
+	require.NoError(t, err)
+
+	_, ok := chaincode.contracts["collectionsContract"].functions["GetCollections"]
+	require.False(t, ok, "should not include GetCollections as a transaction")
+	_, ok = chaincode.contracts["collectionsContract"].functions["GetTransactionCollections"]
+	require.False(t, ok, "should not include GetTransactionCollections as a transaction")
+
+	contractMetadata := chaincode.metadata.Contracts["collectionsContract"]
+	require.Equal(t, cc.collections, contractMetadata.Collections, "should include collections in metadata of contract")
+	require.Equal(t, "Get", contractMetadata.Transactions[0].Name)
+	require.Equal(t, cc.usage["Get"], contractMetadata.Transactions[0].Collections, "should include collection usage in metadata of transaction")
+	require.Nil(t, contractMetadata.Transactions[1].Collections, "should not include collection usage of transaction not declaring any")
+	require.NoError(t, metadata.ValidateAgainstSchema(chaincode.metadata), "should produce valid metadata")
+
+	mockStub := newStub(t, "collectionsContract:Put", "Org1Only", "key", "value")
+	mockStub.EXPECT().PutPrivateData("Org1Only", "key", []byte("value")).Return(nil).Once()
+	require.Equal(t, int32(200), chaincode.Invoke(mockStub).Status, "should use any declared collection when function does not declare usage")
+
+	mockStub = newStub(t, "collectionsContract:Put", "_implicit_org_Org1MSP", "key", "value")
+	mockStub.EXPECT().PutPrivateData("_implicit_org_Org1MSP", "key", []byte("value")).Return(nil).Once()
+	require.Equal(t, int32(200), chaincode.Invoke(mockStub).Status, "should use implicit org collection without declaration")
+
+	mockStub = newStub(t, "collectionsContract:Put", "Undeclared", "key", "value")
+	AssertProtoEqual(t, shim.Error("private data collection Undeclared is not declared for use by the transaction"), chaincode.Invoke(mockStub))
+
+	mockStub = newStub(t, "collectionsContract:Get", "Org1AndOrg2", "key")
+	mockStub.EXPECT().GetPrivateData("Org1AndOrg2", "key").Return([]byte("value"), nil).Once()
+	AssertProtoEqual(t, shim.Success([]byte("value")), chaincode.Invoke(mockStub))
+
+	mockStub = newStub(t, "collectionsContract:Get", "Org1Only", "key")
+	AssertProtoEqual(t, shim.Error("private data collection Org1Only is not declared for use by the transaction"), chaincode.Invoke(mockStub))
+
+	cc.usage = map[string][]metadata.CollectionUsage{"Get": {{Name: "Missing", Read: true}}}
+	_, err = NewChaincode(cc)
+	require.EqualError(t, err, "Get of contract collectionsContract uses collection Missing which is not declared by the contract", "should error for invalid collections")
+
+	cc.collections = nil
+	cc.usage = nil
+	chaincode, err = NewChaincode(cc)
+	require.NoError(t, err)
+	require.Nil(t, chaincode.metadata.Contracts["collectionsContract"].Collections, "should not set collections when none declared")
+
+	mockStub = newStub(t, "collectionsContract:Put", "Org1Only", "key", "value")
+	AssertProtoEqual(t, shim.Error("private data collection Org1Only is not declared for use by the transaction"), chaincode.Invoke(mockStub))
+}
+
+func TestCollectionsInterfaceNames(t *testing.T) {
+	chaincode, err := NewChaincode(new(namesakeCollectionsContract))
+	require.NoError(t, err)
+
+	for _, fn := range []string{"GetCollections", "GetTransactionCollections"} {
+		_, ok := chaincode.contracts["namesakeCollectionsContract"].functions[fn]
+		require.True(t, ok, "should include %s as a transaction when contract does not implement collections interface", fn)
+	}
+
+	require.Nil(t, chaincode.metadata.Contracts["namesakeCollectionsContract"].Collections, "should not describe collections")
+	callContractFunctionAndCheckSuccess(t, chaincode, []string{"namesakeCollectionsContract:GetCollections", "Org1MSP"}, invokeType, "collections of Org1MSP")
+	callContractFunctionAndCheckSuccess(t, chaincode, []string{"namesakeCollectionsContract:GetTransactionCollections"}, invokeType, "transaction collections")
+}
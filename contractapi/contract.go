@@ -3,7 +3,11 @@
 
 package contractapi
 
-import "github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+import (
+	"github.com/hyperledger/fabric-contract-api-go/v2/contractapi/utils"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/hyperledger/fabric-contract-api-go/v2/serializer"
+)
 
 // IgnoreContractInterface extends ContractInterface and provides additional functionality
 // that can be used to mark which functions should not be accessible by invoking/querying
@@ -26,6 +30,117 @@ type EvaluationContractInterface interface {
 	GetEvaluateTransactions() []string
 }
 
+// DocumentedContractInterface extends ContractInterface and provides additional functionality
+// that can be used to improve metadata
+type DocumentedContractInterface interface {
+	// GetContractDocs returns a description of the contract and of its transaction functions,
+	// including the names and descriptions of their parameters. Go reflection cannot see
+	// parameter names so without this they are recorded in the metadata as param0, param1,
+	// ..., paramN. The contractdocs command can generate this function from the doc comments
+	// in the contract's source
+	GetContractDocs() metadata.ContractDocs
+}
+
+// ErrorsContractInterface extends ContractInterface and provides additional functionality
+// that can be used to improve metadata
+type ErrorsContractInterface interface {
+	// GetTransactionErrors returns the errors that functions of the contract may return
+	// keyed by function name. These are included in the metadata of the transaction so
+	// that they form part of the published interface of the chaincode. If an error has
+	// details set then the type of those details is used to describe them in the metadata
+	GetTransactionErrors() map[string][]*ChaincodeError
+}
+
+// AccessControlledContractInterface extends ContractInterface and provides additional
+// functionality that can be used to restrict which clients may call functions of the contract
+type AccessControlledContractInterface interface {
+	// GetAccessPolicies returns the policies of functions of the contract keyed by function
+	// name. When a function with a policy is called the policy is checked against the client
+	// identity before the before transaction is called or any arguments are decoded and a
+	// ChaincodeError with status 403 is returned if the client does not satisfy it. Policies
+	// are included in the metadata of the transaction. Functions without a policy may be called
+	// by any client
+	GetAccessPolicies() map[string]metadata.AccessPolicy
+}
+
+// EventsContractInterface extends ContractInterface and provides additional functionality
+// that can be used to improve metadata
+type EventsContractInterface interface {
+	// GetEvents returns the events that functions of the contract may emit keyed by event
+	// name. The value of each is a value of the type of the payload of the event, or nil if
+	// the event has no payload. These are included in the metadata of the contract with a
+	// schema generated from the type of their payload. Once events are declared EmitEvent and
+	// BatchEvent return an error for events not declared or payloads of another type
+	GetEvents() map[string]interface{}
+}
+
+// SerializerContractInterface extends ContractInterface and provides additional functionality
+// that can be used to change how the arguments and return values of functions are converted
+type SerializerContractInterface interface {
+	// GetTransactionSerializer returns the serializer used for the functions of the contract,
+	// including its unknown transaction, in place of the TransactionSerializer of the chaincode.
+	// The transaction context is still given the TransactionSerializer of the chaincode, so that
+	// state and events are encoded the same way by every contract. If nil is returned the
+	// TransactionSerializer of the chaincode is used
+	GetTransactionSerializer() serializer.TransactionSerializer
+}
+
+// FunctionSerializersContractInterface extends ContractInterface and provides additional
+// functionality that can be used to change how the arguments and return values of individual
+// functions are converted
+type FunctionSerializersContractInterface interface {
+	// GetFunctionSerializers returns the serializers used for functions of the contract keyed
+	// by function name. These take precedence over the serializer of the contract. The encoding
+	// of the serializer a function uses is recorded in the metadata of the transaction if the
+	// serializer is a serializer.EncodingTransactionSerializer
+	GetFunctionSerializers() map[string]serializer.TransactionSerializer
+}
+
+// DefaultArgsContractInterface extends ContractInterface and provides additional functionality
+// that can be used to add parameters to functions without breaking existing callers
+type DefaultArgsContractInterface interface {
+	// GetDefaultArgs returns default values for the last parameters of functions of the contract
+	// keyed by function name. A function with N defaults may be called without its last N args,
+	// in which case the defaults are used. Defaults must be assignable to the types of their
+	// parameters and are not copied, so should not be modified by the function. Trailing pointer
+	// parameters may also be omitted, in which case they are nil. Parameters which may be
+	// omitted are recorded in the metadata as not required
+	GetDefaultArgs() map[string][]interface{}
+}
+
+// CollectionsContractInterface extends ContractInterface and provides additional functionality
+// that can be used to declare the private data collections used by the contract
+type CollectionsContractInterface interface {
+	// GetCollections returns the private data collections used by functions of the contract. These
+	// are included in the metadata of the contract, from which the collections config of the
+	// chaincode can be generated. When a contract declares its collections the collections
+	// returned by the transaction context are limited to those declared and implicit org collections
+	GetCollections() []metadata.CollectionMetadata
+}
+
+// TransactionCollectionsContractInterface extends ContractInterface and provides additional
+// functionality that can be used to declare how functions of the contract use private data collections
+type TransactionCollectionsContractInterface interface {
+	// GetTransactionCollections returns the private data collections used by functions of the contract
+	// keyed by function name, with whether each reads or writes the collection. These are included in
+	// the metadata of the transaction. Collections returned by the transaction context during a call of
+	// the function are limited to those listed and implicit org collections, with only the reads or writes
+	// declared permitted. Functions not listed may use each collection declared by the contract
+	GetTransactionCollections() map[string][]metadata.CollectionUsage
+}
+
+// TransactionInfo describes the transaction being handled to before, after and unknown
+// transaction functions
+type TransactionInfo = utils.TransactionInfo
+
+// Transient is the type of a parameter of a contract function whose value is read from the
+// transient data of the transaction rather than from its args, for example for private data.
+// The transient data is keyed by the name of the parameter in the metadata, where it is listed
+// as a transient input of the transaction, and its value is converted to T and validated in
+// the same way as args. Transient parameters of pointer types may be left out of the transient
+// data, in which case their value is nil
+type Transient[T any] = utils.Transient[T]
+
 // ContractInterface defines functions a valid contract should have. Contracts to
 // be used in chaincode must implement this interface.
 type ContractInterface interface {
@@ -39,7 +154,8 @@ type ContractInterface interface {
 	// When the contract is used in creating a new chaincode this function is called
 	// and the unknown transaction returned is stored. The unknown function is then
 	// called in cases where an unknown function name is passed for a call to the
-	// contract via Init/Invoke of the chaincode. If nil is returned the
+	// contract via Init/Invoke of the chaincode. The unknown function may take a
+	// *TransactionInfo as its last parameter. If nil is returned the
 	// chaincode uses its default handling for unknown function names
 	GetUnknownTransaction() interface{}
 
@@ -47,8 +163,9 @@ type ContractInterface interface {
 	// When the contract is used in creating a new chaincode this function is called
 	// and the before transaction returned is stored. The before function is then
 	// called before the named function on each Init/Invoke of that contract via the
-	// chaincode. When called the before function is passed no extra args, only the
-	// the transaction context (if specified to take it). If nil is returned
+	// chaincode. When called the before function is passed the transaction context
+	// (if specified to take it) and a *TransactionInfo describing the transaction
+	// (if specified to take it as its last parameter). If nil is returned
 	// then no before function is called on Init/Invoke.
 	GetBeforeTransaction() interface{}
 
@@ -58,7 +175,9 @@ type ContractInterface interface {
 	// called after the named function on each Init/Invoke of that contract via the
 	// chaincode. When called the after function is passed the returned value of the
 	// named function and the transaction context (if the function takes the transaction
-	// context). If nil is returned then no after function is called on Init/
+	// context). It may also take a *TransactionInfo as its last parameter, in which
+	// case it is also called when the named function returns an error with the error
+	// set in the info. If nil is returned then no after function is called on Init/
 	// Invoke.
 	GetAfterTransaction() interface{}
 
@@ -89,7 +208,10 @@ type Contract struct {
 	UnknownTransaction        interface{}
 	BeforeTransaction         interface{}
 	AfterTransaction          interface{}
+	Interceptors              []Interceptor
 	TransactionContextHandler SettableTransactionContextInterface
+	TransactionSerializer     serializer.TransactionSerializer
+	FunctionSerializers       map[string]serializer.TransactionSerializer
 }
 
 // GetInfo returns the info about the contract for use in metadata
@@ -112,6 +234,22 @@ func (c *Contract) GetAfterTransaction() interface{} {
 	return c.AfterTransaction
 }
 
+// GetInterceptors returns the current set interceptors, may be nil
+func (c *Contract) GetInterceptors() []Interceptor {
+	return c.Interceptors
+}
+
+// GetTransactionSerializer returns the current set serializer of the contract, may be nil
+func (c *Contract) GetTransactionSerializer() serializer.TransactionSerializer {
+	return c.TransactionSerializer
+}
+
+// GetFunctionSerializers returns the current set serializers of functions of the contract,
+// may be nil
+func (c *Contract) GetFunctionSerializers() map[string]serializer.TransactionSerializer {
+	return c.FunctionSerializers
+}
+
 // GetName returns the name of the contract
 func (c *Contract) GetName() string {
 	return c.Name
@@ -14,6 +14,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode"
 
 	"github.com/hyperledger/fabric-chaincode-go/v2/pkg/cid"
@@ -27,6 +28,15 @@ import (
 
 type contractChaincodeContract struct {
 	info                      metadata.InfoMetadata
+	docs                      metadata.ContractDocs
+	errors                    map[string][]*ChaincodeError
+	policies                  map[string]metadata.AccessPolicy
+	events                    map[string]interface{}
+	collections               []metadata.CollectionMetadata
+	collectionUsage           map[string][]metadata.CollectionUsage
+	interceptors              []Interceptor
+	serializer                serializer.TransactionSerializer
+	functionSerializers       map[string]serializer.TransactionSerializer
 	functions                 map[string]*internal.ContractFunction
 	unknownTransaction        *internal.TransactionHandler
 	beforeTransaction         *internal.TransactionHandler
@@ -34,6 +44,45 @@ type contractChaincodeContract struct {
 	transactionContextHandler reflect.Type
 }
 
+// namedArgsFunction returns the name of the function called and whether it is called with named args,
+// which it is when the name passed is that of a function of the contract followed by NamedArgsSuffix
+func (ccn contractChaincodeContract) namedArgsFunction(fn string) (string, bool) {
+	if name, ok := strings.CutSuffix(fn, NamedArgsSuffix); ok {
+		if _, known := ccn.functions[toFirstRuneUpperCase(name)]; known {
+			return name, true
+		}
+	}
+
+	return fn, false
+}
+
+// functionSerializer returns the serializer of the named function of the contract, or that of the
+// contract if the function has none. It returns nil if neither the function nor the contract has
+// a serializer, in which case the serializer of the chaincode is used
+func (ccn contractChaincodeContract) functionSerializer(fn string) serializer.TransactionSerializer {
+	if fnSerializer, ok := ccn.functionSerializers[fn]; ok && fnSerializer != nil {
+		return fnSerializer
+	}
+
+	return ccn.serializer
+}
+
+// functionCollections returns the private data collections the named function of the contract may
+// use, which are those it declares it uses or else each of the collections declared by the contract
+func (ccn contractChaincodeContract) functionCollections(fn string) []metadata.CollectionUsage {
+	if usage, ok := ccn.collectionUsage[fn]; ok {
+		return usage
+	}
+
+	collections := []metadata.CollectionUsage{}
+
+	for _, collection := range ccn.collections {
+		collections = append(collections, metadata.CollectionUsage{Name: collection.Name, Read: true, Write: true})
+	}
+
+	return collections
+}
+
 // ContractChaincode a struct to meet the chaincode interface and provide routing of calls to contracts
 type ContractChaincode struct {
 	DefaultContract       string
@@ -41,6 +90,12 @@ type ContractChaincode struct {
 	metadata              metadata.ContractChaincodeMetadata
 	Info                  metadata.InfoMetadata
 	TransactionSerializer serializer.TransactionSerializer
+	Interceptors          []Interceptor
+	StrictArgs            bool
+	systemContract        *SystemContract
+	metadataSerializer    serializer.TransactionSerializer
+	metadataOnce          sync.Once
+	metadataErr           error
 }
 
 const (
@@ -54,6 +109,11 @@ const (
 	clientCertVariable    = "CORE_TLS_CLIENT_CERT_FILE"
 )
 
+// NamedArgsSuffix the suffix added to the name of a function to call it with named args, for
+// example Create@named. The function is passed a single JSON object arg with a property for
+// each parameter keyed by the name of the parameter in the metadata
+const NamedArgsSuffix = "@named"
+
 // NewChaincode creates a new chaincode using contracts passed. The function parses each
 // of the passed functions and stores details about their make-up to be used by the chaincode.
 // Public functions of the contracts are stored and are made callable in the chaincode. The function
@@ -62,10 +122,13 @@ const (
 // metadata is a JSON formatted MetadataContractChaincode containing each contract as a name and details
 // of the public functions and types they take in/return. It also outlines version details for contracts and the
 // chaincode. If these are blank strings this is set to latest. The names for parameters do not match those used
-// in the functions, instead they are recorded as param0, param1, ..., paramN. If there exists a file
+// in the functions, instead they are recorded as param0, param1, ..., paramN, unless the contract meets the
+// DocumentedContractInterface in which case the names and descriptions it returns are used. If there exists a file
 // contract-metadata/metadata.json then this will overwrite the generated metadata. The contents of this file must
 // validate against the schema. The transaction serializer for the contract is set to be the JSONSerializer by
-// default. This can be updated using by changing the TransactionSerializer property
+// default. This can be updated using by changing the TransactionSerializer property before the chaincode is started
+// or first invoked, when the metadata is generated again using the TransactionSerializer set. The metadata is not
+// changed after that
 func NewChaincode(contracts ...ContractInterface) (*ContractChaincode, error) {
 	ciMethods := getCiMethods()
 
@@ -92,6 +155,8 @@ func NewChaincode(contracts ...ContractInterface) (*ContractChaincode, error) {
 		return nil, err
 	}
 
+	cc.TransactionSerializer = new(serializer.JSONSerializer)
+
 	if err := cc.augmentMetadata(); err != nil {
 		return nil, err
 	}
@@ -99,14 +164,43 @@ func NewChaincode(contracts ...ContractInterface) (*ContractChaincode, error) {
 	metadataJSON, _ := json.Marshal(cc.metadata)
 
 	sysC.setMetadata(string(metadataJSON))
-
-	cc.TransactionSerializer = new(serializer.JSONSerializer)
+	cc.systemContract = sysC
+	cc.metadataSerializer = cc.TransactionSerializer
 
 	return cc, nil
 }
 
+// prepareMetadata generates the metadata of the chaincode again, once, if the TransactionSerializer
+// has been changed since the chaincode was created, so that the schemas and encodings of transactions
+// using it match the values it handles. The metadata is left alone after it is first prepared
+func (cc *ContractChaincode) prepareMetadata() error {
+	cc.metadataOnce.Do(func() {
+		if cc.TransactionSerializer == cc.metadataSerializer {
+			return
+		}
+
+		if err := cc.augmentMetadata(); err != nil {
+			cc.metadataErr = fmt.Errorf("failed to generate metadata for the transaction serializer. %s", err.Error())
+			return
+		}
+
+		if cc.systemContract != nil {
+			metadataJSON, _ := json.Marshal(cc.metadata)
+			cc.systemContract.setMetadata(string(metadataJSON))
+		}
+
+		cc.metadataSerializer = cc.TransactionSerializer
+	})
+
+	return cc.metadataErr
+}
+
 // Start starts the chaincode in the fabric shim
 func (cc *ContractChaincode) Start() error {
+	if err := cc.prepareMetadata(); err != nil {
+		return err
+	}
+
 	server, err := loadChaincodeServerConfig()
 	if err != nil {
 		return err
@@ -132,26 +226,30 @@ func (cc *ContractChaincode) Init(stub shim.ChaincodeStubInterface) *peer.Respon
 	return cc.Invoke(stub)
 }
 
-// Invoke is called to update or query the ledger in a proposal transaction. Takes the
-// args passed in the transaction and uses the first argument to identify the contract
-// and function of that contract to be called. The remaining args are then used as
-// parameters to that function. Args are converted from strings to the expected parameter
-// types of the function before being passed using the set transaction serializer for the ContractChaincode.
-// A transaction context is generated and is passed, if required, as the first parameter to the named function.
-// Before and after functions are called before and after the named function passed if the contract defines such
-// functions to exist. If the before function returns an error the named function is not called and its error
-// is returned in shim.Error. If the after function returns an error then its value is returned
-// to shim.Error otherwise the value returned from the named function is returned as shim.Success (formatted by
-// the transaction serializer). If an unknown name is passed as part of the first arg a shim.Error is returned.
-// If a valid name is passed but the function name is unknown then the contract with that name's
-// unknown function is called and its value returned as success or error depending on its return. If no
-// unknown function is defined for the contract then shim.Error is returned by Invoke. In the case of
-// unknown function names being passed (and the unknown handler returns an error) or the named function
-// returning an error then the after function if defined is not called. If the named function or unknown
-// function handler returns a non-error type then then the after transaction is sent this value. The same
-// transaction context is passed as a pointer to before, after, named and unknown functions on each Invoke.
-// If no contract name is passed then the default contract is used.
+// Invoke is called to update or query the ledger in a proposal transaction. The first arg
+// names the contract, or the default contract if no name is given, and the function to call,
+// and the remaining args are passed to the function. A transaction context is created for the
+// call and passed to each function taking it. The call proceeds in order:
+//
+//  1. The client identity is checked against the access policy of the function, if the
+//     contract declares one (see AccessControlledContractInterface).
+//  2. The args, and the transient data for parameters of type Transient, are converted using
+//     the serializer of the function (see FunctionSerializersContractInterface). Args may be
+//     passed by name instead (see NamedArgsSuffix) and trailing args omitted (see
+//     DefaultArgsContractInterface). Surplus args are ignored unless StrictArgs is set.
+//  3. The Interceptors of the chaincode and then those of the contract are called (see
+//     Interceptor) around the before function, the named function, or the unknown function
+//     if the name is not a function of the contract, and the after function (see
+//     ContractInterface). An error converting the args is returned from within them.
+//  4. The value returned is formatted using the serializer as the payload of the success
+//     response.
+//
+// Errors are returned in shim.Error unless they are or wrap a ChaincodeError, which sets the
+// status and payload of the response.
 func (cc *ContractChaincode) Invoke(stub shim.ChaincodeStubInterface) *peer.Response {
+	if err := cc.prepareMetadata(); err != nil {
+		return shim.Error(err.Error())
+	}
 
 	ns, fn, params := cc.getNamespaceFunctionAndParams(stub)
 
@@ -164,76 +262,233 @@ func (cc *ContractChaincode) Invoke(stub shim.ChaincodeStubInterface) *peer.Resp
 		return shim.Error("Blank function name passed")
 	}
 
+	fn, namedArgs := nsContract.namedArgsFunction(fn)
+
 	ctx := reflect.New(nsContract.transactionContextHandler)
 	ctxIface := ctx.Interface().(SettableTransactionContextInterface)
 	ctxIface.SetStub(stub)
 
-	ci, _ := cid.New(stub)
+	ci, ciErr := cid.New(stub)
 	ctxIface.SetClientIdentity(ci)
 
-	beforeTransaction := nsContract.beforeTransaction
+	txSerializer := cc.functionSerializer(nsContract, fn)
 
-	if beforeTransaction != nil {
-		_, _, errRes := beforeTransaction.Call(ctx, nil, nil)
+	if sctx, ok := ctxIface.(SerializerSettableTransactionContextInterface); ok {
+		sctx.SetTransactionSerializer(cc.TransactionSerializer)
+	}
 
-		if errRes != nil {
-			return shim.Error(errRes.Error())
-		}
+	if ectx, ok := ctxIface.(EventsSettableTransactionContextInterface); ok && nsContract.events != nil {
+		ectx.SetEvents(nsContract.events)
 	}
 
-	var successReturn string
-	var successIFace interface{}
-	var errorReturn error
+	if cctx, ok := ctxIface.(CollectionsSettableTransactionContextInterface); ok && nsContract.collections != nil {
+		cctx.SetCollections(nsContract.functionCollections(toFirstRuneUpperCase(fn)))
+	}
 
-	serializer := cc.TransactionSerializer
+	if policy, ok := nsContract.policies[toFirstRuneUpperCase(fn)]; ok {
+		if ciErr != nil {
+			return errorResponse(fmt.Errorf("%w. %w", accessDenied("client identity could not be read. Cannot call function %s", fn), ciErr), schemaOptions(txSerializer))
+		}
 
-	if contractFn, ok := nsContract.functions[toFirstRuneUpperCase(fn)]; !ok {
-		unknownTransaction := nsContract.unknownTransaction
-		if unknownTransaction == nil {
-			return shim.Error(fmt.Sprintf("Function %s not found in contract %s", fn, ns))
+		if err := checkAccess(policy, ci, fn); err != nil {
+			return errorResponse(err, schemaOptions(txSerializer))
 		}
+	}
 
-		successReturn, successIFace, errorReturn = unknownTransaction.Call(ctx, nil, serializer)
-	} else {
-		var transactionSchema *metadata.TransactionMetadata
+	info := &TransactionInfo{ContractName: ns, FunctionName: fn, RawArgs: params}
+
+	contractFn, knownFn := nsContract.functions[toFirstRuneUpperCase(fn)]
+
+	var transactionSchema *metadata.TransactionMetadata
+	var argsErr error
+
+	if knownFn {
+		info.FunctionName = toFirstRuneUpperCase(fn)
+		info.CallType = contractFn.GetCallType().String()
 
 		for i, v := range cc.metadata.Contracts[ns].Transactions {
-			if v.Name == fn {
+			if v.Name == info.FunctionName {
 				transactionSchema = &cc.metadata.Contracts[ns].Transactions[i]
 				break
 			}
 		}
 
-		successReturn, successIFace, errorReturn = contractFn.Call(ctx, transactionSchema, &cc.metadata.Components, serializer, params...)
+		info.Args, argsErr = cc.decodeArgs(stub, contractFn, transactionSchema, txSerializer, namedArgs, params)
+	}
+
+	invoke := chainInterceptors(cc.getInterceptors(nsContract, ctx), func(callCtx TransactionContextInterface, callInfo *TransactionInfo) (interface{}, error) {
+		ctxValue, err := contextValue(callCtx, ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if argsErr != nil {
+			return nil, argsErr
+		}
+
+		if knownFn {
+			return contractFn.Execute(ctxValue, callInfo.Args...)
+		}
+
+		if nsContract.unknownTransaction == nil {
+			return nil, &functionNotFoundError{fn: fn, ns: ns}
+		}
+
+		_, result, err := nsContract.unknownTransaction.CallWithInfo(ctxValue, nil, callInfo, nil)
+
+		return result, err
+	})
+
+	tctx, _ := ctxIface.(TransactionContextInterface)
+
+	result, err := invoke(tctx, info)
+
+	if err != nil {
+		return errorResponse(err, schemaOptions(txSerializer))
 	}
 
-	if errorReturn != nil {
-		return shim.Error(errorReturn.Error())
+	var successReturn []byte
+
+	if knownFn {
+		successReturn, err = formatReturn(contractFn, result, transactionSchema, &cc.metadata.Components, txSerializer)
+	} else {
+		successReturn, err = formatReturn(&nsContract.unknownTransaction.ContractFunction, result, nil, nil, txSerializer)
 	}
 
-	afterTransaction := nsContract.afterTransaction
+	if err != nil {
+		return errorResponse(err, schemaOptions(txSerializer))
+	}
 
-	if afterTransaction != nil {
-		_, _, errRes := afterTransaction.Call(ctx, successIFace, nil)
+	return shim.Success(successReturn)
+}
 
-		if errRes != nil {
-			return shim.Error(errRes.Error())
+// decodeArgs converts the args of a call to a known function, and its transient data if the function
+// takes transient parameters, to the types of the parameters of the function
+func (cc *ContractChaincode) decodeArgs(stub shim.ChaincodeStubInterface, fn *internal.ContractFunction, transactionSchema *metadata.TransactionMetadata, txSerializer serializer.TransactionSerializer, namedArgs bool, params []string) ([]interface{}, error) {
+	if cc.StrictArgs && !namedArgs {
+		if err := fn.CheckArgCount(len(params)); err != nil {
+			return nil, err
 		}
 	}
 
-	return shim.Success([]byte(successReturn))
+	var args []interface{}
+	var err error
+
+	if bytesSerializer, ok := txSerializer.(serializer.BytesTransactionSerializer); namedArgs {
+		args, err = decodeNamedArgs(fn, transactionSchema, &cc.metadata.Components, txSerializer, params)
+	} else if ok {
+		args, err = fn.DecodeBytesArgs(transactionSchema, &cc.metadata.Components, bytesSerializer, stub.GetArgs()[1:]...)
+	} else {
+		args, err = fn.DecodeArgs(transactionSchema, &cc.metadata.Components, txSerializer, params...)
+	}
+
+	if err != nil || !fn.TakesTransient() {
+		return args, err
+	}
+
+	transient, err := stub.GetTransient()
+	if err != nil {
+		return nil, err
+	}
+
+	return fn.DecodeTransientArgs(transactionSchema, &cc.metadata.Components, txSerializer, transient, args)
+}
+
+// decodeNamedArgs converts the args of a function called with named args to the types of its parameters.
+// The function must be passed a single JSON object arg with a property for each parameter, named as in
+// the metadata and of a JSON type allowed by the schema of the parameter. Properties with string values are
+// converted by the serializer from the string, others from their JSON, so that they are converted and
+// validated as they would be if passed in order. Named args cannot be used with serializers producing an
+// encoding other than JSON
+func decodeNamedArgs(fn *internal.ContractFunction, transactionSchema *metadata.TransactionMetadata, components *metadata.ComponentMetadata, txSerializer serializer.TransactionSerializer, params []string) ([]interface{}, error) {
+	if ets, ok := txSerializer.(serializer.EncodingTransactionSerializer); ok && ets.Encoding() != "json" {
+		return nil, fmt.Errorf("named args cannot be used with functions using %s encoding", ets.Encoding())
+	}
+
+	if len(params) != 1 {
+		return nil, fmt.Errorf("functions called with named args must be passed a single JSON object arg. Received %d args", len(params))
+	}
+
+	properties := map[string]json.RawMessage{}
+
+	if err := json.Unmarshal([]byte(params[0]), &properties); err != nil {
+		return nil, fmt.Errorf("named args must be a JSON object. %s", err.Error())
+	}
+
+	return fn.DecodeNamedArgs(transactionSchema, components, txSerializer, properties)
+}
+
+// formatReturn formats the result of a function as the payload of the success response, using
+// bytes directly if the serializer supports it
+func formatReturn(fn *internal.ContractFunction, result interface{}, transactionSchema *metadata.TransactionMetadata, components *metadata.ComponentMetadata, txSerializer serializer.TransactionSerializer) ([]byte, error) {
+	if bytesSerializer, ok := txSerializer.(serializer.BytesTransactionSerializer); ok {
+		return fn.FormatReturnBytes(result, transactionSchema, components, bytesSerializer)
+	}
+
+	str, err := fn.FormatReturn(result, transactionSchema, components, txSerializer)
+
+	return []byte(str), err
+}
+
+// getInterceptors returns the interceptors to call around a function of the contract. Those of
+// the chaincode are called first, then those of the contract and then the before and after
+// transactions of the contract
+func (cc *ContractChaincode) getInterceptors(nsContract contractChaincodeContract, ctx reflect.Value) []Interceptor {
+	interceptors := append([]Interceptor{}, cc.Interceptors...)
+	interceptors = append(interceptors, nsContract.interceptors...)
+
+	if nsContract.beforeTransaction != nil {
+		interceptors = append(interceptors, beforeInterceptor(nsContract.beforeTransaction, ctx))
+	}
+
+	if nsContract.afterTransaction != nil {
+		interceptors = append(interceptors, afterInterceptor(nsContract.afterTransaction, ctx))
+	}
+
+	return interceptors
+}
+
+// FunctionSerializer returns the serializer Invoke uses to convert the args and success response of
+// the function, named in the form contract:function as when calling the chaincode. This is the
+// serializer of the function if its contract defines one, else that of the contract if it defines
+// one, else the TransactionSerializer of the chaincode
+func (cc *ContractChaincode) FunctionSerializer(nsFn string) serializer.TransactionSerializer {
+	ns, fn := cc.splitNamespaceFunction(nsFn)
+
+	nsContract, ok := cc.contracts[ns]
+	if !ok {
+		return cc.TransactionSerializer
+	}
+
+	fn, _ = nsContract.namedArgsFunction(fn)
+
+	return cc.functionSerializer(nsContract, fn)
+}
+
+func (cc *ContractChaincode) functionSerializer(nsContract contractChaincodeContract, fn string) serializer.TransactionSerializer {
+	if txSerializer := nsContract.functionSerializer(toFirstRuneUpperCase(fn)); txSerializer != nil {
+		return txSerializer
+	}
+
+	return cc.TransactionSerializer
 }
 
 func (cc *ContractChaincode) getNamespaceFunctionAndParams(stub shim.ChaincodeStubInterface) (string, string, []string) {
 	nsFn, params := stub.GetFunctionAndParameters()
+	ns, fn := cc.splitNamespaceFunction(nsFn)
+
+	return ns, fn, params
+}
 
+func (cc *ContractChaincode) splitNamespaceFunction(nsFn string) (string, string) {
 	nsIndex := strings.LastIndex(nsFn, ":")
 
 	if nsIndex == -1 {
-		return cc.DefaultContract, nsFn, params
+		return cc.DefaultContract, nsFn
 	}
 
-	return nsFn[:nsIndex], nsFn[nsIndex+1:], params
+	return nsFn[:nsIndex], nsFn[nsIndex+1:]
 }
 
 func (cc *ContractChaincode) addContract(contract ContractInterface, excludeFuncs []string) error {
@@ -261,9 +516,29 @@ func (cc *ContractChaincode) addContract(contract ContractInterface, excludeFunc
 		ccn.info.Title = ns
 	}
 
+	if dci, ok := contract.(DocumentedContractInterface); ok {
+		ccn.docs = dci.GetContractDocs()
+
+		if ccn.info.Description == "" {
+			ccn.info.Description = ccn.docs.Description
+		}
+	}
+
 	contractType := reflect.PointerTo(reflect.TypeOf(contract).Elem())
 	contractValue := reflect.ValueOf(contract).Elem().Addr()
 
+	if ici, ok := contract.(InterceptedContractInterface); ok {
+		ccn.interceptors = ici.GetInterceptors()
+	}
+
+	if eci, ok := contract.(EventsContractInterface); ok {
+		ccn.events = eci.GetEvents()
+
+		if err := validateEvents(ccn.events, ns); err != nil {
+			return err
+		}
+	}
+
 	ut := contract.GetUnknownTransaction()
 
 	if ut != nil {
@@ -303,11 +578,13 @@ func (cc *ContractChaincode) addContract(contract ContractInterface, excludeFunc
 		evaluateMethods = eci.GetEvaluateTransactions()
 	}
 
+	optionalCiMethods := getOptionalCiMethods(contract)
+
 	for i := 0; i < contractType.NumMethod(); i++ {
 		typeMethod := contractType.Method(i)
 		valueMethod := contractValue.Method(i)
 
-		if !utils.StringInSlice(typeMethod.Name, excludeFuncs) {
+		if !utils.StringInSlice(typeMethod.Name, excludeFuncs) && !utils.StringInSlice(typeMethod.Name, optionalCiMethods) {
 			var err error
 
 			var callType internal.CallType = internal.CallTypeSubmit
@@ -328,6 +605,71 @@ func (cc *ContractChaincode) addContract(contract ContractInterface, excludeFunc
 		return fmt.Errorf("contracts are required to have at least 1 (non-ignored) public method. Contract %s has none. Method names that have been ignored: %s", ns, utils.SliceAsCommaSentence(excludeFuncs))
 	}
 
+	if eci, ok := contract.(ErrorsContractInterface); ok {
+		ccn.errors = eci.GetTransactionErrors()
+
+		if err := validateErrors(ccn.errors, ccn, ns); err != nil {
+			return err
+		}
+	}
+
+	if aci, ok := contract.(AccessControlledContractInterface); ok {
+		ccn.policies = aci.GetAccessPolicies()
+
+		for fn := range ccn.policies {
+			if _, ok := ccn.functions[fn]; !ok {
+				return fmt.Errorf("access policy defined for %s which is not a transaction function of contract %s", fn, ns)
+			}
+		}
+	}
+
+	if sci, ok := contract.(SerializerContractInterface); ok {
+		ccn.serializer = sci.GetTransactionSerializer()
+	}
+
+	if fsci, ok := contract.(FunctionSerializersContractInterface); ok {
+		ccn.functionSerializers = fsci.GetFunctionSerializers()
+
+		for fn := range ccn.functionSerializers {
+			if _, ok := ccn.functions[fn]; !ok {
+				return fmt.Errorf("serializer defined for %s which is not a transaction function of contract %s", fn, ns)
+			}
+		}
+	}
+
+	if daci, ok := contract.(DefaultArgsContractInterface); ok {
+		for fn, defaults := range daci.GetDefaultArgs() {
+			contractFn, ok := ccn.functions[fn]
+
+			if !ok {
+				return fmt.Errorf("default args defined for %s which is not a transaction function of contract %s", fn, ns)
+			}
+
+			if err := contractFn.SetDefaultArgs(defaults...); err != nil {
+				return fmt.Errorf("default args of %s in contract %s are not valid. %s", fn, ns, err.Error())
+			}
+		}
+	}
+
+	cci, declaresCollections := contract.(CollectionsContractInterface)
+	tcci, declaresUsage := contract.(TransactionCollectionsContractInterface)
+
+	if declaresCollections || declaresUsage {
+		ccn.collections = []metadata.CollectionMetadata{}
+
+		if declaresCollections {
+			ccn.collections = append(ccn.collections, cci.GetCollections()...)
+		}
+
+		if declaresUsage {
+			ccn.collectionUsage = tcci.GetTransactionCollections()
+		}
+
+		if err := validateCollections(ccn.collections, ccn.collectionUsage, ccn, ns); err != nil {
+			return err
+		}
+	}
+
 	cc.contracts[ns] = ccn
 
 	if cc.DefaultContract == "" {
@@ -337,7 +679,7 @@ func (cc *ContractChaincode) addContract(contract ContractInterface, excludeFunc
 	return nil
 }
 
-func (cc *ContractChaincode) reflectMetadata() metadata.ContractChaincodeMetadata {
+func (cc *ContractChaincode) reflectMetadata() (metadata.ContractChaincodeMetadata, error) {
 	reflectedMetadata := metadata.ContractChaincodeMetadata{}
 	reflectedMetadata.Contracts = make(map[string]metadata.ContractMetadata)
 	reflectedMetadata.Components.Schemas = make(map[string]metadata.ObjectMetadata)
@@ -362,7 +704,30 @@ func (cc *ContractChaincode) reflectMetadata() metadata.ContractChaincodeMetadat
 		}
 
 		for key, fn := range contract.functions {
-			fnMetadata := fn.ReflectMetadata(key, &reflectedMetadata.Components)
+			fnMetadata := fn.ReflectMetadataWithOptions(key, &reflectedMetadata.Components, schemaOptions(cc.functionSerializer(contract, key)))
+
+			if txDocs, ok := contract.docs.Transactions[key]; ok {
+				applyTransactionDocs(&fnMetadata, txDocs, fn.TransientPositions())
+			}
+
+			for _, ccErr := range contract.errors[key] {
+				errorMetadata, err := ccErr.reflectMetadata(&reflectedMetadata.Components, schemaOptions(cc.functionSerializer(contract, key)))
+				if err != nil {
+					return metadata.ContractChaincodeMetadata{}, fmt.Errorf("error %s of %s in contract %s is not valid. %s", ccErr.Code, key, contractMetadata.Name, err.Error())
+				}
+
+				fnMetadata.Errors = append(fnMetadata.Errors, errorMetadata)
+			}
+
+			if policy, ok := contract.policies[key]; ok {
+				fnMetadata.Access = &policy
+			}
+
+			if ets, ok := cc.functionSerializer(contract, key).(serializer.EncodingTransactionSerializer); ok {
+				fnMetadata.Encoding = ets.Encoding()
+			}
+
+			fnMetadata.Collections = contract.collectionUsage[key]
 
 			contractMetadata.Transactions = append(contractMetadata.Transactions, fnMetadata)
 		}
@@ -371,10 +736,69 @@ func (cc *ContractChaincode) reflectMetadata() metadata.ContractChaincodeMetadat
 			return contractMetadata.Transactions[i].Name < contractMetadata.Transactions[j].Name
 		})
 
+		if len(contract.events) > 0 {
+			var err error
+			contractMetadata.Events, err = reflectEventsMetadata(contract.events, key, &reflectedMetadata.Components, schemaOptions(cc.TransactionSerializer))
+
+			if err != nil {
+				return metadata.ContractChaincodeMetadata{}, err
+			}
+		}
+
+		if len(contract.collections) > 0 {
+			contractMetadata.Collections = contract.collections
+		}
+
 		reflectedMetadata.Contracts[key] = contractMetadata
 	}
 
-	return reflectedMetadata
+	return reflectedMetadata, nil
+}
+
+// schemaOptions returns the options for generating schemas of the values handled by the serializer,
+// which are the default options unless it is a serializer.SchemaTransactionSerializer
+func schemaOptions(txSerializer serializer.TransactionSerializer) metadata.SchemaOptions {
+	if sts, ok := txSerializer.(serializer.SchemaTransactionSerializer); ok {
+		return sts.SchemaOptions()
+	}
+
+	return metadata.SchemaOptions{}
+}
+
+// applyTransactionDocs sets the description and parameter names of reflected transaction
+// metadata. Documented parameters are matched to reflected parameters from the end of the
+// list so that docs may include or omit the transaction context parameter. The docs of
+// parameters at the transient positions, which exclude the transaction context, name and
+// describe the transient parameters. Docs with neither the same number of parameters nor
+// one extra are ignored
+func applyTransactionDocs(fnMetadata *metadata.TransactionMetadata, txDocs metadata.TransactionDocs, transientPositions []int) {
+	fnMetadata.Description = txDocs.Description
+
+	offset := len(txDocs.Parameters) - len(fnMetadata.Parameters) - len(fnMetadata.Transient)
+
+	if offset != 0 && offset != 1 || len(transientPositions) != len(fnMetadata.Transient) {
+		return
+	}
+
+	paramIdx, transientIdx := 0, 0
+
+	for i, paramDocs := range txDocs.Parameters[offset:] {
+		var paramMetadata *metadata.ParameterMetadata
+
+		if transientIdx < len(transientPositions) && transientPositions[transientIdx] == i {
+			paramMetadata = &fnMetadata.Transient[transientIdx]
+			transientIdx++
+		} else {
+			paramMetadata = &fnMetadata.Parameters[paramIdx]
+			paramIdx++
+		}
+
+		if paramDocs.Name != "" && paramDocs.Name != "_" {
+			paramMetadata.Name = paramDocs.Name
+		}
+
+		paramMetadata.Description = paramDocs.Description
+	}
 }
 
 func (cc *ContractChaincode) augmentMetadata() error {
@@ -383,7 +807,10 @@ func (cc *ContractChaincode) augmentMetadata() error {
 		return err
 	}
 
-	reflectedMetadata := cc.reflectMetadata()
+	reflectedMetadata, err := cc.reflectMetadata()
+	if err != nil {
+		return err
+	}
 
 	fileMetadata.Append(reflectedMetadata)
 	err = fileMetadata.CompileSchemas()
@@ -407,14 +834,47 @@ func getCiMethods() []string {
 
 	interfaceTypes := []reflect.Type{contractInterfaceType, ignoreContractInterfaceType, evaluateContractInterfaceType}
 
-	var ciMethods []string
+	return interfaceMethods(interfaceTypes)
+}
+
+// getOptionalCiMethods returns the names of the methods of the optional contract interfaces
+// which the contract implements. Methods of the same names are transaction functions of
+// contracts which do not implement the interfaces
+func getOptionalCiMethods(contract ContractInterface) []string {
+	documentedContractInterfaceType := reflect.TypeOf((*DocumentedContractInterface)(nil)).Elem()
+	errorsContractInterfaceType := reflect.TypeOf((*ErrorsContractInterface)(nil)).Elem()
+	accessControlledContractInterfaceType := reflect.TypeOf((*AccessControlledContractInterface)(nil)).Elem()
+	interceptedContractInterfaceType := reflect.TypeOf((*InterceptedContractInterface)(nil)).Elem()
+	eventsContractInterfaceType := reflect.TypeOf((*EventsContractInterface)(nil)).Elem()
+	serializerContractInterfaceType := reflect.TypeOf((*SerializerContractInterface)(nil)).Elem()
+	functionSerializersContractInterfaceType := reflect.TypeOf((*FunctionSerializersContractInterface)(nil)).Elem()
+	defaultArgsContractInterfaceType := reflect.TypeOf((*DefaultArgsContractInterface)(nil)).Elem()
+	collectionsContractInterfaceType := reflect.TypeOf((*CollectionsContractInterface)(nil)).Elem()
+	transactionCollectionsContractInterfaceType := reflect.TypeOf((*TransactionCollectionsContractInterface)(nil)).Elem()
+
+	interfaceTypes := []reflect.Type{documentedContractInterfaceType, errorsContractInterfaceType, accessControlledContractInterfaceType, interceptedContractInterfaceType, eventsContractInterfaceType, serializerContractInterfaceType, functionSerializersContractInterfaceType, defaultArgsContractInterfaceType, collectionsContractInterfaceType, transactionCollectionsContractInterfaceType}
+
+	contractType := reflect.TypeOf(contract)
+	implemented := []reflect.Type{}
+
+	for _, interfaceType := range interfaceTypes {
+		if contractType.Implements(interfaceType) {
+			implemented = append(implemented, interfaceType)
+		}
+	}
+
+	return interfaceMethods(implemented)
+}
+
+func interfaceMethods(interfaceTypes []reflect.Type) []string {
+	var methods []string
 	for _, interfaceType := range interfaceTypes {
 		for i := 0; i < interfaceType.NumMethod(); i++ {
-			ciMethods = append(ciMethods, interfaceType.Method(i).Name)
+			methods = append(methods, interfaceType.Method(i).Name)
 		}
 	}
 
-	return ciMethods
+	return methods
 }
 
 func loadChaincodeServerConfig() (*shim.ChaincodeServer, error) {
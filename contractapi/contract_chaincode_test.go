@@ -11,6 +11,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/go-openapi/spec"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hyperledger/fabric-chaincode-go/v2/shim"
 	"github.com/hyperledger/fabric-contract-api-go/v2/internal"
@@ -125,6 +126,22 @@ func (gc *goodContract) logAfter(data interface{}) {
 	gc.called = append(gc.called, fmt.Sprintf("After function called with %v", data))
 }
 
+func (gc *goodContract) logBeforeInfo(info *TransactionInfo) {
+	gc.called = append(gc.called, fmt.Sprintf("Before function called for %s:%s %s with %v %v", info.ContractName, info.FunctionName, info.CallType, info.RawArgs, info.Args))
+}
+
+func (gc *goodContract) logAfterInfo(data interface{}, info *TransactionInfo) {
+	gc.called = append(gc.called, fmt.Sprintf("After function called for %s with %v %v %v", info.FunctionName, data, info.Result, info.Err))
+}
+
+func (gc *goodContract) logUnknownInfo(info *TransactionInfo) {
+	gc.called = append(gc.called, fmt.Sprintf("Unknown function called for %s with %v", info.FunctionName, info.RawArgs))
+}
+
+func (gc *goodContract) AcceptsInt(value int) int {
+	return value
+}
+
 func (gc *goodContract) logUnknown() {
 	gc.called = append(gc.called, "Unknown function called")
 }
@@ -151,6 +168,10 @@ func (gc *goodContract) CheckContextStub(ctx *TransactionContext) (string, error
 	return "Stub as expected", nil
 }
 
+func (gc *goodContract) CheckContextSerializer(ctx *TransactionContext) string {
+	return reflect.TypeOf(ctx.GetTransactionSerializer()).String()
+}
+
 type goodContractCustomContext struct {
 	Contract
 }
@@ -186,6 +207,26 @@ func (gifc *ignorableFuncContract) GetIgnoredFunctions() []string {
 	return []string{"IgnoreMe"}
 }
 
+type namesakeContract struct {
+	myContract
+}
+
+func (nc *namesakeContract) GetContractDocs(ctx TransactionContextInterface) string {
+	return "docs"
+}
+
+func (nc *namesakeContract) GetEvents(ctx TransactionContextInterface, assetID string) (string, error) {
+	return "events of " + assetID, nil
+}
+
+func (nc *namesakeContract) GetAccessPolicies(ctx TransactionContextInterface) string {
+	return "policies"
+}
+
+func (nc *namesakeContract) GetTransactionSerializer() string {
+	return "serializer"
+}
+
 type evaluateContract struct {
 	myContract
 }
@@ -194,12 +235,211 @@ func (ec *evaluateContract) GetEvaluateTransactions() []string {
 	return []string{"ReturnsString"}
 }
 
+type documentedContract struct {
+	myContract
+}
+
+func (dc *documentedContract) TakesParams(ctx *TransactionContext, id string, value int) {}
+
+func (dc *documentedContract) GetContractDocs() metadata.ContractDocs {
+	return metadata.ContractDocs{
+		Description: "A documented contract",
+		Transactions: map[string]metadata.TransactionDocs{
+			"TakesParams": {
+				Description: "Takes some params",
+				Parameters: []metadata.ParameterDocs{
+					{Name: "ctx"},
+					{Name: "id", Description: "the ID"},
+					{Name: "value"},
+				},
+			},
+		},
+	}
+}
+
+var errAssetNotFound = NewChaincodeError(404, "ASSET_NOT_FOUND", "the asset does not exist")
+
+type errorDetails struct {
+	ID string `json:"id"`
+}
+
+type errorsContract struct {
+	myContract
+	transactionErrors map[string][]*ChaincodeError
+}
+
+func (ec *errorsContract) ReadAsset(id string) (string, error) {
+	return "", fmt.Errorf("failed to read. %w", errAssetNotFound.WithDetails(errorDetails{ID: id}))
+}
+
+func (ec *errorsContract) GetTransactionErrors() map[string][]*ChaincodeError {
+	if ec.transactionErrors != nil {
+		return ec.transactionErrors
+	}
+
+	return map[string][]*ChaincodeError{
+		"ReadAsset": {errAssetNotFound.WithDetails(errorDetails{}), NewChaincodeError(500, "", "internal error")},
+	}
+}
+
 type txHandler struct{}
 
 func (tx *txHandler) Handler() {
 	// do nothing
 }
 
+type assetStatus string
+
+func (as assetStatus) EnumValues() []interface{} {
+	return []interface{}{assetStatus("active"), assetStatus("closed")}
+}
+
+type enumContract struct {
+	Contract
+}
+
+func (ec *enumContract) Close(status assetStatus) assetStatus {
+	if status == "active" {
+		return "closed"
+	}
+
+	return "unknown"
+}
+
+type validatedArg struct {
+	ID    string `json:"id" validate:"pattern=^[a-z]+$,description=lower case identifier"`
+	Value int    `json:"value" validate:"minimum=0"`
+}
+
+type validatedContract struct {
+	Contract
+}
+
+func (vc *validatedContract) Create(arg validatedArg) int {
+	return arg.Value
+}
+
+type optionalParamsContract struct {
+	Contract
+}
+
+func (opc *optionalParamsContract) Transfer(id string, amount int, arg *validatedArg) string {
+	if arg == nil {
+		return fmt.Sprintf("%s:%d", id, amount)
+	}
+
+	return fmt.Sprintf("%s:%d:%s", id, amount, arg.ID)
+}
+
+func (opc *optionalParamsContract) GetDefaultArgs() map[string][]interface{} {
+	return map[string][]interface{}{
+		"Transfer": {10, nil},
+	}
+}
+
+type namedArgsContract struct {
+	optionalParamsContract
+}
+
+func (nac *namedArgsContract) GetContractDocs() metadata.ContractDocs {
+	return metadata.ContractDocs{
+		Transactions: map[string]metadata.TransactionDocs{
+			"Transfer": {
+				Parameters: []metadata.ParameterDocs{{Name: "id"}, {Name: "amount"}, {Name: "arg"}},
+			},
+		},
+	}
+}
+
+type variadicContract struct {
+	Contract
+}
+
+func (vc *variadicContract) DeleteAssets(ctx TransactionContextInterface, ids ...string) string {
+	return strings.Join(ids, ",")
+}
+
+func (vc *variadicContract) Total(args ...validatedArg) int {
+	total := 0
+
+	for _, arg := range args {
+		total += arg.Value
+	}
+
+	return total
+}
+
+type int64Contract struct {
+	Contract
+}
+
+func (ic *int64Contract) Echo(value int64) int64 {
+	return value
+}
+
+type int64Asset struct {
+	Value int64 `json:"value"`
+}
+
+type int64AssetContract struct {
+	Contract
+}
+
+func (iac *int64AssetContract) PutA(asset int64Asset) int64 {
+	return asset.Value
+}
+
+func (iac *int64AssetContract) PutB(asset int64Asset) int64 {
+	return asset.Value
+}
+
+type privateDetails struct {
+	Owner string `json:"owner" validate:"pattern=^[a-z]+$"`
+	Price int    `json:"price"`
+}
+
+type transientContract struct {
+	Contract
+}
+
+func (tc *transientContract) CreatePrivate(ctx TransactionContextInterface, id string, details Transient[privateDetails]) string {
+	return fmt.Sprintf("%s:%s:%d", id, details.Value.Owner, details.Value.Price)
+}
+
+func (tc *transientContract) UpdatePrivate(details Transient[*privateDetails], id string) string {
+	if details.Value == nil {
+		return id
+	}
+
+	return fmt.Sprintf("%s:%s", id, details.Value.Owner)
+}
+
+func (tc *transientContract) GetContractDocs() metadata.ContractDocs {
+	return metadata.ContractDocs{
+		Transactions: map[string]metadata.TransactionDocs{
+			"CreatePrivate": {
+				Parameters: []metadata.ParameterDocs{{Name: "ctx"}, {Name: "id"}, {Name: "details", Description: "the private details"}},
+			},
+			"UpdatePrivate": {
+				Parameters: []metadata.ParameterDocs{{Name: "details"}, {Name: "id"}},
+			},
+		},
+	}
+}
+
+type badDefaultArgsContract struct {
+	Contract
+	defaults map[string][]interface{}
+}
+
+func (bdac *badDefaultArgsContract) Transfer(id string, amount int) string {
+	return id
+}
+
+func (bdac *badDefaultArgsContract) GetDefaultArgs() map[string][]interface{} {
+	return bdac.defaults
+}
+
 type optionalFieldArg struct {
 	OptionalOne string `json:"optionalOne" metadata:",optional"`
 	OptionalTwo string `json:"optionalTwo" metadata:",optional"`
@@ -237,6 +477,16 @@ func testContractChaincodeContractMatchesContract(t *testing.T, actual contractC
 	}
 }
 
+func toByteArgs(args ...string) [][]byte {
+	byteArgs := [][]byte{}
+
+	for _, arg := range args {
+		byteArgs = append(byteArgs, []byte(arg))
+	}
+
+	return byteArgs
+}
+
 func callContractFunctionAndCheckError(t *testing.T, cc *ContractChaincode, arguments []string, callType CallType, expectedMessage string) {
 	t.Helper()
 
@@ -255,6 +505,7 @@ func callContractFunctionAndCheckResponse(t *testing.T, cc *ContractChaincode, a
 	mockStub := NewMockChaincodeStub(t)
 	mockStub.EXPECT().GetTxID().Maybe().Return(standardTxID)
 	mockStub.EXPECT().GetFunctionAndParameters().Maybe().Return(arguments[0], arguments[1:])
+	mockStub.EXPECT().GetArgs().Maybe().Return(toByteArgs(arguments...))
 	mockStub.EXPECT().GetCreator().Maybe().Return([]byte{}, nil)
 
 	var response *peer.Response
@@ -275,6 +526,19 @@ func callContractFunctionAndCheckResponse(t *testing.T, cc *ContractChaincode, a
 	AssertProtoEqual(t, expectedResponse, response)
 }
 
+func callContractFunctionWithTransient(t *testing.T, cc *ContractChaincode, arguments []string, transient map[string][]byte) *peer.Response {
+	t.Helper()
+
+	mockStub := NewMockChaincodeStub(t)
+	mockStub.EXPECT().GetTxID().Maybe().Return(standardTxID)
+	mockStub.EXPECT().GetFunctionAndParameters().Maybe().Return(arguments[0], arguments[1:])
+	mockStub.EXPECT().GetArgs().Maybe().Return(toByteArgs(arguments...))
+	mockStub.EXPECT().GetCreator().Maybe().Return([]byte{}, nil)
+	mockStub.EXPECT().GetTransient().Maybe().Return(transient, nil)
+
+	return cc.Invoke(mockStub)
+}
+
 func testCallingContractFunctions(t *testing.T, callType CallType) {
 	var cc *ContractChaincode
 
@@ -374,6 +638,9 @@ func testCallingContractFunctions(t *testing.T, callType CallType) {
 	// should pass the stub into transaction context as expected
 	callContractFunctionAndCheckSuccess(t, cc, []string{"goodContract:CheckContextStub"}, callType, "Stub as expected")
 
+	// should pass the serializer into transaction context
+	callContractFunctionAndCheckSuccess(t, cc, []string{"goodContract:CheckContextSerializer"}, callType, "*serializer.JSONSerializer")
+
 	sc := goodContractCustomContext{}
 	sc.TransactionContextHandler = new(customContext)
 	cc, _ = NewChaincode(&sc)
@@ -428,6 +695,7 @@ func jsonCompare(t *testing.T, s1, s2 string) {
 
 func TestReflectMetadata(t *testing.T) {
 	var reflectedMetadata metadata.ContractChaincodeMetadata
+	var err error
 
 	goodMethod := new(simpleStruct).GoodMethod
 	anotherGoodMethod := new(simpleStruct).AnotherGoodMethod
@@ -478,23 +746,74 @@ func TestReflectMetadata(t *testing.T) {
 
 	// TESTS
 
-	reflectedMetadata = cc.reflectMetadata()
+	reflectedMetadata, err = cc.reflectMetadata()
+	require.NoError(t, err)
 	require.Equal(t, expectedMetadata, reflectedMetadata, "should return contract chaincode metadata")
 
 	expectedMetadata.Info.Version = "latest"
 	cc.Info.Version = ""
 	expectedMetadata.Info.Title = "undefined"
 	cc.Info.Title = ""
-	reflectedMetadata = cc.reflectMetadata()
+	reflectedMetadata, err = cc.reflectMetadata()
+	require.NoError(t, err)
 	require.Equal(t, expectedMetadata, reflectedMetadata, "should sub in value for title and version when not set")
 
 	cc.DefaultContract = "MyContract"
-	reflectedMetadata = cc.reflectMetadata()
+	reflectedMetadata, err = cc.reflectMetadata()
+	require.NoError(t, err)
 	contractMetadata.Default = true
 	expectedMetadata.Contracts["MyContract"] = contractMetadata
 	require.Equal(t, expectedMetadata, reflectedMetadata, "should return contract chaincode metadata when default")
 }
 
+func TestApplyTransactionDocs(t *testing.T) {
+	var txMetadata metadata.TransactionMetadata
+
+	newTxMetadata := func() metadata.TransactionMetadata {
+		return metadata.TransactionMetadata{
+			Name: "SomeTx",
+			Parameters: []metadata.ParameterMetadata{
+				{Name: "param0", Schema: spec.StringProperty()},
+				{Name: "param1", Schema: spec.Int64Property()},
+			},
+		}
+	}
+
+	txMetadata = newTxMetadata()
+	applyTransactionDocs(&txMetadata, metadata.TransactionDocs{
+		Description: "some description",
+		Parameters:  []metadata.ParameterDocs{{Name: "id", Description: "the ID"}, {Name: "value"}},
+	}, []int{})
+	require.Equal(t, "some description", txMetadata.Description, "should set description")
+	require.Equal(t, metadata.ParameterMetadata{Name: "id", Description: "the ID", Schema: spec.StringProperty()}, txMetadata.Parameters[0], "should set name and description of first param")
+	require.Equal(t, metadata.ParameterMetadata{Name: "value", Schema: spec.Int64Property()}, txMetadata.Parameters[1], "should set name of second param")
+
+	txMetadata = newTxMetadata()
+	applyTransactionDocs(&txMetadata, metadata.TransactionDocs{
+		Parameters: []metadata.ParameterDocs{{Name: "ctx"}, {Name: "id"}, {Name: "_", Description: "unnamed"}},
+	}, []int{})
+	require.Equal(t, "id", txMetadata.Parameters[0].Name, "should skip the context param")
+	require.Equal(t, "param1", txMetadata.Parameters[1].Name, "should keep reflected name for blank identifier")
+	require.Equal(t, "unnamed", txMetadata.Parameters[1].Description, "should set description for blank identifier")
+
+	txMetadata = newTxMetadata()
+	applyTransactionDocs(&txMetadata, metadata.TransactionDocs{
+		Description: "mismatched",
+		Parameters:  []metadata.ParameterDocs{{Name: "id"}},
+	}, []int{})
+	require.Equal(t, "mismatched", txMetadata.Description, "should set description when params mismatch")
+	require.Equal(t, newTxMetadata().Parameters, txMetadata.Parameters, "should not change params when number of docs does not match")
+
+	txMetadata = newTxMetadata()
+	txMetadata.Transient = []metadata.ParameterMetadata{{Name: "transient0", Schema: spec.StringProperty()}}
+	applyTransactionDocs(&txMetadata, metadata.TransactionDocs{
+		Parameters: []metadata.ParameterDocs{{Name: "ctx"}, {Name: "id"}, {Name: "secret", Description: "the secret"}, {Name: "value"}},
+	}, []int{1})
+	require.Equal(t, "id", txMetadata.Parameters[0].Name, "should name param before transient param")
+	require.Equal(t, "value", txMetadata.Parameters[1].Name, "should name param after transient param")
+	require.Equal(t, metadata.ParameterMetadata{Name: "secret", Description: "the secret", Schema: spec.StringProperty()}, txMetadata.Transient[0], "should set name and description of transient param")
+}
+
 func TestAugmentMetadata(t *testing.T) {
 	info := metadata.InfoMetadata{
 		Title:   "some chaincode",
@@ -508,7 +827,9 @@ func TestAugmentMetadata(t *testing.T) {
 	err := cc.augmentMetadata()
 	require.NoError(t, err)
 
-	require.Equal(t, cc.reflectMetadata(), cc.metadata, "should return reflected metadata when none supplied as file")
+	reflectedMetadata, err := cc.reflectMetadata()
+	require.NoError(t, err)
+	require.Equal(t, reflectedMetadata, cc.metadata, "should return reflected metadata when none supplied as file")
 }
 
 func TestAddContract(t *testing.T) {
@@ -695,10 +1016,59 @@ func TestNewChaincode(t *testing.T) {
 	require.Len(t, contractChaincode.contracts, 3, "should add both passed contracts and system contract")
 	require.Equal(t, reflect.TypeOf(new(serializer.JSONSerializer)), reflect.TypeOf(contractChaincode.TransactionSerializer), "should have set the transaction serializer")
 	setMetadata, _, _ := contractChaincode.contracts[SystemContractName].functions["GetMetadata"].Call(reflect.ValueOf(nil), nil, nil, new(serializer.JSONSerializer))
-	jsonCompare(t, "{\"info\":{\"title\":\"undefined\",\"version\":\"latest\"},\"contracts\":{\"evaluateContract\":{\"info\":{\"title\":\"evaluateContract\",\"version\":\"latest\"},\"name\":\"evaluateContract\",\"transactions\":[{\"returns\":{\"type\":\"string\"},\"tag\":[\"evaluate\", \"EVALUATE\"],\"name\":\"ReturnsString\"}],\"default\": false},\"myContract\":{\"info\":{\"title\":\"myContract\",\"version\":\"latest\"},\"name\":\"myContract\",\"transactions\":[{\"returns\":{\"type\":\"string\"},\"tag\":[\"submit\", \"SUBMIT\"],\"name\":\"ReturnsString\"}], \"default\": true},\"org.hyperledger.fabric\":{\"info\":{\"title\":\"org.hyperledger.fabric\",\"version\":\"latest\"},\"name\":\"org.hyperledger.fabric\",\"transactions\":[{\"returns\":{\"type\":\"string\"},\"tag\":[\"evaluate\", \"EVALUATE\"],\"name\":\"GetMetadata\"}], \"default\": false}},\"components\":{}}", setMetadata)
+	jsonCompare(t, "{\"info\":{\"title\":\"undefined\",\"version\":\"latest\"},\"contracts\":{\"evaluateContract\":{\"info\":{\"title\":\"evaluateContract\",\"version\":\"latest\"},\"name\":\"evaluateContract\",\"transactions\":[{\"returns\":{\"type\":\"string\"},\"encoding\":\"json\",\"tag\":[\"evaluate\", \"EVALUATE\"],\"name\":\"ReturnsString\"}],\"default\": false},\"myContract\":{\"info\":{\"title\":\"myContract\",\"version\":\"latest\"},\"name\":\"myContract\",\"transactions\":[{\"returns\":{\"type\":\"string\"},\"encoding\":\"json\",\"tag\":[\"submit\", \"SUBMIT\"],\"name\":\"ReturnsString\"}], \"default\": true},\"org.hyperledger.fabric\":{\"info\":{\"title\":\"org.hyperledger.fabric\",\"version\":\"latest\"},\"name\":\"org.hyperledger.fabric\",\"transactions\":[{\"returns\":{\"type\":\"string\"},\"encoding\":\"json\",\"tag\":[\"evaluate\", \"EVALUATE\"],\"name\":\"GetCollectionsConfig\"},{\"returns\":{\"type\":\"string\"},\"encoding\":\"json\",\"tag\":[\"evaluate\", \"EVALUATE\"],\"name\":\"GetMetadata\"},{\"returns\":{\"type\":\"string\"},\"encoding\":\"json\",\"tag\":[\"evaluate\", \"EVALUATE\"],\"name\":\"GetOpenAPI\"}], \"default\": false}},\"components\":{}}", setMetadata)
+
+	contractChaincode, err = NewChaincode(new(documentedContract))
+	require.NoError(t, err, "should not error for documented contract")
+	require.Equal(t, "A documented contract", contractChaincode.metadata.Contracts["documentedContract"].Info.Description, "should use contract docs as description")
+	_, ok := contractChaincode.contracts["documentedContract"].functions["GetContractDocs"]
+	require.False(t, ok, "should not include GetContractDocs as a transaction")
+	for _, tx := range contractChaincode.metadata.Contracts["documentedContract"].Transactions {
+		if tx.Name == "TakesParams" {
+			require.Equal(t, "Takes some params", tx.Description, "should set transaction description")
+			require.Equal(t, "id", tx.Parameters[0].Name, "should use documented param name")
+			require.Equal(t, "the ID", tx.Parameters[0].Description, "should use documented param description")
+			require.Equal(t, "value", tx.Parameters[1].Name, "should use documented param name")
+			require.NotNil(t, tx.Parameters[1].CompiledSchema, "should compile schema for documented param")
+		}
+	}
+	callContractFunctionAndCheckError(t, contractChaincode, []string{"documentedContract:TakesParams", "id1", "bad"}, invokeType, "error managing parameter value. conversion error. cannot convert passed value bad to int")
+
+	contractChaincode, err = NewChaincode(new(errorsContract))
+	require.NoError(t, err, "should not error for contract with errors")
+	_, ok = contractChaincode.contracts["errorsContract"].functions["GetTransactionErrors"]
+	require.False(t, ok, "should not include GetTransactionErrors as a transaction")
+	for _, tx := range contractChaincode.metadata.Contracts["errorsContract"].Transactions {
+		if tx.Name == "ReadAsset" {
+			require.Equal(t, []metadata.ErrorMetadata{
+				{Status: 404, Code: "ASSET_NOT_FOUND", Description: "the asset does not exist", Schema: spec.RefSchema("#/components/schemas/errorDetails")},
+				{Status: 500, Description: "internal error"},
+			}, tx.Errors, "should set transaction errors")
+		} else {
+			require.Nil(t, tx.Errors, "should not set errors for transactions without them")
+		}
+	}
+	require.Contains(t, contractChaincode.metadata.Components.Schemas, "errorDetails", "should add schema of error details to components")
+
+	mockStub := NewMockChaincodeStub(t)
+	mockStub.EXPECT().GetFunctionAndParameters().Return("errorsContract:ReadAsset", []string{"asset1"})
+	mockStub.EXPECT().GetArgs().Maybe().Return(toByteArgs("errorsContract:ReadAsset", "asset1"))
+	mockStub.EXPECT().GetCreator().Maybe().Return([]byte{}, nil)
+	expectedResponse := &peer.Response{
+		Status:  404,
+		Message: "failed to read. ASSET_NOT_FOUND: the asset does not exist",
+		Payload: []byte(`{"status":404,"code":"ASSET_NOT_FOUND","message":"the asset does not exist","details":{"id":"asset1"}}`),
+	}
+	AssertProtoEqual(t, expectedResponse, contractChaincode.Invoke(mockStub))
+
+	_, err = NewChaincode(&errorsContract{transactionErrors: map[string][]*ChaincodeError{"Missing": {errAssetNotFound}}})
+	require.EqualError(t, err, "errors defined for Missing which is not a transaction function of contract errorsContract", "should error when errors defined for missing function")
+
+	_, err = NewChaincode(&errorsContract{transactionErrors: map[string][]*ChaincodeError{"ReadAsset": {errAssetNotFound.WithDetails(make(chan int))}}})
+	require.ErrorContains(t, err, "error ASSET_NOT_FOUND of ReadAsset in contract errorsContract is not valid. ", "should error when error details cannot be described")
 
 	contractChaincode, err = NewChaincode(new(ignorableFuncContract))
-	_, ok := contractChaincode.contracts["ignorableFuncContract"].functions["IgnoreMe"]
+	_, ok = contractChaincode.contracts["ignorableFuncContract"].functions["IgnoreMe"]
 	require.NoError(t, err, "should not return error for valid contract with ignores")
 	require.False(t, ok, "should not include ignored function")
 }
@@ -708,6 +1078,24 @@ func TestNewChaincodeOptionalFields(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNewChaincodeOptionalInterfaceNames(t *testing.T) {
+	cc, err := NewChaincode(new(namesakeContract))
+	require.NoError(t, err)
+
+	for _, fn := range []string{"GetContractDocs", "GetEvents", "GetAccessPolicies", "GetTransactionSerializer"} {
+		_, ok := cc.contracts["namesakeContract"].functions[fn]
+		require.True(t, ok, "should include %s as a transaction when contract does not implement optional interface", fn)
+	}
+
+	_, ok := cc.contracts["namesakeContract"].functions["GetFunctionSerializers"]
+	require.False(t, ok, "should not include GetFunctionSerializers as a transaction when contract implements optional interface")
+
+	require.Nil(t, cc.metadata.Contracts["namesakeContract"].Events, "should not describe events")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"namesakeContract:GetContractDocs"}, invokeType, "docs")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"namesakeContract:GetEvents", "asset1"}, invokeType, "events of asset1")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"namesakeContract:GetTransactionSerializer"}, invokeType, "serializer")
+}
+
 func TestStart(t *testing.T) {
 	mc := new(myContract)
 
@@ -725,6 +1113,315 @@ func TestInit(t *testing.T) {
 	testCallingContractFunctions(t, initType)
 }
 
+func TestInvokeTransactionInfo(t *testing.T) {
+	gc := goodContract{}
+	gc.BeforeTransaction = gc.logBeforeInfo
+	gc.AfterTransaction = gc.logAfterInfo
+	cc, _ := NewChaincode(&gc)
+
+	callContractFunctionAndCheckSuccess(t, cc, []string{"goodContract:acceptsInt", "1"}, invokeType, "1")
+	require.Equal(t, []string{"Before function called for goodContract:AcceptsInt SUBMIT with [1] [1]", "After function called for AcceptsInt with 1 1 <nil>"}, gc.called, "should pass info to before and after functions")
+	gc.called = nil
+
+	callContractFunctionAndCheckError(t, cc, []string{"goodContract:ReturnsError"}, invokeType, "Some error")
+	require.Equal(t, []string{"Before function called for goodContract:ReturnsError SUBMIT with [] []", "After function called for ReturnsError with <nil> <nil> Some error"}, gc.called, "should call after function taking info when function errors")
+	gc.called = nil
+
+	callContractFunctionAndCheckError(t, cc, []string{"goodContract:AcceptsInt", "bad"}, invokeType, "error managing parameter param0. conversion error. cannot convert passed value bad to int")
+	require.Equal(t, []string{"Before function called for goodContract:AcceptsInt SUBMIT with [bad] []", "After function called for AcceptsInt with <nil> <nil> error managing parameter param0. conversion error. cannot convert passed value bad to int"}, gc.called, "should call before function and after function taking info when args cannot be decoded")
+	gc.called = nil
+
+	gc.BeforeTransaction = func() error {
+		return errors.New("before error")
+	}
+	cc, _ = NewChaincode(&gc)
+	callContractFunctionAndCheckError(t, cc, []string{"goodContract:AcceptsInt", "bad"}, invokeType, "before error")
+
+	gc = goodContract{}
+	gc.BeforeTransaction = gc.logBeforeInfo
+	gc.UnknownTransaction = gc.logUnknownInfo
+	gc.AfterTransaction = func(info *TransactionInfo) error {
+		return errors.New("after error")
+	}
+	cc, _ = NewChaincode(&gc)
+
+	callContractFunctionAndCheckError(t, cc, []string{"goodContract:missing", "a", "b"}, invokeType, "after error")
+	require.Equal(t, []string{"Before function called for goodContract:missing  with [a b] []", "Unknown function called for missing with [a b]"}, gc.called, "should pass info to unknown function")
+
+	gc = goodContract{}
+	gc.AfterTransaction = func(info *TransactionInfo) error {
+		return errors.New("after error")
+	}
+	cc, _ = NewChaincode(&gc)
+	callContractFunctionAndCheckError(t, cc, []string{"goodContract:ReturnsError"}, invokeType, "after error")
+
+	gc.AfterTransaction = gc.logAfter
+	cc, _ = NewChaincode(&gc)
+	callContractFunctionAndCheckError(t, cc, []string{"goodContract:ReturnsError"}, invokeType, "Some error")
+	require.Empty(t, gc.called, "should not call after function not taking info when function errors")
+}
+
+func TestInvokeSerializerBytes(t *testing.T) {
+	arg := []byte{0xff, 0xfe, 0x00}
+
+	cc, _ := NewChaincode(new(goodContract))
+
+	mockStub := NewMockChaincodeStub(t)
+	mockStub.EXPECT().GetFunctionAndParameters().Return("goodContract:AcceptsBytes", []string{string(arg)})
+	mockStub.EXPECT().GetArgs().Return([][]byte{[]byte("goodContract:AcceptsBytes"), arg})
+	mockStub.EXPECT().GetCreator().Maybe().Return([]byte{}, nil)
+
+	response := cc.Invoke(mockStub)
+	require.Equal(t, shim.Success(arg), response, "should read args as bytes when serializer supports bytes")
+
+	cc.TransactionSerializer = struct {
+		serializer.TransactionSerializer
+	}{new(serializer.JSONSerializer)}
+
+	mockStub = NewMockChaincodeStub(t)
+	mockStub.EXPECT().GetFunctionAndParameters().Return("goodContract:AcceptsBytes", []string{"some bytes"})
+	mockStub.EXPECT().GetCreator().Maybe().Return([]byte{}, nil)
+
+	response = cc.Invoke(mockStub)
+	require.Equal(t, shim.Success([]byte("some bytes")), response, "should read args as strings when serializer does not support bytes")
+}
+
+func TestInvokeContractSerializers(t *testing.T) {
+	gc := new(goodContract)
+	gc.TransactionSerializer = new(mockSerializer)
+	gc.FunctionSerializers = map[string]serializer.TransactionSerializer{"AcceptsInt": &serializer.JSONSerializer{Int64AsString: true}, "CheckContextSerializer": new(serializer.ProtobufSerializer)}
+
+	cc, err := NewChaincode(gc, new(evaluateContract))
+	require.NoError(t, err)
+
+	_, ok := cc.contracts["goodContract"].functions["GetTransactionSerializer"]
+	require.False(t, ok, "should not include serializer functions as transactions")
+	_, ok = cc.contracts["goodContract"].functions["GetFunctionSerializers"]
+	require.False(t, ok, "should not include function serializer functions as transactions")
+
+	callContractFunctionAndCheckSuccess(t, cc, []string{"goodContract:ReturnsString"}, invokeType, "GOODBYE WORLD")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"goodContract:AcceptsInt", "1"}, invokeType, "1")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"goodContract:CheckContextSerializer"}, invokeType, "*serializer.JSONSerializer")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"evaluateContract:ReturnsString"}, invokeType, "Some string")
+
+	require.Same(t, gc.FunctionSerializers["AcceptsInt"], cc.FunctionSerializer("goodContract:acceptsInt"), "should return serializer of function")
+	require.Same(t, gc.FunctionSerializers["AcceptsInt"], cc.FunctionSerializer("goodContract:AcceptsInt@named"), "should return serializer of function called with named args")
+	require.Same(t, gc.TransactionSerializer, cc.FunctionSerializer("goodContract:ReturnsString"), "should return serializer of contract")
+	require.Same(t, gc.TransactionSerializer, cc.FunctionSerializer("ReturnsString"), "should return serializer of default contract")
+	require.Same(t, cc.TransactionSerializer, cc.FunctionSerializer("evaluateContract:ReturnsString"), "should return serializer of chaincode")
+	require.Same(t, cc.TransactionSerializer, cc.FunctionSerializer("missingContract:ReturnsString"), "should return serializer of chaincode for unknown contract")
+
+	require.Empty(t, cc.metadata.Contracts["goodContract"].Transactions[0].Encoding, "should not record encoding of serializer without one")
+
+	for _, tx := range cc.metadata.Contracts["goodContract"].Transactions {
+		if tx.Name == "AcceptsInt" {
+			require.Equal(t, "json", tx.Encoding, "should record encoding of function serializer")
+			require.Equal(t, []string{"string"}, []string(tx.Parameters[0].Schema.Type), "should generate schema with options of function serializer")
+		}
+	}
+
+	for _, tx := range cc.metadata.Contracts["evaluateContract"].Transactions {
+		require.Equal(t, "json", tx.Encoding, "should record encoding of serializer of chaincode")
+	}
+
+	cc, err = NewChaincode(new(evaluateContract))
+	require.NoError(t, err)
+
+	cc.TransactionSerializer = new(serializer.CBORSerializer)
+	require.NoError(t, cc.prepareMetadata())
+
+	for _, tx := range cc.metadata.Contracts["evaluateContract"].Transactions {
+		require.Equal(t, "cbor", tx.Encoding, "should record encoding of serializer set on chaincode")
+	}
+
+	gc = new(goodContract)
+	gc.FunctionSerializers = map[string]serializer.TransactionSerializer{"Missing": new(serializer.JSONSerializer)}
+	_, err = NewChaincode(gc)
+	require.EqualError(t, err, "serializer defined for Missing which is not a transaction function of contract goodContract", "should error for serializer of unknown function")
+}
+
+func TestInvokeChaincodeSerializerSchemaOptions(t *testing.T) {
+	gc := new(goodContract)
+	gc.FunctionSerializers = map[string]serializer.TransactionSerializer{"AcceptsInt": new(serializer.JSONSerializer)}
+
+	cc, err := NewChaincode(gc, new(int64Contract))
+	require.NoError(t, err)
+
+	cc.TransactionSerializer = &serializer.JSONSerializer{Int64AsString: true}
+
+	callContractFunctionAndCheckSuccess(t, cc, []string{"int64Contract:Echo", "5"}, invokeType, "5")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"goodContract:AcceptsInt", "5"}, invokeType, "5")
+
+	require.Equal(t, []string{"string"}, []string(cc.metadata.Contracts["int64Contract"].Transactions[0].Parameters[0].Schema.Type), "should generate schema with options of chaincode serializer")
+
+	for _, tx := range cc.metadata.Contracts["goodContract"].Transactions {
+		if tx.Name == "AcceptsInt" {
+			require.Equal(t, []string{"integer"}, []string(tx.Parameters[0].Schema.Type), "should generate schema with options of function serializer")
+		}
+	}
+
+	metadataJSON, _ := json.Marshal(cc.metadata)
+	callContractFunctionAndCheckSuccess(t, cc, []string{SystemContractName + ":GetMetadata"}, invokeType, string(metadataJSON))
+
+	cc.TransactionSerializer = new(serializer.JSONSerializer)
+
+	callContractFunctionAndCheckSuccess(t, cc, []string{SystemContractName + ":GetMetadata"}, invokeType, string(metadataJSON))
+	require.Equal(t, []string{"string"}, []string(cc.metadata.Contracts["int64Contract"].Transactions[0].Parameters[0].Schema.Type), "should not generate metadata again after first invoke")
+
+	cc, err = NewChaincode(new(int64Contract))
+	require.NoError(t, err)
+
+	cc.TransactionSerializer = &serializer.JSONSerializer{Int64AsString: true}
+	_ = cc.Start()
+	require.Equal(t, []string{"string"}, []string(cc.metadata.Contracts["int64Contract"].Transactions[0].Parameters[0].Schema.Type), "should generate metadata again when started")
+}
+
+func TestInvokeSharedStructSchemaOptions(t *testing.T) {
+	// reflected several times as the order functions are reflected in is not fixed
+	for i := 0; i < 10; i++ {
+		iac := new(int64AssetContract)
+		iac.FunctionSerializers = map[string]serializer.TransactionSerializer{"PutB": &serializer.JSONSerializer{Int64AsString: true}}
+
+		cc, err := NewChaincode(iac)
+		require.NoError(t, err)
+
+		callContractFunctionAndCheckSuccess(t, cc, []string{"PutA", `{"value":5}`}, invokeType, "5")
+		callContractFunctionAndCheckSuccess(t, cc, []string{"PutB", `{"value":"5"}`}, invokeType, "5")
+
+		require.Equal(t, "#/components/schemas/int64Asset", cc.metadata.Contracts["int64AssetContract"].Transactions[0].Parameters[0].Schema.Ref.String(), "should reference component of struct generated without options")
+		require.Equal(t, "#/components/schemas/int64Asset_Int64AsString", cc.metadata.Contracts["int64AssetContract"].Transactions[1].Parameters[0].Schema.Ref.String(), "should reference component of struct generated with options")
+	}
+}
+
+func TestInvokeEnumParameters(t *testing.T) {
+	cc, err := NewChaincode(new(enumContract))
+	require.NoError(t, err)
+
+	require.Equal(t, []interface{}{"active", "closed"}, cc.metadata.Contracts["enumContract"].Transactions[0].Parameters[0].Schema.Enum, "should describe enum values of parameter")
+
+	callContractFunctionAndCheckSuccess(t, cc, []string{"Close", "active"}, invokeType, "closed")
+	callContractFunctionAndCheckError(t, cc, []string{"Close", "open"}, invokeType, "error managing parameter param0. value did not match schema:\n1. param0: param0 must be one of the following: \"active\", \"closed\"")
+	callContractFunctionAndCheckError(t, cc, []string{"Close", "closed"}, invokeType, "error handling success response. value did not match schema:\n1. return: return must be one of the following: \"active\", \"closed\"")
+}
+
+func TestInvokeValidateTags(t *testing.T) {
+	cc, err := NewChaincode(new(validatedContract))
+	require.NoError(t, err)
+
+	require.Equal(t, "lower case identifier", cc.metadata.Components.Schemas["validatedArg"].Properties["id"].Description, "should describe property using validate tag")
+
+	callContractFunctionAndCheckSuccess(t, cc, []string{"Create", `{"id":"asset","value":1}`}, invokeType, "1")
+	callContractFunctionAndCheckError(t, cc, []string{"Create", `{"id":"ASSET","value":1}`}, invokeType, "error managing parameter param0. value did not match schema:\n1. param0.id: Does not match pattern '^[a-z]+$'")
+	callContractFunctionAndCheckError(t, cc, []string{"Create", `{"id":"asset","value":-1}`}, invokeType, "error managing parameter param0. value did not match schema:\n1. param0.value: Must be greater than or equal to 0")
+}
+
+func TestInvokeOptionalParameters(t *testing.T) {
+	cc, err := NewChaincode(new(optionalParamsContract))
+	require.NoError(t, err)
+
+	parameters := cc.metadata.Contracts["optionalParamsContract"].Transactions[0].Parameters
+	require.Nil(t, parameters[0].Required, "should not mark required parameter")
+	require.Equal(t, false, *parameters[1].Required, "should mark parameter with default as not required")
+	require.Equal(t, false, *parameters[2].Required, "should mark trailing pointer parameter as not required")
+
+	callContractFunctionAndCheckSuccess(t, cc, []string{"Transfer", "asset"}, invokeType, "asset:10")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"Transfer", "asset", "5"}, invokeType, "asset:5")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"Transfer", "asset", "5", `{"id":"other","value":1}`}, invokeType, "asset:5:other")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"Transfer", "asset", "5", `{"id":"other","value":1}`, "surplus"}, invokeType, "asset:5:other")
+	callContractFunctionAndCheckError(t, cc, []string{"Transfer"}, invokeType, "incorrect number of params. Expected 1 to 3, received 0")
+
+	cc.StrictArgs = true
+	callContractFunctionAndCheckSuccess(t, cc, []string{"Transfer", "asset"}, invokeType, "asset:10")
+	callContractFunctionAndCheckError(t, cc, []string{"Transfer", "asset", "5", `{"id":"other","value":1}`, "surplus"}, invokeType, "incorrect number of params. Expected 1 to 3, received 4")
+
+	_, err = NewChaincode(&badDefaultArgsContract{defaults: map[string][]interface{}{"Missing": {1}}})
+	require.EqualError(t, err, "default args defined for Missing which is not a transaction function of contract badDefaultArgsContract", "should error for defaults of unknown function")
+
+	_, err = NewChaincode(&badDefaultArgsContract{defaults: map[string][]interface{}{"Transfer": {"ten"}}})
+	require.EqualError(t, err, "default args of Transfer in contract badDefaultArgsContract are not valid. default arg 1 of type string cannot be used for parameter of type int", "should error for default of wrong type")
+}
+
+func TestInvokeNamedArgs(t *testing.T) {
+	cc, err := NewChaincode(new(namedArgsContract))
+	require.NoError(t, err)
+
+	callContractFunctionAndCheckSuccess(t, cc, []string{"Transfer@named", `{"id":"asset","amount":5}`}, invokeType, "asset:5")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"Transfer@named", `{"id":"asset"}`}, invokeType, "asset:10")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"Transfer@named", `{"id":"asset","arg":{"id":"other","value":1}}`}, invokeType, "asset:10:other")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"Transfer@named", `{"id":"asset","arg":null}`}, invokeType, "asset:10")
+	callContractFunctionAndCheckError(t, cc, []string{"Transfer@named", `{"amount":5}`}, invokeType, "error managing parameter id. named args do not include the parameter")
+	callContractFunctionAndCheckError(t, cc, []string{"Transfer@named", `{"id":5}`}, invokeType, "error managing parameter id. value is a JSON number but the parameter is of type string")
+	callContractFunctionAndCheckError(t, cc, []string{"Transfer@named", `{"amount":"5","id":"asset"}`}, invokeType, "error managing parameter amount. value is a JSON string but the parameter is of type integer")
+	callContractFunctionAndCheckError(t, cc, []string{"Transfer@named", `{"id":"asset","count":5}`}, invokeType, "named args include count which is not a parameter of the function")
+	callContractFunctionAndCheckError(t, cc, []string{"Transfer@named", `{"id":"asset","arg":{"id":"OTHER","value":1}}`}, invokeType, "error managing parameter arg. value did not match schema:\n1. arg.id: Does not match pattern '^[a-z]+$'")
+	jsonErr := json.Unmarshal([]byte(`["asset"]`), &map[string]json.RawMessage{})
+	callContractFunctionAndCheckError(t, cc, []string{"Transfer@named", `["asset"]`}, invokeType, "named args must be a JSON object. "+jsonErr.Error())
+	callContractFunctionAndCheckError(t, cc, []string{"Transfer@named", `{"id":"asset"}`, "5"}, invokeType, "functions called with named args must be passed a single JSON object arg. Received 2 args")
+	callContractFunctionAndCheckError(t, cc, []string{"Unknown@named", `{}`}, invokeType, "Function Unknown@named not found in contract namedArgsContract")
+
+	cc.TransactionSerializer = new(serializer.CBORSerializer)
+	callContractFunctionAndCheckError(t, cc, []string{"Transfer@named", `{"id":"asset"}`}, invokeType, "named args cannot be used with functions using cbor encoding")
+}
+
+func TestInvokeVariadic(t *testing.T) {
+	cc, err := NewChaincode(new(variadicContract))
+	require.NoError(t, err)
+
+	parameters := cc.metadata.Contracts["variadicContract"].Transactions[0].Parameters
+	require.True(t, parameters[0].Variadic, "should mark variadic parameter in metadata")
+	require.Equal(t, spec.ArrayProperty(spec.StringProperty()), parameters[0].Schema, "should describe variadic parameter as array")
+
+	callContractFunctionAndCheckSuccess(t, cc, []string{"DeleteAssets", "a", "b", "c"}, invokeType, "a,b,c")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"DeleteAssets"}, invokeType, "")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"DeleteAssets@named", `{"param0":["a","b"]}`}, invokeType, "a,b")
+	callContractFunctionAndCheckSuccess(t, cc, []string{"Total", `{"id":"a","value":1}`, `{"id":"b","value":2}`}, invokeType, "3")
+	callContractFunctionAndCheckError(t, cc, []string{"Total", `{"id":"a","value":1}`, `{"id":"B","value":2}`}, invokeType, "error managing parameter param0. value did not match schema:\n1. param0.id: Does not match pattern '^[a-z]+$'")
+
+	cc.StrictArgs = true
+	callContractFunctionAndCheckSuccess(t, cc, []string{"DeleteAssets", "a", "b", "c"}, invokeType, "a,b,c")
+}
+
+func TestInvokeTransient(t *testing.T) {
+	cc, err := NewChaincode(new(transientContract))
+	require.NoError(t, err)
+
+	createPrivate := cc.metadata.Contracts["transientContract"].Transactions[0]
+	require.Equal(t, []string{"id"}, []string{createPrivate.Parameters[0].Name}, "should describe only args as parameters")
+	require.Len(t, createPrivate.Transient, 1, "should describe transient parameter")
+	require.Equal(t, "details", createPrivate.Transient[0].Name, "should name transient parameter from docs")
+	require.Equal(t, "the private details", createPrivate.Transient[0].Description, "should describe transient parameter from docs")
+	require.Nil(t, createPrivate.Transient[0].Required, "should not mark transient parameter of value type")
+
+	updatePrivate := cc.metadata.Contracts["transientContract"].Transactions[1]
+	require.Equal(t, "id", updatePrivate.Parameters[0].Name, "should name parameter following transient parameter from docs")
+	require.Equal(t, "details", updatePrivate.Transient[0].Name)
+	require.Equal(t, false, *updatePrivate.Transient[0].Required, "should mark transient parameter of pointer type as not required")
+
+	details := map[string][]byte{"details": []byte(`{"owner":"alice","price":5}`)}
+
+	response := callContractFunctionWithTransient(t, cc, []string{"CreatePrivate", "asset"}, details)
+	AssertProtoEqual(t, shim.Success([]byte("asset:alice:5")), response)
+
+	response = callContractFunctionWithTransient(t, cc, []string{"UpdatePrivate", "asset"}, details)
+	AssertProtoEqual(t, shim.Success([]byte("asset:alice")), response)
+
+	response = callContractFunctionWithTransient(t, cc, []string{"UpdatePrivate", "asset"}, map[string][]byte{})
+	AssertProtoEqual(t, shim.Success([]byte("asset")), response)
+
+	response = callContractFunctionWithTransient(t, cc, []string{"CreatePrivate@named", `{"id":"asset"}`}, details)
+	AssertProtoEqual(t, shim.Success([]byte("asset:alice:5")), response)
+
+	response = callContractFunctionWithTransient(t, cc, []string{"CreatePrivate", "asset"}, map[string][]byte{})
+	AssertProtoEqual(t, shim.Error("error managing transient parameter details. transient data does not include the parameter"), response)
+
+	response = callContractFunctionWithTransient(t, cc, []string{"CreatePrivate", "asset"}, map[string][]byte{"details": []byte(`{"owner":"ALICE","price":5}`)})
+	AssertProtoEqual(t, shim.Error("error managing transient parameter details. value did not match schema:\n1. details.owner: Does not match pattern '^[a-z]+$'"), response)
+
+	cc.StrictArgs = true
+	response = callContractFunctionWithTransient(t, cc, []string{"CreatePrivate", "asset", "extra"}, details)
+	AssertProtoEqual(t, shim.Error("incorrect number of params. Expected 1, received 2"), response)
+}
+
 func TestInvoke(t *testing.T) {
 	testCallingContractFunctions(t, invokeType)
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/hyperledger/fabric-contract-api-go/v2/serializer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -81,3 +82,21 @@ func TestGetTransactionContextHandler(t *testing.T) {
 	mc.TransactionContextHandler = new(customContext)
 	assert.Equal(t, new(customContext), mc.GetTransactionContextHandler(), "should return custom context when set")
 }
+
+func TestGetContractTransactionSerializer(t *testing.T) {
+	mc := myContract{}
+
+	assert.Nil(t, mc.GetTransactionSerializer(), "should return nil when unset")
+
+	mc.TransactionSerializer = new(serializer.ProtobufSerializer)
+	assert.Equal(t, new(serializer.ProtobufSerializer), mc.GetTransactionSerializer(), "should return serializer when set")
+}
+
+func TestGetFunctionSerializers(t *testing.T) {
+	mc := myContract{}
+
+	assert.Nil(t, mc.GetFunctionSerializers(), "should return nil when unset")
+
+	mc.FunctionSerializers = map[string]serializer.TransactionSerializer{"Read": new(serializer.ProtobufSerializer)}
+	assert.Equal(t, map[string]serializer.TransactionSerializer{"Read": new(serializer.ProtobufSerializer)}, mc.GetFunctionSerializers(), "should return serializers when set")
+}
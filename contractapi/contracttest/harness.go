@@ -0,0 +1,276 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contracttest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/hyperledger/fabric-chaincode-go/v2/shim"
+	"github.com/hyperledger/fabric-contract-api-go/v2/contractapi"
+	"github.com/hyperledger/fabric-contract-api-go/v2/serializer"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+)
+
+// DefaultMSPID is the MSP ID of the identity used by a harness when no other is set
+const DefaultMSPID = "Org1MSP"
+
+// Harness calls functions of contract chaincode using an in-memory stub. Args and results
+// are converted using the serializer the chaincode uses for the function called
+type Harness struct {
+	Chaincode *contractapi.ContractChaincode
+	Stub      *Stub
+	identity  *Identity
+}
+
+// NewHarness creates a harness for the chaincode with an empty ledger. Functions are called
+// using an identity of DefaultMSPID unless another is set
+func NewHarness(cc *contractapi.ContractChaincode) *Harness {
+	return &Harness{
+		Chaincode: cc,
+		Stub:      NewStub(),
+		identity:  NewIdentity(DefaultMSPID),
+	}
+}
+
+// SetIdentity sets the identity used to call functions unless another is set for the call
+func (h *Harness) SetIdentity(identity *Identity) {
+	h.identity = identity
+}
+
+// AsIdentity starts a call made using the identity
+func (h *Harness) AsIdentity(identity *Identity) *Call {
+	return h.newCall().AsIdentity(identity)
+}
+
+// WithTransient starts a call made with the transient data
+func (h *Harness) WithTransient(transient map[string][]byte) *Call {
+	return h.newCall().WithTransient(transient)
+}
+
+// WithTxID starts a call made using the transaction ID
+func (h *Harness) WithTxID(txID string) *Call {
+	return h.newCall().WithTxID(txID)
+}
+
+// Invoke calls the function as a submitted transaction. See Call.Invoke
+func (h *Harness) Invoke(fn string, args ...interface{}) *Result {
+	return h.newCall().Invoke(fn, args...)
+}
+
+// Evaluate calls the function as an evaluated transaction. See Call.Evaluate
+func (h *Harness) Evaluate(fn string, args ...interface{}) *Result {
+	return h.newCall().Evaluate(fn, args...)
+}
+
+func (h *Harness) newCall() *Call {
+	return &Call{harness: h, identity: h.identity}
+}
+
+// Call is a call of a function being built
+type Call struct {
+	harness   *Harness
+	identity  *Identity
+	transient map[string][]byte
+	txID      string
+}
+
+// AsIdentity sets the identity used to make the call. If nil the call is made without a
+// creator
+func (c *Call) AsIdentity(identity *Identity) *Call {
+	c.identity = identity
+	return c
+}
+
+// WithTransient sets the transient data of the call
+func (c *Call) WithTransient(transient map[string][]byte) *Call {
+	c.transient = transient
+	return c
+}
+
+// WithTxID sets the transaction ID of the call. If not set one is generated
+func (c *Call) WithTxID(txID string) *Call {
+	c.txID = txID
+	return c
+}
+
+// Invoke calls the function, named in the form contract:function as when calling chaincode,
+// through the Invoke function of the chaincode. The values written by the function are
+// written to the ledger if the call succeeds, as for a submitted transaction. Args that are
+// not strings or bytes are converted using the serializer the chaincode uses for the function.
+// If that serializer implements serializer.BytesTransactionSerializer strings are converted
+// too, using ToBytes, and only bytes are passed as they are
+func (c *Call) Invoke(fn string, args ...interface{}) *Result {
+	return c.call(fn, args, true)
+}
+
+// Evaluate calls the function in the same way as Invoke but discards the values written by
+// the function, as for an evaluated transaction
+func (c *Call) Evaluate(fn string, args ...interface{}) *Result {
+	return c.call(fn, args, false)
+}
+
+func (c *Call) call(fn string, args []interface{}, submit bool) *Result {
+	txSerializer := c.harness.Chaincode.FunctionSerializer(fn)
+	result := &Result{serializer: txSerializer}
+
+	stringArgs, err := toArgs(txSerializer, fn, args)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	var creator []byte
+
+	if c.identity != nil {
+		creator, err = c.identity.Bytes()
+		if err != nil {
+			result.err = err
+			return result
+		}
+	}
+
+	stub := c.harness.Stub
+	stub.StartTransaction(c.txID, stringArgs)
+	stub.SetCreator(creator)
+	_ = stub.SetTransient(c.transient)
+
+	response := c.harness.Chaincode.Invoke(stub)
+
+	result.TxID = stub.TxID
+	result.Response = response
+	result.Event = stub.EndTransaction(submit && response.Status < shim.ERRORTHRESHOLD)
+
+	return result
+}
+
+func toArgs(txSerializer serializer.TransactionSerializer, fn string, args []interface{}) ([][]byte, error) {
+	stringArgs := [][]byte{[]byte(fn)}
+
+	bytesSerializer, useBytes := txSerializer.(serializer.BytesTransactionSerializer)
+
+	for i, arg := range args {
+		var bytes []byte
+		var err error
+
+		switch value := arg.(type) {
+		case nil:
+			bytes = []byte{}
+		case []byte:
+			bytes = value
+		case string:
+			if !useBytes {
+				bytes = []byte(value)
+				break
+			}
+
+			bytes, err = bytesSerializer.ToBytes(reflect.ValueOf(arg), reflect.TypeOf(arg), nil, nil)
+		default:
+			if useBytes {
+				bytes, err = bytesSerializer.ToBytes(reflect.ValueOf(arg), reflect.TypeOf(arg), nil, nil)
+				break
+			}
+
+			var str string
+			str, err = txSerializer.ToString(reflect.ValueOf(arg), reflect.TypeOf(arg), nil, nil)
+			bytes = []byte(str)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert arg %d. %w", i, err)
+		}
+
+		stringArgs = append(stringArgs, bytes)
+	}
+
+	return stringArgs, nil
+}
+
+// Result is the result of calling a function
+type Result struct {
+	// TxID is the ID of the transaction the function was called in
+	TxID string
+	// Response is the response returned by the chaincode
+	Response *peer.Response
+	// Event is the event set by the function, if any
+	Event *peer.ChaincodeEvent
+
+	serializer serializer.TransactionSerializer
+	err        error
+}
+
+// Err returns an error if the function could not be called or the chaincode returned an
+// error response. Error responses are returned as a ChaincodeError so that they can be
+// compared with the errors of the contract using errors.Is
+func (r *Result) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	if r.Response.Status < shim.ERRORTHRESHOLD {
+		return nil
+	}
+
+	ccErr := contractapi.NewChaincodeError(r.Response.Status, "", r.Response.Message)
+
+	if len(r.Response.Payload) > 0 {
+		_ = json.Unmarshal(r.Response.Payload, ccErr)
+	}
+
+	return ccErr
+}
+
+// String returns the payload of a successful response as a string
+func (r *Result) String() string {
+	if r.Response == nil {
+		return ""
+	}
+
+	return string(r.Response.Payload)
+}
+
+// Events returns the events set by the function. If the function emitted events using
+// BatchEvent the events of the batch are returned
+func (r *Result) Events() ([]contractapi.BatchedEvent, error) {
+	if r.Event == nil {
+		return nil, nil
+	}
+
+	return contractapi.ParseEvents(r.Event.EventName, r.Event.Payload)
+}
+
+// Decode converts the payload of a successful response using the serializer the chaincode
+// uses for the function called, using FromBytes if it implements
+// serializer.BytesTransactionSerializer, and sets the value pointed to by target to it. It
+// returns the error of Err if the call failed
+func (r *Result) Decode(target interface{}) error {
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	targetValue := reflect.ValueOf(target)
+
+	if targetValue.Kind() != reflect.Pointer || targetValue.IsNil() {
+		return errors.New("target must be a non-nil pointer")
+	}
+
+	var value reflect.Value
+	var err error
+
+	if bytesSerializer, ok := r.serializer.(serializer.BytesTransactionSerializer); ok {
+		value, err = bytesSerializer.FromBytes(r.Response.Payload, targetValue.Type().Elem(), nil, nil)
+	} else {
+		value, err = r.serializer.FromString(string(r.Response.Payload), targetValue.Type().Elem(), nil, nil)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to decode payload. %w", err)
+	}
+
+	targetValue.Elem().Set(value)
+
+	return nil
+}
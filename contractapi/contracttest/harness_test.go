@@ -0,0 +1,263 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contracttest
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/fabric-contract-api-go/v2/contractapi"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/hyperledger/fabric-contract-api-go/v2/serializer"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// ================================
+// HELPERS
+// ================================
+
+var errAssetNotFound = contractapi.NewChaincodeError(404, "ASSET_NOT_FOUND", "asset not found")
+
+type asset struct {
+	ID    string `json:"id"`
+	Value int    `json:"value"`
+}
+
+type assetContract struct {
+	contractapi.Contract
+}
+
+func (ac *assetContract) Create(ctx contractapi.TransactionContextInterface, a asset) error {
+	bytes, _ := json.Marshal(a)
+
+	if err := ctx.GetStub().PutState(a.ID, bytes); err != nil {
+		return err
+	}
+
+	return ctx.GetStub().SetEvent("Created", []byte(a.ID))
+}
+
+func (ac *assetContract) Transfer(ctx contractapi.TransactionContextInterface, id string, owner string) error {
+	ectx := ctx.(contractapi.EventTransactionContextInterface)
+
+	if err := ectx.BatchEvent("Transferred", id); err != nil {
+		return err
+	}
+
+	return ectx.BatchEvent("OwnerSet", owner)
+}
+
+func (ac *assetContract) Read(ctx contractapi.TransactionContextInterface, id string) (*asset, error) {
+	bytes, err := ctx.GetStub().GetState(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes == nil {
+		return nil, errAssetNotFound.WithDetails(id)
+	}
+
+	a := new(asset)
+	err = json.Unmarshal(bytes, a)
+
+	return a, err
+}
+
+func (ac *assetContract) Secret(ctx contractapi.TransactionContextInterface) (string, error) {
+	transient, err := ctx.GetStub().GetTransient()
+	return string(transient["secret"]), err
+}
+
+func (ac *assetContract) Whoami(ctx contractapi.TransactionContextInterface) (string, error) {
+	return ctx.GetClientIdentity().GetMSPID()
+}
+
+func (ac *assetContract) Admin() string {
+	return "admin"
+}
+
+func (ac *assetContract) GetAccessPolicies() map[string]metadata.AccessPolicy {
+	return map[string]metadata.AccessPolicy{
+		"Admin": {Attributes: map[string]string{"role": "admin"}},
+	}
+}
+
+func newHarness(t *testing.T) *Harness {
+	t.Helper()
+
+	cc, err := contractapi.NewChaincode(new(assetContract))
+	require.NoError(t, err)
+
+	return NewHarness(cc)
+}
+
+// ================================
+// TESTS
+// ================================
+
+type chaincodeIDContract struct {
+	contractapi.Contract
+}
+
+func (cc *chaincodeIDContract) Create(ctx contractapi.TransactionContextInterface, id *peer.ChaincodeID) error {
+	bytes, err := proto.Marshal(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.GetStub().PutState(id.Name, bytes)
+}
+
+func (cc *chaincodeIDContract) Read(ctx contractapi.TransactionContextInterface, name string) (*peer.ChaincodeID, error) {
+	bytes, err := ctx.GetStub().GetState(name)
+	if err != nil {
+		return nil, err
+	}
+
+	id := new(peer.ChaincodeID)
+	err = proto.Unmarshal(bytes, id)
+
+	return id, err
+}
+
+type serializersContract struct {
+	contractapi.Contract
+}
+
+func (sc *serializersContract) Echo(a asset) asset {
+	return a
+}
+
+func (sc *serializersContract) Name(name string) string {
+	return name
+}
+
+func TestHarnessInvoke(t *testing.T) {
+	h := newHarness(t)
+
+	result := h.WithTxID("create1").Invoke("assetContract:Create", asset{ID: "asset1", Value: 10})
+	require.NoError(t, result.Err())
+	require.Equal(t, "create1", result.TxID, "should use transaction ID")
+	require.Equal(t, "Created", result.Event.EventName, "should return event")
+	require.Equal(t, "asset1", string(result.Event.Payload), "should return event payload")
+	require.Len(t, h.Stub.Events(), 1, "should record event of submitted transaction")
+
+	var read *asset
+	require.NoError(t, h.Evaluate("assetContract:Read", "asset1").Decode(&read))
+	require.Equal(t, &asset{ID: "asset1", Value: 10}, read, "should decode result")
+
+	result = h.Evaluate("assetContract:Create", asset{ID: "asset2", Value: 20})
+	require.NoError(t, result.Err())
+	require.Len(t, h.Stub.Events(), 1, "should not record event of evaluated transaction")
+
+	result = h.Evaluate("assetContract:Read", "asset2")
+	require.ErrorIs(t, result.Err(), errAssetNotFound, "should discard writes of evaluated transaction")
+	require.ErrorIs(t, result.Decode(&read), errAssetNotFound, "should return error when decoding failed call")
+
+	var ccErr *contractapi.ChaincodeError
+	require.ErrorAs(t, result.Err(), &ccErr)
+	require.Equal(t, int32(404), ccErr.Status, "should return status of error")
+	require.Equal(t, "asset2", ccErr.Details, "should return details of error")
+
+	result = h.Invoke("assetContract:Missing")
+	require.EqualError(t, result.Err(), "Function Missing not found in contract assetContract", "should return error without code")
+
+	require.EqualError(t, h.Evaluate("assetContract:Read", "asset1").Decode(asset{}), "target must be a non-nil pointer")
+
+	var value int
+	require.ErrorContains(t, h.Evaluate("assetContract:Read", "asset1").Decode(&value), "failed to decode payload", "should error when payload cannot be decoded")
+}
+
+func TestHarnessEvents(t *testing.T) {
+	h := newHarness(t)
+
+	events, err := h.Invoke("assetContract:Create", asset{ID: "asset1"}).Events()
+	require.NoError(t, err)
+	require.Equal(t, []contractapi.BatchedEvent{{Name: "Created", Payload: []byte("asset1")}}, events, "should return single event")
+
+	events, err = h.Invoke("assetContract:Transfer", "asset1", "alice").Events()
+	require.NoError(t, err)
+	require.Equal(t, []contractapi.BatchedEvent{{Name: "Transferred", Payload: []byte("asset1")}, {Name: "OwnerSet", Payload: []byte("alice")}}, events, "should return batched events")
+
+	events, err = h.Evaluate("assetContract:Read", "asset1").Events()
+	require.NoError(t, err)
+	require.Nil(t, events, "should return no events when none set")
+}
+
+func TestHarnessIdentity(t *testing.T) {
+	h := newHarness(t)
+
+	var mspID string
+	require.NoError(t, h.Evaluate("assetContract:Whoami").Decode(&mspID))
+	require.Equal(t, DefaultMSPID, mspID, "should use default identity")
+
+	require.NoError(t, h.AsIdentity(NewIdentity("Org2MSP")).Evaluate("assetContract:Whoami").Decode(&mspID))
+	require.Equal(t, "Org2MSP", mspID, "should use identity of call")
+
+	h.SetIdentity(NewIdentity("Org3MSP"))
+	require.NoError(t, h.Evaluate("assetContract:Whoami").Decode(&mspID))
+	require.Equal(t, "Org3MSP", mspID, "should use identity of harness")
+
+	require.ErrorIs(t, h.Evaluate("assetContract:Admin").Err(), contractapi.ErrAccessDenied, "should deny access without attribute")
+	require.NoError(t, h.AsIdentity(NewIdentity("Org1MSP").WithAttribute("role", "admin")).Evaluate("assetContract:Admin").Err(), "should allow access with attribute")
+	require.ErrorIs(t, h.AsIdentity(nil).Evaluate("assetContract:Admin").Err(), contractapi.ErrAccessDenied, "should deny access without creator")
+}
+
+func TestHarnessTransient(t *testing.T) {
+	h := newHarness(t)
+
+	result := h.WithTransient(map[string][]byte{"secret": []byte("value")}).Evaluate("assetContract:Secret")
+	require.NoError(t, result.Err())
+	require.Equal(t, "value", result.String(), "should pass transient data")
+
+	require.Equal(t, "", h.Evaluate("assetContract:Secret").String(), "should not keep transient data between calls")
+	require.Equal(t, "", new(Result).String(), "should return blank string without response")
+}
+
+func TestHarnessProtobuf(t *testing.T) {
+	cc, err := contractapi.NewChaincode(new(chaincodeIDContract))
+	require.NoError(t, err)
+	cc.TransactionSerializer = new(serializer.ProtobufSerializer)
+
+	h := NewHarness(cc)
+
+	expected := &peer.ChaincodeID{Name: "basic", Version: "1.0"}
+	require.NoError(t, h.Invoke("chaincodeIDContract:Create", expected).Err())
+
+	var read *peer.ChaincodeID
+	require.NoError(t, h.Evaluate("chaincodeIDContract:Read", "basic").Decode(&read))
+	require.True(t, proto.Equal(expected, read), "should pass and return protobuf messages")
+
+	var ccMetadata metadata.ContractChaincodeMetadata
+	require.NoError(t, json.Unmarshal(h.Evaluate("org.hyperledger.fabric:GetMetadata").Response.Payload, &ccMetadata))
+	require.Contains(t, ccMetadata.Components.Schemas, "protos.ChaincodeID", "should describe message in metadata")
+}
+
+func TestHarnessSerializers(t *testing.T) {
+	sc := new(serializersContract)
+	sc.TransactionSerializer = new(serializer.CBORSerializer)
+	sc.FunctionSerializers = map[string]serializer.TransactionSerializer{"Name": new(serializer.JSONSerializer)}
+
+	cc, err := contractapi.NewChaincode(sc)
+	require.NoError(t, err)
+
+	h := NewHarness(cc)
+
+	var echoed asset
+	require.NoError(t, h.Evaluate("serializersContract:Echo", asset{ID: "asset1", Value: 10}).Decode(&echoed))
+	require.Equal(t, asset{ID: "asset1", Value: 10}, echoed, "should convert args and result using serializer of contract")
+
+	encoded, err := new(serializer.CBORSerializer).ToBytes(reflect.ValueOf(asset{ID: "asset2"}), reflect.TypeOf(asset{}), nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, h.Evaluate("serializersContract:Echo", encoded).Decode(&echoed))
+	require.Equal(t, asset{ID: "asset2"}, echoed, "should pass bytes as they are")
+
+	var name string
+	require.NoError(t, h.Evaluate("serializersContract:Name", "alice").Decode(&name))
+	require.Equal(t, "alice", name, "should convert args and result using serializer of function")
+	require.Equal(t, "alice", h.Evaluate("serializersContract:Name", "alice").String(), "should pass strings as they are to string serializers")
+}
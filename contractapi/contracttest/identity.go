@@ -0,0 +1,105 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contracttest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/msp"
+	"google.golang.org/protobuf/proto"
+)
+
+// attributesOID is the certificate extension in which Fabric CA stores attributes
+var attributesOID = asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 7, 8, 1}
+
+// Identity builds a serialized X.509 identity for use as the creator of transactions.
+// The certificate is self-signed, so is only of use where the identity is read using the
+// cid package and not verified
+type Identity struct {
+	mspID      string
+	commonName string
+	ous        []string
+	attributes map[string]string
+}
+
+// NewIdentity returns an identity with the MSP ID and a common name of user1
+func NewIdentity(mspID string) *Identity {
+	return &Identity{
+		mspID:      mspID,
+		commonName: "user1",
+		attributes: make(map[string]string),
+	}
+}
+
+// WithCommonName sets the common name of the certificate subject
+func (i *Identity) WithCommonName(commonName string) *Identity {
+	i.commonName = commonName
+	return i
+}
+
+// WithOU adds an organizational unit to the certificate subject
+func (i *Identity) WithOU(ou string) *Identity {
+	i.ous = append(i.ous, ou)
+	return i
+}
+
+// WithAttribute adds an attribute to the certificate in the form used by Fabric CA
+func (i *Identity) WithAttribute(name, value string) *Identity {
+	i.attributes[name] = value
+	return i
+}
+
+// MSPID returns the MSP ID of the identity
+func (i *Identity) MSPID() string {
+	return i.mspID
+}
+
+// Bytes returns the identity serialized as returned by GetCreator of a stub
+func (i *Identity) Bytes() ([]byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key. %w", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: i.commonName, OrganizationalUnit: i.ous},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+
+	if len(i.attributes) > 0 {
+		attrs, err := json.Marshal(map[string]interface{}{"attrs": i.attributes})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal attributes. %w", err)
+		}
+
+		template.ExtraExtensions = []pkix.Extension{{Id: attributesOID, Value: attrs}}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate. %w", err)
+	}
+
+	serialized, err := proto.Marshal(&msp.SerializedIdentity{
+		Mspid:   i.mspID,
+		IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize identity. %w", err)
+	}
+
+	return serialized, nil
+}
@@ -0,0 +1,48 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contracttest
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/v2/pkg/cid"
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// TESTS
+// ================================
+
+func TestIdentity(t *testing.T) {
+	identity := NewIdentity("Org2MSP").WithCommonName("admin").WithOU("client").WithAttribute("role", "manager")
+	require.Equal(t, "Org2MSP", identity.MSPID(), "should return MSP ID")
+
+	creator, err := identity.Bytes()
+	require.NoError(t, err)
+
+	stub := NewStub()
+	stub.SetCreator(creator)
+
+	ci, err := cid.New(stub)
+	require.NoError(t, err)
+
+	mspID, _ := ci.GetMSPID()
+	require.Equal(t, "Org2MSP", mspID, "should serialize MSP ID")
+
+	cert, _ := ci.GetX509Certificate()
+	require.Equal(t, "admin", cert.Subject.CommonName, "should set common name")
+	require.Equal(t, []string{"client"}, cert.Subject.OrganizationalUnit, "should set OUs")
+
+	value, found, _ := ci.GetAttributeValue("role")
+	require.True(t, found, "should add attribute")
+	require.Equal(t, "manager", value, "should set attribute value")
+
+	creator, err = NewIdentity("Org1MSP").Bytes()
+	require.NoError(t, err)
+	stub.SetCreator(creator)
+	ci, err = cid.New(stub)
+	require.NoError(t, err)
+	_, found, _ = ci.GetAttributeValue("role")
+	require.False(t, found, "should not add attributes when none set")
+}
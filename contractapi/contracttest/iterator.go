@@ -0,0 +1,68 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contracttest
+
+import (
+	"errors"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/queryresult"
+)
+
+var errIteratorExhausted = errors.New("iterator has no more results")
+
+// stateIterator iterates over a copy of key values taken when the query was made
+type stateIterator struct {
+	kvs    []*queryresult.KV
+	closed bool
+}
+
+func newIterator(kvs []*queryresult.KV) *stateIterator {
+	return &stateIterator{kvs: kvs}
+}
+
+func (si *stateIterator) HasNext() bool {
+	return !si.closed && len(si.kvs) > 0
+}
+
+func (si *stateIterator) Next() (*queryresult.KV, error) {
+	if !si.HasNext() {
+		return nil, errIteratorExhausted
+	}
+
+	kv := si.kvs[0]
+	si.kvs = si.kvs[1:]
+
+	return kv, nil
+}
+
+func (si *stateIterator) Close() error {
+	si.closed = true
+	return nil
+}
+
+// historyIterator iterates over the modifications of a key taken when the query was made
+type historyIterator struct {
+	modifications []*queryresult.KeyModification
+	closed        bool
+}
+
+func (hi *historyIterator) HasNext() bool {
+	return !hi.closed && len(hi.modifications) > 0
+}
+
+func (hi *historyIterator) Next() (*queryresult.KeyModification, error) {
+	if !hi.HasNext() {
+		return nil, errIteratorExhausted
+	}
+
+	modification := hi.modifications[0]
+	hi.modifications = hi.modifications[1:]
+
+	return modification, nil
+}
+
+func (hi *historyIterator) Close() error {
+	hi.closed = true
+	return nil
+}
@@ -0,0 +1,599 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package contracttest provides an in-memory ledger and helpers for unit testing contracts
+// and chaincode created using the contractapi package without a Fabric network.
+package contracttest
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric-chaincode-go/v2/shim"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const compositeKeyNamespace = "\x00"
+
+// ErrNotSupported is returned by functions of the stub that the in-memory ledger cannot
+// provide, such as rich queries
+var ErrNotSupported = errors.New("not supported by the in-memory stub")
+
+// Stub is an in-memory implementation of shim.ChaincodeStubInterface. Values written
+// during a transaction are held separately from the ledger until the transaction ends
+// and are only written to it if the transaction is committed, so as in Fabric a
+// transaction does not read its own writes. Values written outside of a transaction are
+// written directly to the ledger, which can be used to set up the ledger for a test.
+// A Stub is not safe for concurrent use
+type Stub struct {
+	// TxID is the ID of the current transaction
+	TxID string
+	// ChannelID is the ID of the channel returned to chaincode
+	ChannelID string
+	// TxTimestamp is the timestamp of the current transaction
+	TxTimestamp *timestamppb.Timestamp
+
+	args       [][]byte
+	creator    []byte
+	transient  map[string][]byte
+	inTx       bool
+	txCount    int
+	event      *peer.ChaincodeEvent
+	events     []*peer.ChaincodeEvent
+	state      map[string][]byte
+	private    map[string]map[string][]byte
+	writes     map[string]*write
+	history    map[string][]*queryresult.KeyModification
+	parameters map[string][]byte
+	chaincodes map[string]shim.Chaincode
+}
+
+type write struct {
+	collection string
+	key        string
+	value      []byte
+	purge      bool
+}
+
+// NewStub creates a stub with an empty ledger
+func NewStub() *Stub {
+	return &Stub{
+		ChannelID:  "mychannel",
+		state:      make(map[string][]byte),
+		private:    make(map[string]map[string][]byte),
+		writes:     make(map[string]*write),
+		history:    make(map[string][]*queryresult.KeyModification),
+		parameters: make(map[string][]byte),
+		chaincodes: make(map[string]shim.Chaincode),
+	}
+}
+
+// StartTransaction starts a transaction with the given ID and args. Values written until
+// the transaction ends are held separately from the ledger
+func (s *Stub) StartTransaction(txID string, args [][]byte) {
+	s.txCount++
+
+	if txID == "" {
+		txID = fmt.Sprintf("tx%d", s.txCount)
+	}
+
+	s.TxID = txID
+	s.TxTimestamp = timestamppb.Now()
+	s.args = args
+	s.inTx = true
+	s.event = nil
+	s.writes = make(map[string]*write)
+}
+
+// EndTransaction ends the current transaction. If commit is true the values written during
+// the transaction are written to the ledger and its event recorded, otherwise they are
+// discarded. The event set by the transaction is returned
+func (s *Stub) EndTransaction(commit bool) *peer.ChaincodeEvent {
+	event := s.event
+
+	if commit {
+		keys := make([]string, 0, len(s.writes))
+		for key := range s.writes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			s.apply(s.writes[key])
+		}
+
+		if event != nil {
+			s.events = append(s.events, event)
+		}
+	}
+
+	s.inTx = false
+	s.event = nil
+	s.writes = make(map[string]*write)
+	s.transient = nil
+
+	return event
+}
+
+// SetCreator sets the serialized identity returned by GetCreator. Identity.Bytes returns
+// a serialized identity
+func (s *Stub) SetCreator(creator []byte) {
+	s.creator = creator
+}
+
+// SetTransient sets the transient data returned by GetTransient. Transient data is cleared
+// when a transaction ends
+func (s *Stub) SetTransient(transient map[string][]byte) error {
+	s.transient = transient
+	return nil
+}
+
+// Events returns the events of committed transactions in the order they were committed
+func (s *Stub) Events() []*peer.ChaincodeEvent {
+	return s.events
+}
+
+// RegisterChaincode registers chaincode to be called by InvokeChaincode using the name
+func (s *Stub) RegisterChaincode(name string, cc shim.Chaincode) {
+	s.chaincodes[name] = cc
+}
+
+// GetArgs returns the args of the current transaction
+func (s *Stub) GetArgs() [][]byte {
+	return s.args
+}
+
+// GetStringArgs returns the args of the current transaction as strings
+func (s *Stub) GetStringArgs() []string {
+	args := make([]string, 0, len(s.args))
+
+	for _, arg := range s.args {
+		args = append(args, string(arg))
+	}
+
+	return args
+}
+
+// GetFunctionAndParameters returns the first arg of the current transaction as the function
+// and the rest as its parameters
+func (s *Stub) GetFunctionAndParameters() (string, []string) {
+	args := s.GetStringArgs()
+
+	if len(args) == 0 {
+		return "", []string{}
+	}
+
+	return args[0], args[1:]
+}
+
+// GetArgsSlice returns the args of the current transaction joined together
+func (s *Stub) GetArgsSlice() ([]byte, error) {
+	slice := []byte{}
+
+	for _, arg := range s.args {
+		slice = append(slice, arg...)
+	}
+
+	return slice, nil
+}
+
+// GetTxID returns the ID of the current transaction
+func (s *Stub) GetTxID() string {
+	return s.TxID
+}
+
+// GetChannelID returns the ID of the channel
+func (s *Stub) GetChannelID() string {
+	return s.ChannelID
+}
+
+// InvokeChaincode calls Invoke of chaincode registered using RegisterChaincode with a new
+// stub. The called chaincode has its own ledger
+func (s *Stub) InvokeChaincode(chaincodeName string, args [][]byte, channel string) *peer.Response {
+	cc, ok := s.chaincodes[chaincodeName]
+	if !ok {
+		return shim.Error(fmt.Sprintf("chaincode %s is not registered", chaincodeName))
+	}
+
+	stub := NewStub()
+	stub.ChannelID = channel
+	stub.creator = s.creator
+	stub.StartTransaction(s.TxID, args)
+	defer stub.EndTransaction(false)
+
+	return cc.Invoke(stub)
+}
+
+// GetState returns the value of the key in the ledger
+func (s *Stub) GetState(key string) ([]byte, error) {
+	return s.state[key], nil
+}
+
+// GetMultipleStates returns the values of the keys in the ledger
+func (s *Stub) GetMultipleStates(keys ...string) ([][]byte, error) {
+	values := make([][]byte, 0, len(keys))
+
+	for _, key := range keys {
+		values = append(values, s.state[key])
+	}
+
+	return values, nil
+}
+
+// PutState writes the value of the key. Writing an empty value deletes the key
+func (s *Stub) PutState(key string, value []byte) error {
+	if key == "" {
+		return errors.New("key must not be an empty string")
+	}
+
+	return s.write(&write{key: key, value: value})
+}
+
+// DelState deletes the key
+func (s *Stub) DelState(key string) error {
+	return s.write(&write{key: key})
+}
+
+// SetStateValidationParameter sets the key-level endorsement policy of the key
+func (s *Stub) SetStateValidationParameter(key string, ep []byte) error {
+	s.parameters[ledgerKey("", key)] = ep
+	return nil
+}
+
+// GetStateValidationParameter returns the key-level endorsement policy of the key
+func (s *Stub) GetStateValidationParameter(key string) ([]byte, error) {
+	return s.parameters[ledgerKey("", key)], nil
+}
+
+// GetStateByRange returns the simple keys in the ledger from startKey (inclusive) to endKey
+// (exclusive) in key order. Blank keys leave the range unbounded
+func (s *Stub) GetStateByRange(startKey string, endKey string) (shim.StateQueryIteratorInterface, error) {
+	return newIterator(rangeOf(s.state, startKey, endKey, false)), nil
+}
+
+// GetStateByRangeWithPagination returns a page of the simple keys in the ledger from startKey
+// (inclusive) to endKey (exclusive). The bookmark is the first key of the page
+func (s *Stub) GetStateByRangeWithPagination(startKey, endKey string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	iterator, metadata := paginate(rangeOf(s.state, startKey, endKey, false), pageSize, bookmark)
+	return iterator, metadata, nil
+}
+
+// GetStateByPartialCompositeKey returns the composite keys in the ledger starting with the
+// partial composite key in key order
+func (s *Stub) GetStateByPartialCompositeKey(objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
+	kvs, err := s.partialCompositeKey(s.state, objectType, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return newIterator(kvs), nil
+}
+
+// GetStateByPartialCompositeKeyWithPagination returns a page of the composite keys in the ledger
+// starting with the partial composite key. The bookmark is the first key of the page
+func (s *Stub) GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	kvs, err := s.partialCompositeKey(s.state, objectType, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	iterator, metadata := paginate(kvs, pageSize, bookmark)
+	return iterator, metadata, nil
+}
+
+// GetAllStatesCompositeKeyWithPagination returns a page of all composite keys in the ledger.
+// The bookmark is the first key of the page
+func (s *Stub) GetAllStatesCompositeKeyWithPagination(pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	iterator, metadata := paginate(rangeOf(s.state, "", "", true), pageSize, bookmark)
+	return iterator, metadata, nil
+}
+
+// CreateCompositeKey combines the object type and attributes into a composite key
+func (s *Stub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
+	return shim.CreateCompositeKey(objectType, attributes)
+}
+
+// SplitCompositeKey splits a composite key into its object type and attributes
+func (s *Stub) SplitCompositeKey(compositeKey string) (string, []string, error) {
+	if !strings.HasPrefix(compositeKey, compositeKeyNamespace) {
+		return "", nil, fmt.Errorf("%q is not a composite key", compositeKey)
+	}
+
+	components := strings.Split(strings.TrimSuffix(compositeKey[1:], "\x00"), "\x00")
+
+	return components[0], components[1:], nil
+}
+
+// GetQueryResult returns ErrNotSupported as the in-memory ledger has no query engine
+func (s *Stub) GetQueryResult(query string) (shim.StateQueryIteratorInterface, error) {
+	return nil, ErrNotSupported
+}
+
+// GetQueryResultWithPagination returns ErrNotSupported as the in-memory ledger has no query engine
+func (s *Stub) GetQueryResultWithPagination(query string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	return nil, nil, ErrNotSupported
+}
+
+// GetHistoryForKey returns the committed modifications of the key, oldest first
+func (s *Stub) GetHistoryForKey(key string) (shim.HistoryQueryIteratorInterface, error) {
+	return &historyIterator{modifications: s.history[key]}, nil
+}
+
+// GetPrivateData returns the value of the key in the private data collection
+func (s *Stub) GetPrivateData(collection, key string) ([]byte, error) {
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+
+	return s.private[collection][key], nil
+}
+
+// GetMultiplePrivateData returns the values of the keys in the private data collection
+func (s *Stub) GetMultiplePrivateData(collection string, keys ...string) ([][]byte, error) {
+	values := make([][]byte, 0, len(keys))
+
+	for _, key := range keys {
+		value, err := s.GetPrivateData(collection, key)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// GetPrivateDataHash returns the SHA-256 hash of the value of the key in the private data
+// collection, or nil if the key has no value
+func (s *Stub) GetPrivateDataHash(collection, key string) ([]byte, error) {
+	value, err := s.GetPrivateData(collection, key)
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(value)
+
+	return hash[:], nil
+}
+
+// PutPrivateData writes the value of the key in the private data collection
+func (s *Stub) PutPrivateData(collection string, key string, value []byte) error {
+	if collection == "" {
+		return errors.New("collection must not be an empty string")
+	}
+
+	if key == "" {
+		return errors.New("key must not be an empty string")
+	}
+
+	return s.write(&write{collection: collection, key: key, value: value})
+}
+
+// DelPrivateData deletes the key in the private data collection
+func (s *Stub) DelPrivateData(collection, key string) error {
+	if collection == "" {
+		return errors.New("collection must not be an empty string")
+	}
+
+	return s.write(&write{collection: collection, key: key})
+}
+
+// PurgePrivateData deletes the key in the private data collection
+func (s *Stub) PurgePrivateData(collection, key string) error {
+	if collection == "" {
+		return errors.New("collection must not be an empty string")
+	}
+
+	return s.write(&write{collection: collection, key: key, purge: true})
+}
+
+// SetPrivateDataValidationParameter sets the key-level endorsement policy of the key in the
+// private data collection
+func (s *Stub) SetPrivateDataValidationParameter(collection, key string, ep []byte) error {
+	s.parameters[ledgerKey(collection, key)] = ep
+	return nil
+}
+
+// GetPrivateDataValidationParameter returns the key-level endorsement policy of the key in
+// the private data collection
+func (s *Stub) GetPrivateDataValidationParameter(collection, key string) ([]byte, error) {
+	return s.parameters[ledgerKey(collection, key)], nil
+}
+
+// GetPrivateDataByRange returns the simple keys in the private data collection from startKey
+// (inclusive) to endKey (exclusive) in key order
+func (s *Stub) GetPrivateDataByRange(collection, startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+
+	return newIterator(rangeOf(s.private[collection], startKey, endKey, false)), nil
+}
+
+// GetPrivateDataByPartialCompositeKey returns the composite keys in the private data collection
+// starting with the partial composite key in key order
+func (s *Stub) GetPrivateDataByPartialCompositeKey(collection, objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+
+	kvs, err := s.partialCompositeKey(s.private[collection], objectType, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return newIterator(kvs), nil
+}
+
+// GetPrivateDataQueryResult returns ErrNotSupported as the in-memory ledger has no query engine
+func (s *Stub) GetPrivateDataQueryResult(collection, query string) (shim.StateQueryIteratorInterface, error) {
+	return nil, ErrNotSupported
+}
+
+// GetCreator returns the serialized identity set using SetCreator
+func (s *Stub) GetCreator() ([]byte, error) {
+	return s.creator, nil
+}
+
+// GetTransient returns the transient data set using SetTransient
+func (s *Stub) GetTransient() (map[string][]byte, error) {
+	if s.transient == nil {
+		return map[string][]byte{}, nil
+	}
+
+	return s.transient, nil
+}
+
+// GetBinding returns an empty binding
+func (s *Stub) GetBinding() ([]byte, error) {
+	return []byte{}, nil
+}
+
+// GetDecorations returns no decorations
+func (s *Stub) GetDecorations() map[string][]byte {
+	return map[string][]byte{}
+}
+
+// GetSignedProposal returns an empty signed proposal
+func (s *Stub) GetSignedProposal() (*peer.SignedProposal, error) {
+	return &peer.SignedProposal{}, nil
+}
+
+// GetTxTimestamp returns the timestamp of the current transaction
+func (s *Stub) GetTxTimestamp() (*timestamppb.Timestamp, error) {
+	if s.TxTimestamp == nil {
+		return nil, errors.New("no transaction has been started")
+	}
+
+	return s.TxTimestamp, nil
+}
+
+// SetEvent sets the event of the current transaction, replacing any event already set
+func (s *Stub) SetEvent(name string, payload []byte) error {
+	if name == "" {
+		return errors.New("event name can not be empty string")
+	}
+
+	event := &peer.ChaincodeEvent{TxId: s.TxID, EventName: name, Payload: payload}
+
+	if !s.inTx {
+		s.events = append(s.events, event)
+		return nil
+	}
+
+	s.event = event
+
+	return nil
+}
+
+// StartWriteBatch does nothing as writes are always batched by the in-memory stub
+func (s *Stub) StartWriteBatch() {}
+
+// FinishWriteBatch does nothing as writes are always batched by the in-memory stub
+func (s *Stub) FinishWriteBatch() error {
+	return nil
+}
+
+func (s *Stub) write(w *write) error {
+	if !s.inTx {
+		s.apply(w)
+		return nil
+	}
+
+	s.writes[ledgerKey(w.collection, w.key)] = w
+
+	return nil
+}
+
+func (s *Stub) apply(w *write) {
+	values := s.state
+
+	if w.collection != "" {
+		if _, ok := s.private[w.collection]; !ok {
+			s.private[w.collection] = make(map[string][]byte)
+		}
+
+		values = s.private[w.collection]
+	}
+
+	if len(w.value) == 0 {
+		delete(values, w.key)
+	} else {
+		values[w.key] = w.value
+	}
+
+	if w.collection == "" {
+		s.history[w.key] = append(s.history[w.key], &queryresult.KeyModification{
+			TxId:      s.TxID,
+			Value:     w.value,
+			Timestamp: s.TxTimestamp,
+			IsDelete:  len(w.value) == 0,
+		})
+	}
+}
+
+func (s *Stub) partialCompositeKey(values map[string][]byte, objectType string, keys []string) ([]*queryresult.KV, error) {
+	prefix, err := s.CreateCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	kvs := []*queryresult.KV{}
+
+	for _, kv := range rangeOf(values, "", "", true) {
+		if strings.HasPrefix(kv.Key, prefix) {
+			kvs = append(kvs, kv)
+		}
+	}
+
+	return kvs, nil
+}
+
+func ledgerKey(collection, key string) string {
+	return collection + "\x00" + key
+}
+
+func rangeOf(values map[string][]byte, startKey, endKey string, composite bool) []*queryresult.KV {
+	kvs := []*queryresult.KV{}
+
+	for key, value := range values {
+		if strings.HasPrefix(key, compositeKeyNamespace) != composite {
+			continue
+		}
+
+		if key >= startKey && (endKey == "" || key < endKey) {
+			kvs = append(kvs, &queryresult.KV{Key: key, Value: value})
+		}
+	}
+
+	sort.Slice(kvs, func(i, j int) bool {
+		return kvs[i].Key < kvs[j].Key
+	})
+
+	return kvs
+}
+
+func paginate(kvs []*queryresult.KV, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata) {
+	start := sort.Search(len(kvs), func(i int) bool {
+		return kvs[i].Key >= bookmark
+	})
+
+	end := len(kvs)
+	nextBookmark := ""
+
+	if pageSize > 0 && start+int(pageSize) < end {
+		end = start + int(pageSize)
+		nextBookmark = kvs[end].Key
+	}
+
+	page := kvs[start:end]
+
+	// #nosec G115 -- page is at most pageSize long
+	return newIterator(page), &peer.QueryResponseMetadata{FetchedRecordsCount: int32(len(page)), Bookmark: nextBookmark}
+}
@@ -0,0 +1,233 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contracttest
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/v2/shim"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// HELPERS
+// ================================
+
+type echoChaincode struct{}
+
+func (ec *echoChaincode) Init(stub shim.ChaincodeStubInterface) *peer.Response {
+	return shim.Success(nil)
+}
+
+func (ec *echoChaincode) Invoke(stub shim.ChaincodeStubInterface) *peer.Response {
+	fn, params := stub.GetFunctionAndParameters()
+	return shim.Success([]byte(fn + " " + stub.GetChannelID() + " " + params[0]))
+}
+
+func keys(t *testing.T, iterator shim.StateQueryIteratorInterface) []string {
+	t.Helper()
+
+	found := []string{}
+
+	for iterator.HasNext() {
+		kv, err := iterator.Next()
+		require.NoError(t, err)
+		found = append(found, kv.Key)
+	}
+
+	require.NoError(t, iterator.Close())
+
+	return found
+}
+
+// ================================
+// TESTS
+// ================================
+
+func TestStubImplementsInterface(t *testing.T) {
+	require.Implements(t, (*shim.ChaincodeStubInterface)(nil), NewStub(), "should implement chaincode stub interface")
+}
+
+func TestStubTransactions(t *testing.T) {
+	stub := NewStub()
+
+	require.NoError(t, stub.PutState("seeded", []byte("value")))
+	value, _ := stub.GetState("seeded")
+	require.Equal(t, []byte("value"), value, "should write directly outside of transaction")
+
+	stub.StartTransaction("", [][]byte{[]byte("fn"), []byte("arg")})
+	require.Equal(t, "tx1", stub.GetTxID(), "should generate transaction ID")
+	fn, params := stub.GetFunctionAndParameters()
+	require.Equal(t, "fn", fn, "should return function")
+	require.Equal(t, []string{"arg"}, params, "should return parameters")
+	slice, _ := stub.GetArgsSlice()
+	require.Equal(t, []byte("fnarg"), slice, "should return args joined")
+
+	require.NoError(t, stub.PutState("key", []byte("written")))
+	require.NoError(t, stub.DelState("seeded"))
+	require.NoError(t, stub.SetEvent("first", nil))
+	require.NoError(t, stub.SetEvent("second", []byte("payload")))
+	value, _ = stub.GetState("key")
+	require.Nil(t, value, "should not read own writes")
+	value, _ = stub.GetState("seeded")
+	require.Equal(t, []byte("value"), value, "should not read own deletes")
+
+	event := stub.EndTransaction(false)
+	require.Equal(t, "second", event.EventName, "should return last event set")
+	require.Empty(t, stub.Events(), "should not record event of uncommitted transaction")
+	value, _ = stub.GetState("key")
+	require.Nil(t, value, "should discard writes when not committed")
+
+	stub.StartTransaction("mytx", nil)
+	require.NoError(t, stub.PutState("key", []byte("written")))
+	require.NoError(t, stub.DelState("seeded"))
+	require.NoError(t, stub.SetEvent("committed", nil))
+	stub.EndTransaction(true)
+
+	value, _ = stub.GetState("key")
+	require.Equal(t, []byte("written"), value, "should write when committed")
+	value, _ = stub.GetState("seeded")
+	require.Nil(t, value, "should delete when committed")
+	require.Len(t, stub.Events(), 1, "should record event of committed transaction")
+	require.Equal(t, "mytx", stub.Events()[0].TxId, "should record transaction of event")
+
+	require.EqualError(t, stub.PutState("", nil), "key must not be an empty string")
+	require.EqualError(t, stub.SetEvent("", nil), "event name can not be empty string")
+}
+
+func TestStubRanges(t *testing.T) {
+	stub := NewStub()
+
+	for _, key := range []string{"c", "a", "b", "d"} {
+		require.NoError(t, stub.PutState(key, []byte(key)))
+	}
+
+	compositeKey, _ := stub.CreateCompositeKey("car", []string{"red", "1"})
+	require.NoError(t, stub.PutState(compositeKey, []byte("red car")))
+	compositeKey, _ = stub.CreateCompositeKey("car", []string{"blue", "2"})
+	require.NoError(t, stub.PutState(compositeKey, []byte("blue car")))
+
+	iterator, err := stub.GetStateByRange("b", "d")
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "c"}, keys(t, iterator), "should return simple keys in range")
+
+	iterator, _ = stub.GetStateByRange("", "")
+	require.Equal(t, []string{"a", "b", "c", "d"}, keys(t, iterator), "should return all simple keys")
+
+	iterator, metadata, err := stub.GetStateByRangeWithPagination("", "", 3, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, keys(t, iterator), "should return first page")
+	require.Equal(t, int32(3), metadata.FetchedRecordsCount, "should return count of page")
+	require.Equal(t, "d", metadata.Bookmark, "should return bookmark of next page")
+
+	iterator, metadata, _ = stub.GetStateByRangeWithPagination("", "", 3, metadata.Bookmark)
+	require.Equal(t, []string{"d"}, keys(t, iterator), "should return next page")
+	require.Equal(t, "", metadata.Bookmark, "should return blank bookmark on last page")
+
+	iterator, err = stub.GetStateByPartialCompositeKey("car", []string{"red"})
+	require.NoError(t, err)
+	found := keys(t, iterator)
+	require.Len(t, found, 1, "should return composite keys matching partial key")
+	objectType, attributes, err := stub.SplitCompositeKey(found[0])
+	require.NoError(t, err)
+	require.Equal(t, "car", objectType, "should split object type")
+	require.Equal(t, []string{"red", "1"}, attributes, "should split attributes")
+
+	iterator, _ = stub.GetStateByPartialCompositeKey("car", []string{})
+	require.Len(t, keys(t, iterator), 2, "should return all composite keys of type")
+
+	_, _, err = stub.SplitCompositeKey("a")
+	require.EqualError(t, err, `"a" is not a composite key`)
+
+	_, err = stub.GetQueryResult("{}")
+	require.ErrorIs(t, err, ErrNotSupported, "should not support rich queries")
+
+	iterator, _ = stub.GetStateByRange("", "")
+	require.NoError(t, iterator.Close())
+	_, err = iterator.Next()
+	require.Error(t, err, "should error when closed")
+}
+
+func TestStubHistory(t *testing.T) {
+	stub := NewStub()
+
+	stub.StartTransaction("tx1", nil)
+	require.NoError(t, stub.PutState("key", []byte("one")))
+	stub.EndTransaction(true)
+
+	stub.StartTransaction("tx2", nil)
+	require.NoError(t, stub.PutState("key", []byte("two")))
+	stub.EndTransaction(false)
+
+	stub.StartTransaction("tx3", nil)
+	require.NoError(t, stub.DelState("key"))
+	stub.EndTransaction(true)
+
+	iterator, err := stub.GetHistoryForKey("key")
+	require.NoError(t, err)
+
+	modification, err := iterator.Next()
+	require.NoError(t, err)
+	require.Equal(t, "tx1", modification.TxId, "should return oldest modification first")
+	require.Equal(t, []byte("one"), modification.Value, "should return value written")
+	require.False(t, modification.IsDelete, "should not mark write as delete")
+
+	modification, _ = iterator.Next()
+	require.Equal(t, "tx3", modification.TxId, "should not include uncommitted modifications")
+	require.True(t, modification.IsDelete, "should mark delete")
+	require.False(t, iterator.HasNext(), "should have no more modifications")
+}
+
+func TestStubPrivateData(t *testing.T) {
+	stub := NewStub()
+
+	require.NoError(t, stub.PutPrivateData("collection", "b", []byte("secret")))
+	require.NoError(t, stub.PutPrivateData("collection", "a", []byte("other")))
+
+	value, err := stub.GetPrivateData("collection", "b")
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret"), value, "should return private data")
+
+	value, _ = stub.GetState("b")
+	require.Nil(t, value, "should keep private data separate from state")
+
+	hash, err := stub.GetPrivateDataHash("collection", "b")
+	require.NoError(t, err)
+	expected := sha256.Sum256([]byte("secret"))
+	require.Equal(t, expected[:], hash, "should return hash of private data")
+
+	iterator, err := stub.GetPrivateDataByRange("collection", "", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, keys(t, iterator), "should return private data in range")
+
+	require.NoError(t, stub.PurgePrivateData("collection", "b"))
+	value, _ = stub.GetPrivateData("collection", "b")
+	require.Nil(t, value, "should purge private data")
+
+	_, err = stub.GetPrivateData("", "b")
+	require.EqualError(t, err, "collection must not be an empty string")
+
+	require.NoError(t, stub.SetTransient(map[string][]byte{"key": []byte("value")}))
+	transient, err := stub.GetTransient()
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"key": []byte("value")}, transient, "should return transient data")
+
+	stub.StartTransaction("", nil)
+	stub.EndTransaction(true)
+	transient, _ = stub.GetTransient()
+	require.Empty(t, transient, "should clear transient data at end of transaction")
+}
+
+func TestStubInvokeChaincode(t *testing.T) {
+	stub := NewStub()
+
+	response := stub.InvokeChaincode("echo", [][]byte{[]byte("fn")}, "otherchannel")
+	require.Equal(t, "chaincode echo is not registered", response.Message, "should error for unregistered chaincode")
+
+	stub.RegisterChaincode("echo", new(echoChaincode))
+	response = stub.InvokeChaincode("echo", [][]byte{[]byte("fn"), []byte("arg")}, "otherchannel")
+	require.Equal(t, "fn otherchannel arg", string(response.Payload), "should call registered chaincode")
+}
@@ -0,0 +1,101 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contractapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+)
+
+// EventBatchName is the name of the event set when a transaction emits events using
+// BatchEvent. Its payload is the JSON form of an EventBatch
+const EventBatchName = "contractapi.EventBatch"
+
+// BatchedEvent is an event emitted by a transaction as part of an EventBatch. Payload is the
+// payload of the event as formatted by the transaction serializer
+type BatchedEvent struct {
+	Name    string `json:"name"`
+	Payload []byte `json:"payload"`
+}
+
+// EventBatch holds the events emitted by a transaction using BatchEvent, in the order they were
+// emitted. Fabric allows only one event per transaction so these are combined
+type EventBatch struct {
+	Events []BatchedEvent `json:"events"`
+}
+
+// ParseEvents returns the events contained in a chaincode event. If the event is an EventBatch
+// the events it holds are returned, otherwise the event itself is returned
+func ParseEvents(name string, payload []byte) ([]BatchedEvent, error) {
+	if name != EventBatchName {
+		return []BatchedEvent{{Name: name, Payload: payload}}, nil
+	}
+
+	batch := EventBatch{}
+
+	if err := json.Unmarshal(payload, &batch); err != nil {
+		return nil, fmt.Errorf("failed to parse event batch. %s", err.Error())
+	}
+
+	return batch.Events, nil
+}
+
+// validateEvents checks that the events declared by a contract have names and payloads of
+// types that can be described in the metadata
+func validateEvents(events map[string]interface{}, ns string) error {
+	for name := range events {
+		if name == "" || name == EventBatchName {
+			return fmt.Errorf("contract %s declares an event with invalid name %q", ns, name)
+		}
+	}
+
+	_, err := reflectEventsMetadata(events, ns, new(metadata.ComponentMetadata), metadata.SchemaOptions{})
+
+	return err
+}
+
+// eventPayloadType returns the type of the payload of an event, treating pointers as the type
+// they point to as both are formatted and described the same way
+func eventPayloadType(payload interface{}) reflect.Type {
+	typ := reflect.TypeOf(payload)
+
+	if typ != nil && typ.Kind() == reflect.Pointer {
+		return typ.Elem()
+	}
+
+	return typ
+}
+
+// reflectEventsMetadata returns the metadata describing the events of the contract, sorted by
+// name. The type of the payload of each event is used to generate a schema for it, using the
+// options of the serializer events are formatted by. An error is returned if no schema can be
+// generated for the type
+func reflectEventsMetadata(events map[string]interface{}, ns string, components *metadata.ComponentMetadata, options metadata.SchemaOptions) ([]metadata.EventMetadata, error) {
+	eventsMetadata := []metadata.EventMetadata{}
+
+	for name, payload := range events {
+		eventMetadata := metadata.EventMetadata{Name: name}
+
+		if payload != nil {
+			var err error
+			eventMetadata.Schema, err = metadata.GetSchemaWithOptions(reflect.TypeOf(payload), components, options)
+
+			if err != nil {
+				return nil, fmt.Errorf("event %s of contract %s has a payload of invalid type. %s", name, ns, err.Error())
+			}
+		}
+
+		eventsMetadata = append(eventsMetadata, eventMetadata)
+	}
+
+	sort.Slice(eventsMetadata, func(i, j int) bool {
+		return eventsMetadata[i].Name < eventsMetadata[j].Name
+	})
+
+	return eventsMetadata, nil
+}
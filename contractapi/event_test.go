@@ -0,0 +1,149 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contractapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// HELPERS
+// ================================
+
+type assetCreated struct {
+	ID    string `json:"id"`
+	Owner string `json:"owner"`
+}
+
+type eventsContract struct {
+	Contract
+	events map[string]interface{}
+}
+
+func (ec *eventsContract) Create(ctx TransactionContextInterface, id string, owner string) error {
+	ectx := ctx.(EventTransactionContextInterface)
+
+	if owner == "" {
+		return ectx.EmitEvent("AssetCreated", assetCreated{ID: id, Owner: owner})
+	}
+
+	if err := ectx.BatchEvent("AssetCreated", assetCreated{ID: id, Owner: owner}); err != nil {
+		return err
+	}
+
+	return ectx.BatchEvent("OwnerSet", owner)
+}
+
+func (ec *eventsContract) GetEvents() map[string]interface{} {
+	return ec.events
+}
+
+// ================================
+// TESTS
+// ================================
+
+func TestParseEvents(t *testing.T) {
+	events, err := ParseEvents("Created", []byte("payload"))
+	require.NoError(t, err)
+	require.Equal(t, []BatchedEvent{{Name: "Created", Payload: []byte("payload")}}, events, "should return single event")
+
+	batch, _ := json.Marshal(EventBatch{Events: []BatchedEvent{{Name: "Created", Payload: []byte("payload")}, {Name: "Deleted"}}})
+	events, err = ParseEvents(EventBatchName, batch)
+	require.NoError(t, err)
+	require.Equal(t, []BatchedEvent{{Name: "Created", Payload: []byte("payload")}, {Name: "Deleted"}}, events, "should return events of batch")
+
+	_, err = ParseEvents(EventBatchName, []byte("bad"))
+	require.ErrorContains(t, err, "failed to parse event batch.", "should error when batch invalid")
+}
+
+func TestValidateEvents(t *testing.T) {
+	require.NoError(t, validateEvents(map[string]interface{}{"Created": assetCreated{}, "Deleted": nil}, "myContract"))
+
+	require.EqualError(t, validateEvents(map[string]interface{}{"": nil}, "myContract"), `contract myContract declares an event with invalid name ""`)
+	require.EqualError(t, validateEvents(map[string]interface{}{EventBatchName: nil}, "myContract"), `contract myContract declares an event with invalid name "contractapi.EventBatch"`)
+	require.ErrorContains(t, validateEvents(map[string]interface{}{"Created": make(chan int)}, "myContract"), "event Created of contract myContract has a payload of invalid type.")
+}
+
+func TestReflectEventsMetadata(t *testing.T) {
+	components := new(metadata.ComponentMetadata)
+
+	eventsMetadata, err := reflectEventsMetadata(map[string]interface{}{"OwnerSet": "", "Created": assetCreated{}, "Deleted": nil}, "myContract", components, metadata.SchemaOptions{})
+	require.NoError(t, err)
+
+	require.Equal(t, []metadata.EventMetadata{
+		{Name: "Created", Schema: spec.RefSchema("#/components/schemas/assetCreated")},
+		{Name: "Deleted"},
+		{Name: "OwnerSet", Schema: spec.StringProperty()},
+	}, eventsMetadata, "should describe events sorted by name")
+	require.Contains(t, components.Schemas, "assetCreated", "should add schema of payload to components")
+
+	_, err = reflectEventsMetadata(map[string]interface{}{"Created": make(chan int)}, "myContract", components, metadata.SchemaOptions{})
+	require.ErrorContains(t, err, "event Created of contract myContract has a payload of invalid type. ", "should error when payload cannot be described")
+
+	eventsMetadata, err = reflectEventsMetadata(map[string]interface{}{"Counted": int64(0)}, "myContract", components, metadata.SchemaOptions{Int64AsString: true})
+	require.NoError(t, err)
+	require.Equal(t, []string{"string"}, []string(eventsMetadata[0].Schema.Type), "should describe payload using schema options")
+}
+
+func TestEvents(t *testing.T) {
+	ec := new(eventsContract)
+	ec.events = map[string]interface{}{"AssetCreated": assetCreated{}, "OwnerSet": ""}
+
+	cc, err := NewChaincode(ec)
+	require.NoError(t, err)
+
+	_, ok := cc.contracts["eventsContract"].functions["GetEvents"]
+	require.False(t, ok, "should not include GetEvents as a transaction")
+	require.Equal(t, []metadata.EventMetadata{
+		{Name: "AssetCreated", Schema: spec.RefSchema("#/components/schemas/assetCreated")},
+		{Name: "OwnerSet", Schema: spec.StringProperty()},
+	}, cc.metadata.Contracts["eventsContract"].Events, "should include events in metadata")
+	require.NoError(t, metadata.ValidateAgainstSchema(cc.metadata), "should produce valid metadata")
+
+	mockStub := newStub(t, "eventsContract:Create", "asset1", "")
+	mockStub.EXPECT().SetEvent("AssetCreated", []byte(`{"id":"asset1","owner":""}`)).Return(nil).Once()
+	require.Equal(t, int32(200), cc.Invoke(mockStub).Status, "should emit single event")
+
+	batch, _ := json.Marshal(EventBatch{Events: []BatchedEvent{
+		{Name: "AssetCreated", Payload: []byte(`{"id":"asset1","owner":"alice"}`)},
+		{Name: "OwnerSet", Payload: []byte("alice")},
+	}})
+	first, _ := json.Marshal(EventBatch{Events: []BatchedEvent{{Name: "AssetCreated", Payload: []byte(`{"id":"asset1","owner":"alice"}`)}}})
+	mockStub = newStub(t, "eventsContract:Create", "asset1", "alice")
+	mockStub.EXPECT().SetEvent(EventBatchName, first).Return(nil).Once()
+	mockStub.EXPECT().SetEvent(EventBatchName, batch).Return(nil).Once()
+	require.Equal(t, int32(200), cc.Invoke(mockStub).Status, "should emit batch of events")
+
+	mockStub = newStub(t, "eventsContract:Create", "asset1", "alice")
+	mockStub.EXPECT().SetEvent(EventBatchName, first).Return(nil).Once()
+	ec.events = map[string]interface{}{"AssetCreated": assetCreated{}}
+	cc, err = NewChaincode(ec)
+	require.NoError(t, err)
+	require.Equal(t, "event OwnerSet is not declared by the contract", string(cc.Invoke(mockStub).Message), "should error for undeclared event")
+
+	mockStub = newStub(t, "eventsContract:Create", "asset1", "alice")
+	mockStub.EXPECT().SetEvent(EventBatchName, first).Return(nil).Once()
+	ec.events = map[string]interface{}{"AssetCreated": assetCreated{}, "OwnerSet": 0}
+	cc, err = NewChaincode(ec)
+	require.NoError(t, err)
+	require.Equal(t, "payload of event OwnerSet is not of the type declared by the contract", string(cc.Invoke(mockStub).Message), "should error for event with payload of undeclared type")
+
+	ec.events = map[string]interface{}{"": nil}
+	_, err = NewChaincode(ec)
+	require.EqualError(t, err, `contract eventsContract declares an event with invalid name ""`, "should error for invalid events")
+
+	ec.events = map[string]interface{}{"Created": make(chan int)}
+	_, err = NewChaincode(ec)
+	require.ErrorContains(t, err, "event Created of contract eventsContract has a payload of invalid type. ", "should error for event payload that cannot be described")
+
+	ec.events = nil
+	cc, err = NewChaincode(ec)
+	require.NoError(t, err)
+	require.Nil(t, cc.metadata.Contracts["eventsContract"].Events, "should not set events when none declared")
+}
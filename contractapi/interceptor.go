@@ -0,0 +1,125 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contractapi
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hyperledger/fabric-contract-api-go/v2/internal"
+)
+
+// Invoker calls the next interceptor in a chain or, at the end of the chain, the contract
+// function. It returns the value and error returned by the function
+type Invoker func(ctx TransactionContextInterface, info *TransactionInfo) (interface{}, error)
+
+// Interceptor is called around the call of contract functions. It is passed the transaction
+// context, the info of the transaction with its decoded args, and the next invoker in the
+// chain. An interceptor may return without calling next to stop the function being called,
+// change info.Args before calling next to change the args the function is called with, and
+// change the value or error returned by next. The value returned must be of the function's
+// return type. The context passed to next must be the context received, or nil to use it
+type Interceptor func(ctx TransactionContextInterface, info *TransactionInfo, next Invoker) (interface{}, error)
+
+// InterceptedContractInterface extends ContractInterface and provides additional functionality
+// that can be used to wrap the calls of functions of the contract
+type InterceptedContractInterface interface {
+	// GetInterceptors returns the interceptors to call around each function of the contract,
+	// including unknown functions. Interceptors are called in order, after those of the
+	// chaincode and before the before and after transactions of the contract
+	GetInterceptors() []Interceptor
+}
+
+// functionNotFoundError is returned at the end of an interceptor chain when an unknown
+// function is called for a contract without an unknown transaction
+type functionNotFoundError struct {
+	fn string
+	ns string
+}
+
+func (e *functionNotFoundError) Error() string {
+	return fmt.Sprintf("Function %s not found in contract %s", e.fn, e.ns)
+}
+
+func chainInterceptors(interceptors []Interceptor, final Invoker) Invoker {
+	next := final
+
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		inner := next
+
+		next = func(ctx TransactionContextInterface, info *TransactionInfo) (interface{}, error) {
+			return interceptor(ctx, info, inner)
+		}
+	}
+
+	return next
+}
+
+func contextValue(ctx TransactionContextInterface, original reflect.Value) (reflect.Value, error) {
+	if ctx == nil {
+		return original, nil
+	}
+
+	value := reflect.ValueOf(ctx)
+
+	if value.Type() != original.Type() {
+		return reflect.Value{}, fmt.Errorf("interceptor passed transaction context of type %s. Expected %s", value.Type().String(), original.Type().String())
+	}
+
+	return value, nil
+}
+
+// beforeInterceptor adapts a before transaction to an interceptor calling it before next
+func beforeInterceptor(th *internal.TransactionHandler, original reflect.Value) Interceptor {
+	return func(ctx TransactionContextInterface, info *TransactionInfo, next Invoker) (interface{}, error) {
+		ctxValue, err := contextValue(ctx, original)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, _, err := th.CallWithInfo(ctxValue, nil, info, nil); err != nil {
+			return nil, err
+		}
+
+		return next(ctx, info)
+	}
+}
+
+// afterInterceptor adapts an after transaction to an interceptor calling it after next. When
+// next returns an error the after transaction is only called if it takes the transaction info
+func afterInterceptor(th *internal.TransactionHandler, original reflect.Value) Interceptor {
+	return func(ctx TransactionContextInterface, info *TransactionInfo, next Invoker) (interface{}, error) {
+		result, err := next(ctx, info)
+
+		if err != nil && !th.TakesInfo() {
+			return nil, err
+		}
+
+		ctxValue, ctxErr := contextValue(ctx, original)
+
+		if ctxErr != nil {
+			return nil, ctxErr
+		}
+
+		if err != nil {
+			info.Err = err
+
+			if _, _, afterErr := th.CallWithInfo(ctxValue, nil, info, nil); afterErr != nil {
+				return nil, afterErr
+			}
+
+			return nil, err
+		}
+
+		info.Result = result
+
+		if _, _, afterErr := th.CallWithInfo(ctxValue, result, info, nil); afterErr != nil {
+			return nil, afterErr
+		}
+
+		return result, nil
+	}
+}
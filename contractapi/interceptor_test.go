@@ -0,0 +1,199 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contractapi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// HELPERS
+// ================================
+
+type interceptedContract struct {
+	Contract
+	called []string
+}
+
+func (ic *interceptedContract) Echo(value string) string {
+	ic.called = append(ic.called, "Echo called with "+value)
+	return value
+}
+
+func (ic *interceptedContract) Fails() error {
+	return errors.New("function error")
+}
+
+func (ic *interceptedContract) logInterceptor(name string) Interceptor {
+	return func(ctx TransactionContextInterface, info *TransactionInfo, next Invoker) (interface{}, error) {
+		ic.called = append(ic.called, fmt.Sprintf("%s before %s", name, info.FunctionName))
+		result, err := next(ctx, info)
+		ic.called = append(ic.called, fmt.Sprintf("%s after %v %v", name, result, err))
+		return result, err
+	}
+}
+
+func newStub(t *testing.T, fn string, args ...string) *MockChaincodeStub {
+	t.Helper()
+
+	mockStub := NewMockChaincodeStub(t)
+	mockStub.EXPECT().GetFunctionAndParameters().Return(fn, args)
+	mockStub.EXPECT().GetArgs().Maybe().Return(toByteArgs(append([]string{fn}, args...)...))
+	mockStub.EXPECT().GetCreator().Maybe().Return([]byte{}, nil)
+
+	return mockStub
+}
+
+// ================================
+// TESTS
+// ================================
+
+func TestChainInterceptors(t *testing.T) {
+	called := []string{}
+
+	interceptor := func(name string) Interceptor {
+		return func(ctx TransactionContextInterface, info *TransactionInfo, next Invoker) (interface{}, error) {
+			called = append(called, name)
+			return next(ctx, info)
+		}
+	}
+
+	final := func(ctx TransactionContextInterface, info *TransactionInfo) (interface{}, error) {
+		called = append(called, "final")
+		return "result", nil
+	}
+
+	result, err := chainInterceptors(nil, final)(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "result", result, "should call final when no interceptors")
+
+	called = []string{}
+	result, err = chainInterceptors([]Interceptor{interceptor("one"), interceptor("two")}, final)(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "result", result, "should return result of final")
+	require.Equal(t, []string{"one", "two", "final"}, called, "should call interceptors in order")
+}
+
+func TestContextValue(t *testing.T) {
+	original := reflect.ValueOf(new(TransactionContext))
+
+	value, err := contextValue(nil, original)
+	require.NoError(t, err)
+	require.Equal(t, original, value, "should use original context when nil passed")
+
+	ctx := new(TransactionContext)
+	value, err = contextValue(ctx, original)
+	require.NoError(t, err)
+	require.Same(t, ctx, value.Interface(), "should use passed context")
+
+	_, err = contextValue(new(customContext), original)
+	require.EqualError(t, err, "interceptor passed transaction context of type *contractapi.customContext. Expected *contractapi.TransactionContext", "should error when context of wrong type passed")
+}
+
+func TestInterceptors(t *testing.T) {
+	ic := new(interceptedContract)
+	ic.Interceptors = []Interceptor{ic.logInterceptor("contract")}
+	ic.BeforeTransaction = func() {
+		ic.called = append(ic.called, "before")
+	}
+	ic.AfterTransaction = func(data interface{}) {
+		ic.called = append(ic.called, fmt.Sprintf("after %v", data))
+	}
+
+	cc, err := NewChaincode(ic)
+	require.NoError(t, err)
+	cc.Interceptors = []Interceptor{ic.logInterceptor("chaincode")}
+
+	_, ok := cc.contracts["interceptedContract"].functions["GetInterceptors"]
+	require.False(t, ok, "should not include GetInterceptors as a transaction")
+
+	callContractFunctionAndCheckSuccess(t, cc, []string{"interceptedContract:Echo", "hello"}, invokeType, "hello")
+	require.Equal(t, []string{
+		"chaincode before Echo",
+		"contract before Echo",
+		"before",
+		"Echo called with hello",
+		"after hello",
+		"contract after hello <nil>",
+		"chaincode after hello <nil>",
+	}, ic.called, "should call chaincode then contract interceptors around before, function and after")
+	ic.called = nil
+
+	callContractFunctionAndCheckError(t, cc, []string{"interceptedContract:Fails"}, invokeType, "function error")
+	require.Equal(t, []string{
+		"chaincode before Fails",
+		"contract before Fails",
+		"before",
+		"contract after <nil> function error",
+		"chaincode after <nil> function error",
+	}, ic.called, "should pass function error to interceptors")
+	ic.called = nil
+
+	callContractFunctionAndCheckError(t, cc, []string{"interceptedContract:Missing"}, invokeType, "Function Missing not found in contract interceptedContract")
+	require.Equal(t, []string{
+		"chaincode before Missing",
+		"contract before Missing",
+		"before",
+		"contract after <nil> Function Missing not found in contract interceptedContract",
+		"chaincode after <nil> Function Missing not found in contract interceptedContract",
+	}, ic.called, "should call interceptors for unknown functions")
+}
+
+func TestInterceptorsChangeCall(t *testing.T) {
+	ic := new(interceptedContract)
+	cc, err := NewChaincode(ic)
+	require.NoError(t, err)
+
+	cc.Interceptors = []Interceptor{func(ctx TransactionContextInterface, info *TransactionInfo, next Invoker) (interface{}, error) {
+		return nil, NewChaincodeError(429, "RATE_LIMITED", "too many requests")
+	}}
+	response := cc.Invoke(newStub(t, "interceptedContract:Echo", "hello"))
+	require.Equal(t, int32(429), response.Status, "should return error of interceptor")
+	require.Empty(t, ic.called, "should not call function when interceptor short circuits")
+
+	cc.Interceptors = []Interceptor{func(ctx TransactionContextInterface, info *TransactionInfo, next Invoker) (interface{}, error) {
+		info.Args[0] = strings.TrimSpace(info.Args[0].(string))
+		result, err := next(ctx, info)
+		return strings.ToUpper(result.(string)), err
+	}}
+	callContractFunctionAndCheckSuccess(t, cc, []string{"interceptedContract:Echo", "  hello  "}, invokeType, "HELLO")
+	require.Equal(t, []string{"Echo called with hello"}, ic.called, "should call function with changed args")
+
+	cc.Interceptors = []Interceptor{func(ctx TransactionContextInterface, info *TransactionInfo, next Invoker) (interface{}, error) {
+		_, err := next(ctx, info)
+		return nil, fmt.Errorf("wrapped. %w", err)
+	}}
+	callContractFunctionAndCheckError(t, cc, []string{"interceptedContract:Fails"}, invokeType, "wrapped. function error")
+
+	cc.Interceptors = []Interceptor{func(ctx TransactionContextInterface, info *TransactionInfo, next Invoker) (interface{}, error) {
+		return 1, nil
+	}}
+	callContractFunctionAndCheckError(t, cc, []string{"interceptedContract:Echo", "hello"}, invokeType, "error handling success response. Value of type int cannot be used for return type string")
+
+	cc.Interceptors = []Interceptor{func(ctx TransactionContextInterface, info *TransactionInfo, next Invoker) (interface{}, error) {
+		return next(new(customContext), info)
+	}}
+	callContractFunctionAndCheckError(t, cc, []string{"interceptedContract:Echo", "hello"}, invokeType, "interceptor passed transaction context of type *contractapi.customContext. Expected *contractapi.TransactionContext")
+
+	ic.BeforeTransaction = func() {}
+	cc, _ = NewChaincode(ic)
+	cc.Interceptors = []Interceptor{func(ctx TransactionContextInterface, info *TransactionInfo, next Invoker) (interface{}, error) {
+		return next(new(customContext), info)
+	}}
+	callContractFunctionAndCheckError(t, cc, []string{"interceptedContract:Echo", "hello"}, invokeType, "interceptor passed transaction context of type *contractapi.customContext. Expected *contractapi.TransactionContext")
+
+	ic.BeforeTransaction = nil
+	ic.AfterTransaction = func(info *TransactionInfo) {}
+	cc, _ = NewChaincode(ic)
+	cc.Interceptors = []Interceptor{func(ctx TransactionContextInterface, info *TransactionInfo, next Invoker) (interface{}, error) {
+		return next(new(customContext), info)
+	}}
+	callContractFunctionAndCheckError(t, cc, []string{"interceptedContract:Echo", "hello"}, invokeType, "interceptor passed transaction context of type *contractapi.customContext. Expected *contractapi.TransactionContext")
+}
@@ -0,0 +1,35 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import "fmt"
+
+// NotFoundError is returned by a repository when no value is stored under a key
+type NotFoundError struct {
+	Key        string
+	Collection string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("no value exists for key %s%s", e.Key, inCollection(e.Collection))
+}
+
+// AlreadyExistsError is returned by a repository when creating a value under a key
+// that already has a value stored
+type AlreadyExistsError struct {
+	Key        string
+	Collection string
+}
+
+func (e *AlreadyExistsError) Error() string {
+	return fmt.Sprintf("a value already exists for key %s%s", e.Key, inCollection(e.Collection))
+}
+
+func inCollection(collection string) string {
+	if collection == "" {
+		return ""
+	}
+
+	return " in collection " + collection
+}
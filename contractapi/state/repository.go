@@ -0,0 +1,267 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package state provides typed access to values stored in the world state or in private
+// data collections from contract functions.
+package state
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hyperledger/fabric-chaincode-go/v2/shim"
+	"github.com/hyperledger/fabric-contract-api-go/v2/contractapi"
+	"github.com/hyperledger/fabric-contract-api-go/v2/serializer"
+)
+
+// Repository stores values of type T under keys extracted from the values themselves.
+// Values are converted to and from their stored form using the transaction serializer of
+// the chaincode, so that the stored format matches the format used for transaction
+// arguments and return values. If the transaction context passed to a function of the
+// repository does not meet contractapi.SerializerTransactionContextInterface then the
+// JSONSerializer is used. A repository created with a collection name reads and writes
+// that private data collection rather than the world state. If the transaction context
+// meets contractapi.CollectionTransactionContextInterface the collection is obtained from
+// it, so the repository may only use the collection in the ways the function called declares.
+type Repository[T any] struct {
+	key        func(T) string
+	collection string
+}
+
+// NewRepository creates a repository of values of type T stored in the world state. The
+// key function returns the key a value is stored under.
+func NewRepository[T any](key func(T) string) *Repository[T] {
+	return &Repository[T]{key: key}
+}
+
+// NewPrivateRepository creates a repository of values of type T stored in the named
+// private data collection. The key function returns the key a value is stored under.
+func NewPrivateRepository[T any](collection string, key func(T) string) *Repository[T] {
+	return &Repository[T]{key: key, collection: collection}
+}
+
+// Collection returns the name of the private data collection used by the repository.
+// This is blank for repositories of the world state
+func (r *Repository[T]) Collection() string {
+	return r.collection
+}
+
+// Key returns the key that the value is stored under
+func (r *Repository[T]) Key(value T) string {
+	return r.key(value)
+}
+
+// Get returns the value stored under the key. If no value is stored under the key
+// a NotFoundError is returned
+func (r *Repository[T]) Get(ctx contractapi.TransactionContextInterface, key string) (T, error) {
+	var zero T
+
+	data, err := r.getState(ctx, key)
+	if err != nil {
+		return zero, err
+	}
+
+	if data == nil {
+		return zero, &NotFoundError{Key: key, Collection: r.collection}
+	}
+
+	return r.decode(ctx, key, data)
+}
+
+// Exists returns whether a value is stored under the key
+func (r *Repository[T]) Exists(ctx contractapi.TransactionContextInterface, key string) (bool, error) {
+	data, err := r.getState(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	return data != nil, nil
+}
+
+// Put stores the value under its key, replacing any value already stored there
+func (r *Repository[T]) Put(ctx contractapi.TransactionContextInterface, value T) error {
+	key := r.key(value)
+
+	data, err := r.encode(ctx, key, value)
+	if err != nil {
+		return err
+	}
+
+	return r.putState(ctx, key, data)
+}
+
+// Create stores the value under its key. If a value is already stored under the key an
+// AlreadyExistsError is returned
+func (r *Repository[T]) Create(ctx contractapi.TransactionContextInterface, value T) error {
+	key := r.key(value)
+
+	exists, err := r.Exists(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return &AlreadyExistsError{Key: key, Collection: r.collection}
+	}
+
+	return r.Put(ctx, value)
+}
+
+// Update replaces the value stored under its key. If no value is stored under the key
+// a NotFoundError is returned
+func (r *Repository[T]) Update(ctx contractapi.TransactionContextInterface, value T) error {
+	key := r.key(value)
+
+	exists, err := r.Exists(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return &NotFoundError{Key: key, Collection: r.collection}
+	}
+
+	return r.Put(ctx, value)
+}
+
+// Delete removes the value stored under the key. If no value is stored under the key
+// a NotFoundError is returned
+func (r *Repository[T]) Delete(ctx contractapi.TransactionContextInterface, key string) error {
+	exists, err := r.Exists(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return &NotFoundError{Key: key, Collection: r.collection}
+	}
+
+	if r.collection != "" {
+		collection, err := r.privateCollection(ctx)
+		if err != nil {
+			return err
+		}
+
+		return collection.DelData(key)
+	}
+
+	return ctx.GetStub().DelState(key)
+}
+
+// GetRange returns the values stored under keys in the range startKey (inclusive) to
+// endKey (exclusive) in key order. Blank start and end keys leave the range unbounded
+func (r *Repository[T]) GetRange(ctx contractapi.TransactionContextInterface, startKey string, endKey string) ([]T, error) {
+	var iterator shim.StateQueryIteratorInterface
+	var err error
+
+	if r.collection != "" {
+		var collection *contractapi.Collection
+
+		if collection, err = r.privateCollection(ctx); err == nil {
+			iterator, err = collection.GetDataByRange(startKey, endKey)
+		}
+	} else {
+		iterator, err = ctx.GetStub().GetStateByRange(startKey, endKey)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer iterator.Close()
+
+	values := []T{}
+
+	for iterator.HasNext() {
+		kv, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := r.decode(ctx, kv.Key, kv.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func (r *Repository[T]) getState(ctx contractapi.TransactionContextInterface, key string) ([]byte, error) {
+	if r.collection != "" {
+		collection, err := r.privateCollection(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return collection.GetData(key)
+	}
+
+	return ctx.GetStub().GetState(key)
+}
+
+func (r *Repository[T]) putState(ctx contractapi.TransactionContextInterface, key string, data []byte) error {
+	if r.collection != "" {
+		collection, err := r.privateCollection(ctx)
+		if err != nil {
+			return err
+		}
+
+		return collection.PutData(key, data)
+	}
+
+	return ctx.GetStub().PutState(key, data)
+}
+
+func (r *Repository[T]) privateCollection(ctx contractapi.TransactionContextInterface) (*contractapi.Collection, error) {
+	if cctx, ok := ctx.(contractapi.CollectionTransactionContextInterface); ok {
+		return cctx.GetCollection(r.collection)
+	}
+
+	return contractapi.NewCollection(ctx.GetStub(), r.collection), nil
+}
+
+func (r *Repository[T]) encode(ctx contractapi.TransactionContextInterface, key string, value T) ([]byte, error) {
+	str, err := transactionSerializer(ctx).ToString(reflect.ValueOf(&value).Elem(), valueType[T](), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding value for key %s. %s", key, err.Error())
+	}
+
+	return []byte(str), nil
+}
+
+func (r *Repository[T]) decode(ctx contractapi.TransactionContextInterface, key string, data []byte) (T, error) {
+	var zero T
+
+	typ := valueType[T]()
+
+	converted, err := transactionSerializer(ctx).FromString(string(data), typ, nil, nil)
+	if err != nil {
+		return zero, fmt.Errorf("error decoding value for key %s. %s", key, err.Error())
+	}
+
+	if converted.Type() != typ && converted.Type().ConvertibleTo(typ) {
+		converted = converted.Convert(typ)
+	}
+
+	value, ok := converted.Interface().(T)
+	if !ok {
+		return zero, fmt.Errorf("error decoding value for key %s. Serializer returned %s, expected %s", key, converted.Type().String(), typ.String())
+	}
+
+	return value, nil
+}
+
+func valueType[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func transactionSerializer(ctx contractapi.TransactionContextInterface) serializer.TransactionSerializer {
+	if sctx, ok := ctx.(contractapi.SerializerTransactionContextInterface); ok {
+		return sctx.GetTransactionSerializer()
+	}
+
+	return new(serializer.JSONSerializer)
+}
@@ -0,0 +1,344 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/v2/pkg/cid"
+	"github.com/hyperledger/fabric-chaincode-go/v2/shim"
+	"github.com/hyperledger/fabric-contract-api-go/v2/contractapi"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/queryresult"
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// HELPERS
+// ================================
+
+type asset struct {
+	ID    string `json:"id"`
+	Value int    `json:"value"`
+}
+
+func assetKey(a *asset) string {
+	return a.ID
+}
+
+type status string
+
+type fakeStub struct {
+	shim.ChaincodeStubInterface
+	state   map[string][]byte
+	private map[string]map[string][]byte
+	err     error
+	iterErr error
+}
+
+func newFakeStub() *fakeStub {
+	return &fakeStub{
+		state:   make(map[string][]byte),
+		private: make(map[string]map[string][]byte),
+	}
+}
+
+func (fs *fakeStub) GetState(key string) ([]byte, error) {
+	return fs.state[key], fs.err
+}
+
+func (fs *fakeStub) PutState(key string, value []byte) error {
+	fs.state[key] = value
+	return nil
+}
+
+func (fs *fakeStub) DelState(key string) error {
+	delete(fs.state, key)
+	return nil
+}
+
+func (fs *fakeStub) GetStateByRange(startKey string, endKey string) (shim.StateQueryIteratorInterface, error) {
+	return fs.rangeOf(fs.state, startKey, endKey)
+}
+
+func (fs *fakeStub) GetPrivateData(collection string, key string) ([]byte, error) {
+	return fs.private[collection][key], fs.err
+}
+
+func (fs *fakeStub) PutPrivateData(collection string, key string, value []byte) error {
+	if _, ok := fs.private[collection]; !ok {
+		fs.private[collection] = make(map[string][]byte)
+	}
+
+	fs.private[collection][key] = value
+	return nil
+}
+
+func (fs *fakeStub) DelPrivateData(collection string, key string) error {
+	delete(fs.private[collection], key)
+	return nil
+}
+
+func (fs *fakeStub) GetPrivateDataByRange(collection string, startKey string, endKey string) (shim.StateQueryIteratorInterface, error) {
+	return fs.rangeOf(fs.private[collection], startKey, endKey)
+}
+
+func (fs *fakeStub) rangeOf(values map[string][]byte, startKey string, endKey string) (shim.StateQueryIteratorInterface, error) {
+	if fs.err != nil {
+		return nil, fs.err
+	}
+
+	iterator := &fakeIterator{err: fs.iterErr}
+
+	for key, value := range values {
+		if key >= startKey && (endKey == "" || key < endKey) {
+			iterator.kvs = append(iterator.kvs, &queryresult.KV{Key: key, Value: value})
+		}
+	}
+
+	sort.Slice(iterator.kvs, func(i, j int) bool {
+		return iterator.kvs[i].Key < iterator.kvs[j].Key
+	})
+
+	return iterator, nil
+}
+
+type fakeIterator struct {
+	kvs []*queryresult.KV
+	err error
+}
+
+func (fi *fakeIterator) HasNext() bool {
+	return len(fi.kvs) > 0
+}
+
+func (fi *fakeIterator) Next() (*queryresult.KV, error) {
+	if fi.err != nil {
+		return nil, fi.err
+	}
+
+	kv := fi.kvs[0]
+	fi.kvs = fi.kvs[1:]
+
+	return kv, nil
+}
+
+func (fi *fakeIterator) Close() error {
+	return nil
+}
+
+type plainContext struct {
+	stub shim.ChaincodeStubInterface
+}
+
+func (pc *plainContext) GetStub() shim.ChaincodeStubInterface {
+	return pc.stub
+}
+
+func (pc *plainContext) GetClientIdentity() cid.ClientIdentity {
+	return nil
+}
+
+type badSerializer struct{}
+
+func (bs *badSerializer) FromString(string, reflect.Type, *metadata.ParameterMetadata, *metadata.ComponentMetadata) (reflect.Value, error) {
+	return reflect.ValueOf(1), nil
+}
+
+func (bs *badSerializer) ToString(reflect.Value, reflect.Type, *metadata.ReturnMetadata, *metadata.ComponentMetadata) (string, error) {
+	return "", errors.New("serializer error")
+}
+
+func newContext(stub shim.ChaincodeStubInterface) *contractapi.TransactionContext {
+	ctx := new(contractapi.TransactionContext)
+	ctx.SetStub(stub)
+
+	return ctx
+}
+
+// ================================
+// TESTS
+// ================================
+
+func TestNewRepository(t *testing.T) {
+	repo := NewRepository(assetKey)
+	require.Empty(t, repo.Collection(), "should not use a collection")
+	require.Equal(t, "asset1", repo.Key(&asset{ID: "asset1"}), "should use key function")
+
+	repo = NewPrivateRepository("collection1", assetKey)
+	require.Equal(t, "collection1", repo.Collection(), "should use the collection")
+	require.Equal(t, "asset1", repo.Key(&asset{ID: "asset1"}), "should use key function")
+}
+
+func TestRepository(t *testing.T) {
+	var err error
+
+	for _, collection := range []string{"", "collection1"} {
+		stub := newFakeStub()
+		ctx := newContext(stub)
+
+		repo := NewPrivateRepository(collection, assetKey)
+
+		_, err = repo.Get(ctx, "asset1")
+		require.EqualError(t, err, (&NotFoundError{Key: "asset1", Collection: collection}).Error(), "should error when getting missing value")
+		var notFound *NotFoundError
+		require.ErrorAs(t, err, &notFound, "should return typed not found error")
+
+		exists, err := repo.Exists(ctx, "asset1")
+		require.NoError(t, err)
+		require.False(t, exists, "should not exist before created")
+
+		err = repo.Update(ctx, &asset{ID: "asset1", Value: 1})
+		require.ErrorAs(t, err, &notFound, "should error when updating missing value")
+
+		err = repo.Delete(ctx, "asset1")
+		require.ErrorAs(t, err, &notFound, "should error when deleting missing value")
+
+		err = repo.Create(ctx, &asset{ID: "asset1", Value: 1})
+		require.NoError(t, err, "should create value")
+
+		stored := stub.state["asset1"]
+		if collection != "" {
+			stored = stub.private[collection]["asset1"]
+		}
+		require.JSONEq(t, `{"id":"asset1","value":1}`, string(stored), "should store value using serializer")
+
+		err = repo.Create(ctx, &asset{ID: "asset1", Value: 2})
+		require.EqualError(t, err, (&AlreadyExistsError{Key: "asset1", Collection: collection}).Error(), "should error when creating existing value")
+		var alreadyExists *AlreadyExistsError
+		require.ErrorAs(t, err, &alreadyExists, "should return typed already exists error")
+
+		exists, err = repo.Exists(ctx, "asset1")
+		require.NoError(t, err)
+		require.True(t, exists, "should exist after created")
+
+		value, err := repo.Get(ctx, "asset1")
+		require.NoError(t, err)
+		require.Equal(t, &asset{ID: "asset1", Value: 1}, value, "should return stored value")
+
+		err = repo.Update(ctx, &asset{ID: "asset1", Value: 3})
+		require.NoError(t, err, "should update existing value")
+
+		err = repo.Put(ctx, &asset{ID: "asset2", Value: 4})
+		require.NoError(t, err, "should put new value")
+
+		err = repo.Put(ctx, &asset{ID: "asset3", Value: 5})
+		require.NoError(t, err, "should put new value")
+
+		values, err := repo.GetRange(ctx, "asset1", "asset3")
+		require.NoError(t, err)
+		require.Equal(t, []*asset{{ID: "asset1", Value: 3}, {ID: "asset2", Value: 4}}, values, "should return values in range in key order")
+
+		values, err = repo.GetRange(ctx, "", "")
+		require.NoError(t, err)
+		require.Len(t, values, 3, "should return all values for unbounded range")
+
+		err = repo.Delete(ctx, "asset2")
+		require.NoError(t, err, "should delete existing value")
+
+		exists, err = repo.Exists(ctx, "asset2")
+		require.NoError(t, err)
+		require.False(t, exists, "should not exist after deleted")
+	}
+}
+
+func TestRepositoryTypes(t *testing.T) {
+	stub := newFakeStub()
+
+	statusRepo := NewRepository(func(s status) string { return "status" })
+	err := statusRepo.Put(&plainContext{stub}, status("active"))
+	require.NoError(t, err)
+	require.Equal(t, "active", string(stub.state["status"]), "should use JSON serializer when context does not provide one")
+
+	value, err := statusRepo.Get(&plainContext{stub}, "status")
+	require.NoError(t, err)
+	require.Equal(t, status("active"), value, "should convert to named type")
+
+	structRepo := NewRepository(func(a asset) string { return a.ID })
+	err = structRepo.Put(&plainContext{stub}, asset{ID: "asset1", Value: 1})
+	require.NoError(t, err)
+
+	structValue, err := structRepo.Get(&plainContext{stub}, "asset1")
+	require.NoError(t, err)
+	require.Equal(t, asset{ID: "asset1", Value: 1}, structValue, "should store non pointer structs")
+}
+
+func TestRepositoryErrors(t *testing.T) {
+	var err error
+
+	stub := newFakeStub()
+	ctx := newContext(stub)
+	repo := NewRepository(assetKey)
+
+	stub.err = errors.New("stub error")
+
+	_, err = repo.Get(ctx, "asset1")
+	require.EqualError(t, err, "stub error", "should return get error")
+
+	_, err = repo.Exists(ctx, "asset1")
+	require.EqualError(t, err, "stub error", "should return exists error")
+
+	err = repo.Create(ctx, &asset{ID: "asset1"})
+	require.EqualError(t, err, "stub error", "should return create error")
+
+	err = repo.Update(ctx, &asset{ID: "asset1"})
+	require.EqualError(t, err, "stub error", "should return update error")
+
+	err = repo.Delete(ctx, "asset1")
+	require.EqualError(t, err, "stub error", "should return delete error")
+
+	_, err = repo.GetRange(ctx, "", "")
+	require.EqualError(t, err, "stub error", "should return range error")
+
+	stub.err = nil
+	stub.state["asset1"] = []byte("not json")
+
+	_, err = repo.Get(ctx, "asset1")
+	require.ErrorContains(t, err, "error decoding value for key asset1. ", "should error when value cannot be decoded")
+
+	_, err = repo.GetRange(ctx, "", "")
+	require.ErrorContains(t, err, "error decoding value for key asset1. ", "should error when range value cannot be decoded")
+
+	stub.iterErr = errors.New("iterator error")
+	_, err = repo.GetRange(ctx, "", "")
+	require.EqualError(t, err, "iterator error", "should return iterator error")
+
+	ctx.SetTransactionSerializer(new(badSerializer))
+
+	err = repo.Put(ctx, &asset{ID: "asset1"})
+	require.EqualError(t, err, "error encoding value for key asset1. serializer error", "should error when value cannot be encoded")
+
+	_, err = repo.Get(ctx, "asset1")
+	require.EqualError(t, err, "error decoding value for key asset1. Serializer returned int, expected *state.asset", "should error when serializer returns wrong type")
+}
+
+func TestRepositoryCollections(t *testing.T) {
+	var err error
+
+	stub := newFakeStub()
+	ctx := newContext(stub)
+	ctx.SetCollections([]metadata.CollectionUsage{{Name: "collection1", Read: true}})
+
+	repo := NewPrivateRepository("collection1", assetKey)
+	stub.private["collection1"] = map[string][]byte{"asset1": []byte(`{"id":"asset1","value":1}`)}
+
+	value, err := repo.Get(ctx, "asset1")
+	require.NoError(t, err)
+	require.Equal(t, &asset{ID: "asset1", Value: 1}, value, "should read collection declared to be read")
+
+	err = repo.Put(ctx, &asset{ID: "asset1", Value: 2})
+	require.EqualError(t, err, "private data collection collection1 is not declared to be written by the transaction", "should error when writing collection only declared to be read")
+
+	err = repo.Delete(ctx, "asset1")
+	require.EqualError(t, err, "private data collection collection1 is not declared to be written by the transaction", "should error when deleting from collection only declared to be read")
+
+	_, err = NewPrivateRepository("collection2", assetKey).Get(ctx, "asset1")
+	require.EqualError(t, err, "private data collection collection2 is not declared for use by the transaction", "should error when collection not declared")
+
+	err = NewRepository(assetKey).Put(ctx, &asset{ID: "asset1", Value: 2})
+	require.NoError(t, err, "should not restrict world state")
+}
@@ -3,6 +3,13 @@
 
 package contractapi
 
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+)
+
 // SystemContract contract added to all chaincode to provide access to metdata
 type SystemContract struct {
 	Contract
@@ -26,5 +33,47 @@ func (sc *SystemContract) GetMetadata() string {
 // evaluate transaction in the metadata. I.e. should be called
 // by query transaction
 func (sc *SystemContract) GetEvaluateTransactions() []string {
-	return []string{"GetMetadata"}
+	return []string{"GetMetadata", "GetOpenAPI", "GetCollectionsConfig"}
+}
+
+// GetOpenAPI returns the metadata of the chaincode the system
+// contract is part of converted to a JSON formatted OpenAPI 3
+// document. See metadata.ToOpenAPI3
+func (sc *SystemContract) GetOpenAPI() (string, error) {
+	ccm := metadata.ContractChaincodeMetadata{}
+
+	if err := json.Unmarshal([]byte(sc.metadata), &ccm); err != nil {
+		return "", fmt.Errorf("failed to read metadata. %s", err.Error())
+	}
+
+	doc, err := metadata.ToOpenAPI3(ccm)
+	if err != nil {
+		return "", err
+	}
+
+	docJSON, _ := json.Marshal(doc)
+
+	return string(docJSON), nil
+}
+
+// GetCollectionsConfig returns the JSON formatted collections config
+// of the chaincode the system contract is part of, defining the private
+// data collections declared by its contracts. This can be used as the
+// collections config file when the chaincode is approved. See
+// metadata.ToCollectionsConfig
+func (sc *SystemContract) GetCollectionsConfig() (string, error) {
+	ccm := metadata.ContractChaincodeMetadata{}
+
+	if err := json.Unmarshal([]byte(sc.metadata), &ccm); err != nil {
+		return "", fmt.Errorf("failed to read metadata. %s", err.Error())
+	}
+
+	configs, err := metadata.ToCollectionsConfig(ccm)
+	if err != nil {
+		return "", err
+	}
+
+	configsJSON, _ := json.Marshal(configs)
+
+	return string(configsJSON), nil
 }
@@ -30,5 +30,39 @@ func TestGetMetadata(t *testing.T) {
 func TestGetEvaluateTransactions(t *testing.T) {
 	sc := SystemContract{}
 
-	assert.Equal(t, []string{"GetMetadata"}, sc.GetEvaluateTransactions(), "should have returned functions names that should be evaluate")
+	assert.Equal(t, []string{"GetMetadata", "GetOpenAPI", "GetCollectionsConfig"}, sc.GetEvaluateTransactions(), "should have returned functions names that should be evaluate")
+}
+
+func TestGetOpenAPI(t *testing.T) {
+	sc := SystemContract{}
+	sc.metadata = "bad"
+
+	_, err := sc.GetOpenAPI()
+	assert.ErrorContains(t, err, "failed to read metadata.", "should error when metadata invalid")
+
+	sc.metadata = `{"contracts":{"myContract":{"name":"myContract","transactions":[{"name":"Read","returns":{"$ref":"#/components/schemas/missing"}}]}}}`
+	_, err = sc.GetOpenAPI()
+	assert.EqualError(t, err, "error converting myContract [Read]. return invalid. reference to unknown component #/components/schemas/missing", "should error when metadata cannot be converted")
+
+	sc.metadata = `{"info":{"title":"my chaincode","version":"1.0.0"},"contracts":{"myContract":{"name":"myContract","transactions":[{"name":"Read","tag":["EVALUATE"]}]}},"components":{}}`
+	doc, err := sc.GetOpenAPI()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"openapi":"3.0.3","info":{"title":"my chaincode","version":"1.0.0"},"tags":[{"name":"myContract"}],"paths":{"/myContract/Read":{"post":{"operationId":"myContract:Read","tags":["myContract"],"responses":{"200":{"description":"The transaction succeeded"}},"x-fabric-transaction-type":"EVALUATE"}}},"components":{"schemas":{}}}`, doc, "should return OpenAPI document")
+}
+
+func TestGetCollectionsConfig(t *testing.T) {
+	sc := SystemContract{}
+	sc.metadata = "bad"
+
+	_, err := sc.GetCollectionsConfig()
+	assert.ErrorContains(t, err, "failed to read metadata.", "should error when metadata invalid")
+
+	sc.metadata = `{"contracts":{"myContract":{"name":"myContract","transactions":[],"collections":[{"name":"myCollection"}]}}}`
+	_, err = sc.GetCollectionsConfig()
+	assert.EqualError(t, err, "collection myCollection of contract myContract has no policy", "should error when metadata cannot be converted")
+
+	sc.metadata = `{"contracts":{"myContract":{"name":"myContract","transactions":[],"collections":[{"name":"myCollection","description":"some collection","policy":"OR('Org1MSP.member')","requiredPeerCount":1,"maxPeerCount":2}]}}}`
+	config, err := sc.GetCollectionsConfig()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"name":"myCollection","policy":"OR('Org1MSP.member')","requiredPeerCount":1,"maxPeerCount":2,"blockToLive":0,"memberOnlyRead":false,"memberOnlyWrite":false}]`, config, "should return collections config")
 }
@@ -4,8 +4,15 @@
 package contractapi
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
 	"github.com/hyperledger/fabric-chaincode-go/v2/pkg/cid"
 	"github.com/hyperledger/fabric-chaincode-go/v2/shim"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/hyperledger/fabric-contract-api-go/v2/serializer"
 )
 
 // TransactionContextInterface defines the interface which TransactionContext
@@ -19,6 +26,40 @@ type TransactionContextInterface interface {
 	GetClientIdentity() cid.ClientIdentity
 }
 
+// SerializerTransactionContextInterface extends TransactionContextInterface and provides
+// access to the transaction serializer of the chaincode so that values can be stored in the
+// same format that they are passed to and returned from transactions. TransactionContext
+// meets this interface.
+type SerializerTransactionContextInterface interface {
+	TransactionContextInterface
+	// GetTransactionSerializer should provide a way to access the serializer set by Init/Invoke
+	GetTransactionSerializer() serializer.TransactionSerializer
+}
+
+// EventTransactionContextInterface extends TransactionContextInterface and provides a way
+// of emitting events with typed payloads. TransactionContext meets this interface.
+type EventTransactionContextInterface interface {
+	TransactionContextInterface
+	// EmitEvent should set the event of the transaction with the passed name and payload
+	EmitEvent(name string, payload interface{}) error
+	// BatchEvent should add an event with the passed name and payload to the batch of
+	// events set as the event of the transaction
+	BatchEvent(name string, payload interface{}) error
+}
+
+// CollectionTransactionContextInterface extends TransactionContextInterface and provides access
+// to the private data collections which the function called may use. TransactionContext meets
+// this interface.
+type CollectionTransactionContextInterface interface {
+	TransactionContextInterface
+	// GetCollection should return the named private data collection, erroring if the function
+	// called may not use it
+	GetCollection(name string) (*Collection, error)
+	// GetImplicitOrgCollection should return the implicit private data collection of the
+	// organization with the MSP ID
+	GetImplicitOrgCollection(mspID string) (*Collection, error)
+}
+
 // SettableTransactionContextInterface defines functions a valid transaction context
 // should have. Transaction context's set for contracts to be used in chaincode
 // must implement this interface.
@@ -33,19 +74,62 @@ type SettableTransactionContextInterface interface {
 	SetClientIdentity(ci cid.ClientIdentity)
 }
 
+// SerializerSettableTransactionContextInterface extends SettableTransactionContextInterface
+// and provides additional functionality that can be used to pass the transaction serializer
+// of the chaincode to the transaction context. Transaction contexts that do not meet this
+// interface are not passed the serializer.
+type SerializerSettableTransactionContextInterface interface {
+	// SetTransactionSerializer should provide a way to pass the serializer of the chaincode to
+	// the transaction context. This is called by Init/Invoke with the TransactionSerializer of
+	// the chaincode, even when the contract or function called sets its own serializer for its
+	// args and returns, so that values stored and events emitted using the serializer of the
+	// context are encoded the same way whichever function is called.
+	SetTransactionSerializer(serializer.TransactionSerializer)
+}
+
+// EventsSettableTransactionContextInterface extends SettableTransactionContextInterface
+// and provides additional functionality that can be used to pass the events the contract
+// declares to the transaction context. Transaction contexts that do not meet this interface
+// are not passed the events.
+type EventsSettableTransactionContextInterface interface {
+	// SetEvents should provide a way to pass the events the contract declares, keyed by name
+	// with a value of the type of their payload, to the transaction context. This is called by
+	// Init/Invoke when the contract declares its events.
+	SetEvents(events map[string]interface{})
+}
+
+// CollectionsSettableTransactionContextInterface extends SettableTransactionContextInterface
+// and provides additional functionality that can be used to pass the private data collections
+// which the function called may use to the transaction context. Transaction contexts that do
+// not meet this interface are not passed the collections.
+type CollectionsSettableTransactionContextInterface interface {
+	// SetCollections should provide a way to pass the private data collections the function
+	// called may use, and whether it may read or write each, to the transaction context. This
+	// is called by Init/Invoke when the contract declares its collections.
+	SetCollections(collections []metadata.CollectionUsage)
+}
+
 // TransactionContext is a basic transaction context to be used in contracts,
 // containing minimal required functionality use in contracts as part of
 // chaincode. Provides access to the stub and clientIdentity of a transaction.
 // If a contract implements the ContractInterface using the Contract struct then
 // this is the default transaction context that will be used.
 type TransactionContext struct {
-	stub           shim.ChaincodeStubInterface
-	clientIdentity cid.ClientIdentity
+	stub                  shim.ChaincodeStubInterface
+	clientIdentity        cid.ClientIdentity
+	transactionSerializer serializer.TransactionSerializer
+	events                []BatchedEvent
+	batched               bool
+	declaredEvents        map[string]interface{}
+	collections           map[string]metadata.CollectionUsage
 }
 
-// SetStub stores the passed stub in the transaction context
+// SetStub stores the passed stub in the transaction context and clears
+// the events emitted using the previous stub
 func (ctx *TransactionContext) SetStub(stub shim.ChaincodeStubInterface) {
 	ctx.stub = stub
+	ctx.events = nil
+	ctx.batched = false
 }
 
 // SetClientIdentity stores the passed stub in the transaction context
@@ -53,6 +137,25 @@ func (ctx *TransactionContext) SetClientIdentity(ci cid.ClientIdentity) {
 	ctx.clientIdentity = ci
 }
 
+// SetTransactionSerializer stores the passed serializer in the transaction context
+func (ctx *TransactionContext) SetTransactionSerializer(ts serializer.TransactionSerializer) {
+	ctx.transactionSerializer = ts
+}
+
+// SetEvents stores the events the contract declares in the transaction context
+func (ctx *TransactionContext) SetEvents(events map[string]interface{}) {
+	ctx.declaredEvents = events
+}
+
+// SetCollections stores the passed private data collections in the transaction context
+func (ctx *TransactionContext) SetCollections(collections []metadata.CollectionUsage) {
+	ctx.collections = make(map[string]metadata.CollectionUsage)
+
+	for _, collection := range collections {
+		ctx.collections[collection.Name] = collection
+	}
+}
+
 // GetStub returns the current set stub
 func (ctx *TransactionContext) GetStub() shim.ChaincodeStubInterface {
 	return ctx.stub
@@ -62,3 +165,130 @@ func (ctx *TransactionContext) GetStub() shim.ChaincodeStubInterface {
 func (ctx *TransactionContext) GetClientIdentity() cid.ClientIdentity {
 	return ctx.clientIdentity
 }
+
+// GetTransactionSerializer returns the current set transaction serializer. If none
+// has been set then a JSONSerializer is returned
+func (ctx *TransactionContext) GetTransactionSerializer() serializer.TransactionSerializer {
+	if ctx.transactionSerializer == nil {
+		return new(serializer.JSONSerializer)
+	}
+
+	return ctx.transactionSerializer
+}
+
+// EmitEvent sets the event of the transaction with the passed name and the payload
+// formatted by the transaction serializer. A nil payload sends an event without
+// payload. If events have been set, because the contract declares its events, an
+// error is returned unless the event is one of them and the payload is of the type
+// declared for it. As Fabric allows only one event per transaction an error is
+// returned if the transaction has already emitted an event; use BatchEvent to emit
+// more than one. Events set directly using the stub are replaced
+func (ctx *TransactionContext) EmitEvent(name string, payload interface{}) error {
+	event, err := ctx.formatEvent(name, payload)
+
+	if err != nil {
+		return err
+	}
+
+	if len(ctx.events) > 0 {
+		return fmt.Errorf("event %s cannot be emitted as the transaction has already emitted an event. Use BatchEvent to emit more than one event", name)
+	}
+
+	ctx.events = append(ctx.events, event)
+
+	return ctx.stub.SetEvent(event.Name, event.Payload)
+}
+
+// BatchEvent adds an event with the passed name and the payload formatted by the
+// transaction serializer to the batch of events of the transaction, checking it
+// against the declared events as EmitEvent does. The batch is set as the event of
+// the transaction named EventBatchName however many events it holds, so clients
+// listen for EventBatchName and use ParseEvents to read the events. An error is
+// returned if the transaction has emitted an event using EmitEvent. Events set
+// directly using the stub are replaced
+func (ctx *TransactionContext) BatchEvent(name string, payload interface{}) error {
+	event, err := ctx.formatEvent(name, payload)
+
+	if err != nil {
+		return err
+	}
+
+	if len(ctx.events) > 0 && !ctx.batched {
+		return fmt.Errorf("event %s cannot be batched as the transaction has already emitted an event using EmitEvent", name)
+	}
+
+	ctx.events = append(ctx.events, event)
+	ctx.batched = true
+
+	batch, err := json.Marshal(EventBatch{Events: ctx.events})
+
+	if err != nil {
+		return fmt.Errorf("failed to format batch of events. %s", err.Error())
+	}
+
+	return ctx.stub.SetEvent(EventBatchName, batch)
+}
+
+func (ctx *TransactionContext) formatEvent(name string, payload interface{}) (BatchedEvent, error) {
+	if name == "" {
+		return BatchedEvent{}, errors.New("event name must not be blank")
+	}
+
+	if ctx.declaredEvents != nil {
+		declared, ok := ctx.declaredEvents[name]
+
+		if !ok {
+			return BatchedEvent{}, fmt.Errorf("event %s is not declared by the contract", name)
+		}
+
+		if eventPayloadType(payload) != eventPayloadType(declared) {
+			return BatchedEvent{}, fmt.Errorf("payload of event %s is not of the type declared by the contract", name)
+		}
+	}
+
+	event := BatchedEvent{Name: name}
+
+	if payload != nil {
+		str, err := ctx.GetTransactionSerializer().ToString(reflect.ValueOf(payload), reflect.TypeOf(payload), nil, nil)
+
+		if err != nil {
+			return BatchedEvent{}, fmt.Errorf("failed to format payload of event %s. %s", name, err.Error())
+		}
+
+		event.Payload = []byte(str)
+	}
+
+	return event, nil
+}
+
+// GetCollection returns the named private data collection. If collections have been set then
+// an error is returned unless the collection is one of them or an implicit org collection, and
+// reads and writes of the collection are refused unless the collection set permits them. If no
+// collections have been set, because the contract does not declare its collections, any
+// collection may be used
+func (ctx *TransactionContext) GetCollection(name string) (*Collection, error) {
+	collection := NewCollection(ctx.stub, name)
+
+	if ctx.collections == nil {
+		return collection, nil
+	}
+
+	if usage, ok := ctx.collections[name]; ok {
+		collection.read = usage.Read
+		collection.write = usage.Write
+	} else if !isImplicitOrgCollection(name) {
+		return nil, fmt.Errorf("private data collection %s is not declared for use by the transaction", name)
+	}
+
+	return collection, nil
+}
+
+// GetImplicitOrgCollection returns the implicit private data collection of the organization with
+// the MSP ID. Implicit org collections may be used without being declared by the contract
+func (ctx *TransactionContext) GetImplicitOrgCollection(mspID string) (*Collection, error) {
+	if mspID == "" {
+		return nil, errors.New("MSP ID of implicit org collection must not be blank")
+	}
+
+	return ctx.GetCollection(ImplicitOrgCollection(mspID))
+}
@@ -5,8 +5,13 @@ package contractapi
 
 import (
 	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"reflect"
 	"testing"
 
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/hyperledger/fabric-contract-api-go/v2/serializer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -36,6 +41,14 @@ func (mci *mockClientIdentity) GetX509Certificate() (*x509.Certificate, error) {
 	return nil, nil
 }
 
+type badSerializer struct {
+	serializer.JSONSerializer
+}
+
+func (bs *badSerializer) ToString(reflect.Value, reflect.Type, *metadata.ReturnMetadata, *metadata.ComponentMetadata) (string, error) {
+	return "", errors.New("serializer error")
+}
+
 // ================================
 // Tests
 // ================================
@@ -77,3 +90,110 @@ func TestGetClientIdentity(t *testing.T) {
 
 	assert.Equal(t, ci, ctx.GetClientIdentity(), "should have returned same client identity as set")
 }
+
+func TestSetTransactionSerializer(t *testing.T) {
+	ts := new(serializer.JSONSerializer)
+
+	ctx := TransactionContext{}
+
+	ctx.SetTransactionSerializer(ts)
+
+	assert.Same(t, ts, ctx.transactionSerializer, "should have set the same serializer as passed")
+}
+
+func TestGetTransactionSerializer(t *testing.T) {
+	ctx := TransactionContext{}
+
+	assert.Equal(t, new(serializer.JSONSerializer), ctx.GetTransactionSerializer(), "should return JSON serializer when none set")
+
+	ts := new(serializer.JSONSerializer)
+	ctx.transactionSerializer = ts
+
+	assert.Same(t, ts, ctx.GetTransactionSerializer(), "should have returned same serializer as set")
+}
+
+func TestEmitEvent(t *testing.T) {
+	stub := NewMockChaincodeStub(t)
+	ctx := TransactionContext{}
+	ctx.SetStub(stub)
+
+	assert.EqualError(t, ctx.EmitEvent("", nil), "event name must not be blank", "should error when name blank")
+
+	stub.EXPECT().SetEvent("Created", []byte(`{"id":"asset1"}`)).Return(nil).Once()
+	assert.Nil(t, ctx.EmitEvent("Created", map[string]string{"id": "asset1"}), "should emit single event using serializer")
+
+	assert.EqualError(t, ctx.EmitEvent("Deleted", nil), "event Deleted cannot be emitted as the transaction has already emitted an event. Use BatchEvent to emit more than one event", "should error when event already emitted")
+	assert.EqualError(t, ctx.BatchEvent("Deleted", nil), "event Deleted cannot be batched as the transaction has already emitted an event using EmitEvent", "should error when batching after event emitted")
+
+	ctx.SetStub(stub)
+	stub.EXPECT().SetEvent("Deleted", []byte(nil)).Return(nil).Once()
+	assert.Nil(t, ctx.EmitEvent("Deleted", nil), "should clear events when stub set")
+
+	ctx.SetStub(stub)
+	batch, _ := json.Marshal(EventBatch{Events: []BatchedEvent{{Name: "Created", Payload: []byte(`{"id":"asset1"}`)}}})
+	stub.EXPECT().SetEvent(EventBatchName, batch).Return(nil).Once()
+	assert.Nil(t, ctx.BatchEvent("Created", map[string]string{"id": "asset1"}), "should emit batch holding single event")
+
+	batch, _ = json.Marshal(EventBatch{Events: []BatchedEvent{
+		{Name: "Created", Payload: []byte(`{"id":"asset1"}`)},
+		{Name: "Deleted"},
+	}})
+	stub.EXPECT().SetEvent(EventBatchName, batch).Return(errors.New("set event error")).Once()
+	assert.EqualError(t, ctx.BatchEvent("Deleted", nil), "set event error", "should emit batch holding each batched event")
+	assert.EqualError(t, ctx.EmitEvent("Updated", nil), "event Updated cannot be emitted as the transaction has already emitted an event. Use BatchEvent to emit more than one event", "should error when emitting after events batched")
+	assert.EqualError(t, ctx.BatchEvent("", nil), "event name must not be blank", "should error when batched name blank")
+
+	ctx.SetStub(stub)
+	ctx.SetTransactionSerializer(new(badSerializer))
+	assert.EqualError(t, ctx.EmitEvent("Created", 1), "failed to format payload of event Created. serializer error", "should error when serializer errors")
+
+	ctx.SetStub(stub)
+	ctx.SetTransactionSerializer(nil)
+	ctx.SetEvents(map[string]interface{}{"Created": map[string]string{}, "Deleted": nil})
+	assert.EqualError(t, ctx.EmitEvent("Updated", nil), "event Updated is not declared by the contract", "should error when event not declared")
+	assert.EqualError(t, ctx.EmitEvent("Created", "asset1"), "payload of event Created is not of the type declared by the contract", "should error when payload not of declared type")
+	assert.EqualError(t, ctx.EmitEvent("Deleted", "asset1"), "payload of event Deleted is not of the type declared by the contract", "should error when payload given for event declared without one")
+	assert.EqualError(t, ctx.BatchEvent("Updated", nil), "event Updated is not declared by the contract", "should error when batched event not declared")
+
+	stub.EXPECT().SetEvent("Created", []byte(`{"id":"asset1"}`)).Return(nil).Once()
+	assert.Nil(t, ctx.EmitEvent("Created", &map[string]string{"id": "asset1"}), "should emit declared event with pointer to payload of declared type")
+}
+
+func TestSetCollections(t *testing.T) {
+	ctx := TransactionContext{}
+	ctx.SetCollections([]metadata.CollectionUsage{{Name: "Org1AndOrg2", Read: true}})
+
+	assert.Equal(t, map[string]metadata.CollectionUsage{"Org1AndOrg2": {Name: "Org1AndOrg2", Read: true}}, ctx.collections, "should have set collections by name")
+
+	ctx.SetCollections(nil)
+	assert.Equal(t, map[string]metadata.CollectionUsage{}, ctx.collections, "should have set no collections")
+}
+
+func TestGetCollection(t *testing.T) {
+	var collection *Collection
+	var err error
+
+	stub := NewMockChaincodeStub(t)
+	ctx := TransactionContext{}
+	ctx.SetStub(stub)
+
+	collection, err = ctx.GetCollection("Org1AndOrg2")
+	assert.Nil(t, err)
+	assert.Equal(t, NewCollection(stub, "Org1AndOrg2"), collection, "should return unrestricted collection when no collections set")
+
+	ctx.SetCollections([]metadata.CollectionUsage{{Name: "Org1AndOrg2", Read: true}})
+
+	collection, err = ctx.GetCollection("Org1AndOrg2")
+	assert.Nil(t, err)
+	assert.Equal(t, &Collection{name: "Org1AndOrg2", stub: stub, read: true}, collection, "should return collection restricted to declared use")
+
+	_, err = ctx.GetCollection("Org1Only")
+	assert.EqualError(t, err, "private data collection Org1Only is not declared for use by the transaction", "should error when collection not declared")
+
+	collection, err = ctx.GetImplicitOrgCollection("Org1MSP")
+	assert.Nil(t, err)
+	assert.Equal(t, NewCollection(stub, "_implicit_org_Org1MSP"), collection, "should return implicit org collection without declaration")
+
+	_, err = ctx.GetImplicitOrgCollection("")
+	assert.EqualError(t, err, "MSP ID of implicit org collection must not be blank", "should error when MSP ID blank")
+}
@@ -0,0 +1,53 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contractapi
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/go-openapi/spec"
+	"github.com/hyperledger/fabric-contract-api-go/v2/internal/types"
+)
+
+// TypeConverter converts values of a type registered using RegisterType to and from
+// the strings used to pass them to and from transactions
+type TypeConverter[T any] struct {
+	FromString func(string) (T, error)
+	ToString   func(T) string
+}
+
+// RegisterType registers a type so that it can be used as a parameter or return type of
+// contract functions, or as the type of a property of structs they use. Values of the type
+// are passed as the strings produced by the converter, including when within structs, slices
+// and maps, and are described in metadata by the schema, which should describe those strings.
+// If the schema is nil any string is allowed. Only named types declared in a package, or
+// pointers to them, can be registered and each only once. Types must be registered before
+// creating chaincode that uses them, for example in an init function
+//
+//	contractapi.RegisterType(contractapi.TypeConverter[time.Duration]{
+//		FromString: time.ParseDuration,
+//		ToString:   time.Duration.String,
+//	}, spec.StringProperty())
+func RegisterType[T any](converter TypeConverter[T], schema *spec.Schema) error {
+	if converter.FromString == nil || converter.ToString == nil {
+		return errors.New("type converter must define both FromString and ToString")
+	}
+
+	parse := func(str string) (reflect.Value, error) {
+		value, err := converter.FromString(str)
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		return reflect.ValueOf(&value).Elem(), nil
+	}
+
+	format := func(value reflect.Value) string {
+		return converter.ToString(value.Interface().(T))
+	}
+
+	return types.RegisterType(reflect.TypeOf((*T)(nil)).Elem(), parse, format, schema)
+}
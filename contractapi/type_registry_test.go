@@ -0,0 +1,109 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package contractapi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// HELPERS
+// ================================
+
+type currencyCode string
+
+type weight struct {
+	grams int
+}
+
+type shipment struct {
+	Currency currencyCode `json:"currency"`
+	Weight   *weight      `json:"weight"`
+}
+
+type registeredTypesContract struct {
+	Contract
+}
+
+func (rtc *registeredTypesContract) Ship(currency currencyCode, grams int) *shipment {
+	return &shipment{Currency: currency, Weight: &weight{grams}}
+}
+
+func (rtc *registeredTypesContract) Weigh(s shipment) weight {
+	return *s.Weight
+}
+
+var currencyCodeConverter = TypeConverter[currencyCode]{
+	FromString: func(str string) (currencyCode, error) {
+		if len(str) != 3 {
+			return "", errors.New("currency codes have three letters")
+		}
+
+		return currencyCode(strings.ToUpper(str)), nil
+	},
+	ToString: func(code currencyCode) string {
+		return string(code)
+	},
+}
+
+func weightConverter[T weight | *weight](toWeight func(int) T, fromWeight func(T) int) TypeConverter[T] {
+	return TypeConverter[T]{
+		FromString: func(str string) (T, error) {
+			grams, err := strconv.Atoi(strings.TrimSuffix(str, "g"))
+			return toWeight(grams), err
+		},
+		ToString: func(w T) string {
+			return fmt.Sprintf("%dg", fromWeight(w))
+		},
+	}
+}
+
+// ================================
+// TESTS
+// ================================
+
+func TestRegisterType(t *testing.T) {
+	var err error
+
+	err = RegisterType(TypeConverter[currencyCode]{}, nil)
+	require.EqualError(t, err, "type converter must define both FromString and ToString", "should error for incomplete converter")
+
+	err = RegisterType(TypeConverter[string]{FromString: func(s string) (string, error) { return s, nil }, ToString: func(s string) string { return s }}, nil)
+	require.EqualError(t, err, "cannot register type string. Only named types declared in a package, and pointers to them, may be registered", "should error for predeclared type")
+
+	err = RegisterType(TypeConverter[[]currencyCode]{FromString: func(string) ([]currencyCode, error) { return nil, nil }, ToString: func([]currencyCode) string { return "" }}, nil)
+	require.EqualError(t, err, "cannot register type []contractapi.currencyCode. Only named types declared in a package, and pointers to them, may be registered", "should error for unnamed type")
+
+	err = RegisterType(currencyCodeConverter, spec.StringProperty().WithPattern("^[A-Z]{3}$"))
+	require.NoError(t, err, "should register named type")
+
+	err = RegisterType(currencyCodeConverter, nil)
+	require.EqualError(t, err, "cannot register type contractapi.currencyCode. The type is already registered", "should error for type already registered")
+
+	err = RegisterType(weightConverter(func(grams int) weight { return weight{grams} }, func(w weight) int { return w.grams }), nil)
+	require.NoError(t, err, "should register struct type")
+
+	err = RegisterType(weightConverter(func(grams int) *weight { return &weight{grams} }, func(w *weight) int { return w.grams }), spec.StringProperty().WithPattern("^[0-9]+g$"))
+	require.NoError(t, err, "should register pointer to struct type")
+
+	cc, err := NewChaincode(new(registeredTypesContract))
+	require.NoError(t, err, "should create chaincode using registered types")
+
+	callContractFunctionAndCheckSuccess(t, cc, []string{"Ship", "gbp", "1200"}, invokeType, `{"currency":"GBP","weight":"1200g"}`)
+	callContractFunctionAndCheckSuccess(t, cc, []string{"Weigh", `{"currency":"EUR","weight":"15g"}`}, invokeType, "15g")
+	callContractFunctionAndCheckError(t, cc, []string{"Ship", "pounds", "1200"}, invokeType, "error managing parameter param0. conversion error. cannot convert passed value pounds to contractapi.currencyCode. currency codes have three letters")
+	callContractFunctionAndCheckError(t, cc, []string{"Weigh", `{"currency":"EUR","weight":"heavy"}`}, invokeType, "error managing parameter param0. conversion error. value {\"currency\":\"EUR\",\"weight\":\"heavy\"} was not passed in expected format contractapi.shipment")
+
+	transactions := cc.metadata.Contracts["registeredTypesContract"].Transactions
+	require.Equal(t, spec.StringProperty().WithPattern("^[A-Z]{3}$"), transactions[0].Parameters[0].Schema, "should describe registered type using its schema")
+	require.Equal(t, spec.StringProperty(), transactions[1].Returns.Schema, "should describe registered type without schema as string")
+	require.Equal(t, *spec.StringProperty().WithPattern("^[0-9]+g$"), cc.metadata.Components.Schemas["shipment"].Properties["weight"], "should describe registered type in struct using its schema")
+}
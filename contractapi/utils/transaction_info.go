@@ -0,0 +1,35 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+// TransactionInfo describes the transaction being handled. It is passed to before, after
+// and unknown transaction handlers that take a *TransactionInfo as their last parameter
+type TransactionInfo struct {
+	// ContractName is the name of the contract called
+	ContractName string
+
+	// FunctionName is the name of the contract function called. For unknown functions
+	// this is the name as sent in the transaction
+	FunctionName string
+
+	// CallType is SUBMIT or EVALUATE matching the tag of the function in the metadata.
+	// It is blank for unknown functions
+	CallType string
+
+	// RawArgs are the arguments of the transaction as sent
+	RawArgs []string
+
+	// Args are the arguments of the transaction converted to the parameter types of
+	// the function. They are nil for unknown functions and when the arguments cannot
+	// be converted, in which case the error converting them is returned in place of
+	// calling the function
+	Args []interface{}
+
+	// Result is the value returned by the function. It is only set for after transactions
+	Result interface{}
+
+	// Err is the error returned by the function. It is only set for after transactions,
+	// which are called when the function returns an error if they take a *TransactionInfo
+	Err error
+}
@@ -0,0 +1,12 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+// Transient is the type of a parameter of a contract function whose value is read from the
+// transient data of the transaction rather than from its args. The transient data is keyed
+// by the name of the parameter in the metadata and the value is converted to T by the
+// serializer of the function
+type Transient[T any] struct {
+	Value T
+}
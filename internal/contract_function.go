@@ -4,18 +4,44 @@
 package internal
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
+	"github.com/go-openapi/spec"
+	"github.com/hyperledger/fabric-contract-api-go/v2/contractapi/utils"
 	"github.com/hyperledger/fabric-contract-api-go/v2/internal/types"
 	metadata "github.com/hyperledger/fabric-contract-api-go/v2/metadata"
 	"github.com/hyperledger/fabric-contract-api-go/v2/serializer"
 )
 
+var transactionInfoType = reflect.TypeOf((*utils.TransactionInfo)(nil))
+
+var transientPkgPath = reflect.TypeOf(utils.TransactionInfo{}).PkgPath()
+
+// isTransientType reports whether a type is an instantiation of utils.Transient
+func isTransientType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.PkgPath() == transientPkgPath && strings.HasPrefix(t.Name(), "Transient[")
+}
+
+// transientParam a parameter of a function whose value is read from the transient data of the
+// transaction. Its position is its index among the parameters of the function other than the context
+type transientParam struct {
+	position int
+	wrapper  reflect.Type
+}
+
 type contractFunctionParams struct {
-	context reflect.Type
-	fields  []reflect.Type
+	context   reflect.Type
+	fields    []reflect.Type
+	info      bool
+	defaults  []reflect.Value
+	variadic  bool
+	transient []transientParam
 }
 
 type contractFunctionReturns struct {
@@ -35,6 +61,18 @@ const (
 	CallTypeEvaluate
 )
 
+// String returns the tag used in the metadata for the call type
+func (ct CallType) String() string {
+	switch ct {
+	case CallTypeSubmit:
+		return "SUBMIT"
+	case CallTypeEvaluate:
+		return "EVALUATE"
+	default:
+		return ""
+	}
+}
+
 // ContractFunction contains a description of a function so that it can be called by a chaincode
 type ContractFunction struct {
 	function reflect.Value
@@ -45,18 +83,191 @@ type ContractFunction struct {
 
 // Call calls function in a contract using string args and handles formatting the response into useful types
 func (cf ContractFunction) Call(ctx reflect.Value, supplementaryMetadata *metadata.TransactionMetadata, components *metadata.ComponentMetadata, serializer serializer.TransactionSerializer, params ...string) (string, interface{}, error) {
+	args, err := cf.DecodeArgs(supplementaryMetadata, components, serializer, params...)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return cf.CallWithArgs(ctx, supplementaryMetadata, components, serializer, args...)
+}
+
+// DecodeArgs converts string args to the types of the function's parameters
+func (cf ContractFunction) DecodeArgs(supplementaryMetadata *metadata.TransactionMetadata, components *metadata.ComponentMetadata, serializer serializer.TransactionSerializer, params ...string) ([]interface{}, error) {
 	var parameterMetadata []metadata.ParameterMetadata
 	if supplementaryMetadata != nil {
 		parameterMetadata = supplementaryMetadata.Parameters
 	}
 
-	values, err := cf.formatArgs(ctx, parameterMetadata, components, params, serializer)
+	values, err := cf.formatArgs(reflect.Value{}, parameterMetadata, components, params, serializer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cf.argsFromValues(values), nil
+}
+
+// DecodeBytesArgs converts byte args to the types of the function's parameters using a serializer
+// which converts from bytes directly
+func (cf ContractFunction) DecodeBytesArgs(supplementaryMetadata *metadata.TransactionMetadata, components *metadata.ComponentMetadata, serializer serializer.BytesTransactionSerializer, params ...[]byte) ([]interface{}, error) {
+	var parameterMetadata []metadata.ParameterMetadata
+	if supplementaryMetadata != nil {
+		parameterMetadata = supplementaryMetadata.Parameters
+	}
+
+	values, err := cf.formatBytesArgs(reflect.Value{}, parameterMetadata, components, params, serializer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cf.argsFromValues(values), nil
+}
+
+// DecodeNamedArgs converts JSON args keyed by the names of the function's parameters, as given in the
+// supplementary metadata, to the types of the parameters. Each arg must be a JSON value of a type allowed by
+// the schema of its parameter. JSON strings are converted from the string, other values from their JSON, so
+// that they are converted as they would be if passed in order. Parameters which may be omitted when args are
+// passed in order may be left out or null, in which case their default or zero value is used. Args which are
+// not parameters of the function are rejected
+func (cf ContractFunction) DecodeNamedArgs(supplementaryMetadata *metadata.TransactionMetadata, components *metadata.ComponentMetadata, serializer serializer.TransactionSerializer, params map[string]json.RawMessage) ([]interface{}, error) {
+	if supplementaryMetadata == nil {
+		return nil, errors.New("named args require the metadata of the function")
+	}
+
+	names := []string{}
+
+	for name := range params {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !parameterNamed(supplementaryMetadata.Parameters, name) {
+			return nil, fmt.Errorf("named args include %s which is not a parameter of the function", name)
+		}
+	}
+
+	values, err := cf.formatNamedArgs(reflect.Value{}, supplementaryMetadata.Parameters, components, params, serializer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cf.argsFromValues(values), nil
+}
+
+// TakesTransient returns whether the function has parameters whose values are read from the transient
+// data of the transaction
+func (cf ContractFunction) TakesTransient() bool {
+	return len(cf.params.transient) > 0
+}
+
+// TransientPositions returns the positions of the function's transient parameters amongst its parameters,
+// excluding the transaction context
+func (cf ContractFunction) TransientPositions() []int {
+	positions := []int{}
+
+	for _, param := range cf.params.transient {
+		positions = append(positions, param.position)
+	}
+
+	return positions
+}
+
+// DecodeTransientArgs converts the values in the transient data of the transaction for the function's transient
+// parameters, keyed by the names of the parameters in the supplementary metadata, and returns the args decoded for
+// the function's other parameters with them inserted in the positions of their parameters. Values are converted
+// from bytes if the serializer converts from bytes directly. Transient parameters of pointer types may be left out
+func (cf ContractFunction) DecodeTransientArgs(supplementaryMetadata *metadata.TransactionMetadata, components *metadata.ComponentMetadata, txSerializer serializer.TransactionSerializer, transient map[string][]byte, args []interface{}) ([]interface{}, error) {
+	if len(cf.params.transient) == 0 {
+		return args, nil
+	}
+
+	if supplementaryMetadata == nil {
+		return nil, errors.New("transient args require the metadata of the function")
+	}
+
+	if len(supplementaryMetadata.Transient) != len(cf.params.transient) {
+		return nil, fmt.Errorf("incorrect number of transient params in supplementary metadata. Expected %d, received %d", len(cf.params.transient), len(supplementaryMetadata.Transient))
+	}
+
+	if len(args) != len(cf.params.fields) {
+		return nil, fmt.Errorf("incorrect number of args. Expected %d, received %d", len(cf.params.fields), len(args))
+	}
+
+	allArgs := []interface{}{}
+
+	for i, param := range cf.params.transient {
+		paramMetadata := &supplementaryMetadata.Transient[i]
+		value := reflect.New(param.wrapper).Elem()
+		valueType := value.Field(0).Type()
+
+		if data, ok := transient[paramMetadata.Name]; ok {
+			var converted reflect.Value
+			var err error
+
+			if bytesSerializer, ok := txSerializer.(serializer.BytesTransactionSerializer); ok {
+				converted, err = bytesSerializer.FromBytes(data, valueType, paramMetadata, components)
+			} else {
+				converted, err = txSerializer.FromString(string(data), valueType, paramMetadata, components)
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("error managing transient parameter %s. %s", paramMetadata.Name, err.Error())
+			}
+
+			value.Field(0).Set(converted)
+		} else if valueType.Kind() != reflect.Pointer {
+			return nil, fmt.Errorf("error managing transient parameter %s. transient data does not include the parameter", paramMetadata.Name)
+		}
+
+		numArgs := param.position - len(allArgs)
+		allArgs = append(allArgs, args[:numArgs]...)
+		allArgs = append(allArgs, value.Interface())
+		args = args[numArgs:]
+	}
+
+	return append(allArgs, args...), nil
+}
+
+func parameterNamed(parameters []metadata.ParameterMetadata, name string) bool {
+	for _, param := range parameters {
+		if param.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (cf ContractFunction) argsFromValues(values []reflect.Value) []interface{} {
+	if cf.params.context != nil {
+		values = values[1:]
+	}
+
+	args := make([]interface{}, len(values))
+
+	for i, value := range values {
+		args[i] = value.Interface()
+	}
+
+	return args
+}
+
+// CallWithArgs calls function in a contract using already decoded args and handles formatting the response
+// into useful types. Args must be assignable to the types of the function's parameters or of the same kind and
+// convertible to them
+func (cf ContractFunction) CallWithArgs(ctx reflect.Value, supplementaryMetadata *metadata.TransactionMetadata, components *metadata.ComponentMetadata, serializer serializer.TransactionSerializer, args ...interface{}) (string, interface{}, error) {
+	values, err := cf.argValues(ctx, args)
 
 	if err != nil {
 		return "", nil, err
 	}
 
-	someResp := cf.function.Call(values)
+	someResp := cf.call(values)
 
 	var returnsMetadata *metadata.ReturnMetadata
 	if supplementaryMetadata != nil {
@@ -66,8 +277,191 @@ func (cf ContractFunction) Call(ctx reflect.Value, supplementaryMetadata *metada
 	return cf.handleResponse(someResp, returnsMetadata, components, serializer)
 }
 
+// Execute calls function in a contract using already decoded args and returns the value and error returned
+// by the function without formatting the value
+func (cf ContractFunction) Execute(ctx reflect.Value, args ...interface{}) (interface{}, error) {
+	values, err := cf.argValues(ctx, args)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, iface, err := cf.handleResponse(cf.call(values), nil, nil, nil)
+
+	return iface, err
+}
+
+// call calls the function with a value for each parameter, passing the value of a variadic
+// parameter as the slice of its args
+func (cf ContractFunction) call(values []reflect.Value) []reflect.Value {
+	if cf.params.variadic {
+		return cf.function.CallSlice(values)
+	}
+
+	return cf.function.Call(values)
+}
+
+// FormatReturn formats a value as the success response of the function using the serializer. The value must be
+// assignable to the function's success return type or of the same kind and convertible to it
+func (cf ContractFunction) FormatReturn(result interface{}, supplementaryMetadata *metadata.TransactionMetadata, components *metadata.ComponentMetadata, serializer serializer.TransactionSerializer) (string, error) {
+	if cf.returns.success == nil || serializer == nil {
+		return "", nil
+	}
+
+	value, returnsMetadata, err := cf.returnValue(result, supplementaryMetadata)
+
+	if err != nil {
+		return "", err
+	}
+
+	str, err := serializer.ToString(value, cf.returns.success, returnsMetadata, components)
+
+	if err != nil {
+		return "", fmt.Errorf("error handling success response. %s", err.Error())
+	}
+
+	return str, nil
+}
+
+// FormatReturnBytes formats a value as the success response of the function using a serializer which converts
+// to bytes directly. The value must be assignable to the function's success return type or of the same kind and
+// convertible to it
+func (cf ContractFunction) FormatReturnBytes(result interface{}, supplementaryMetadata *metadata.TransactionMetadata, components *metadata.ComponentMetadata, serializer serializer.BytesTransactionSerializer) ([]byte, error) {
+	if cf.returns.success == nil || serializer == nil {
+		return nil, nil
+	}
+
+	value, returnsMetadata, err := cf.returnValue(result, supplementaryMetadata)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := serializer.ToBytes(value, cf.returns.success, returnsMetadata, components)
+
+	if err != nil {
+		return nil, fmt.Errorf("error handling success response. %s", err.Error())
+	}
+
+	return bytes, nil
+}
+
+func (cf ContractFunction) returnValue(result interface{}, supplementaryMetadata *metadata.TransactionMetadata) (reflect.Value, *metadata.ReturnMetadata, error) {
+	value := reflect.New(cf.returns.success).Elem()
+
+	if result != nil {
+		resultValue := reflect.ValueOf(result)
+
+		if !resultValue.Type().AssignableTo(cf.returns.success) {
+			if resultValue.Kind() != cf.returns.success.Kind() || !resultValue.Type().ConvertibleTo(cf.returns.success) {
+				return reflect.Value{}, nil, fmt.Errorf("error handling success response. Value of type %s cannot be used for return type %s", resultValue.Type().String(), cf.returns.success.String())
+			}
+
+			resultValue = resultValue.Convert(cf.returns.success)
+		}
+
+		value.Set(resultValue)
+	}
+
+	var returnsMetadata *metadata.ReturnMetadata
+	if supplementaryMetadata != nil {
+		returnsMetadata = &supplementaryMetadata.Returns
+	}
+
+	return value, returnsMetadata, nil
+}
+
+// SetDefaultArgs sets the values used for the last parameters of the function when they are omitted by
+// the caller. Defaults are given for as many of the trailing parameters as there are defaults and must be
+// assignable to the types of the parameters or of the same kind and convertible to them. A nil default
+// is the zero value of the parameter type
+func (cf *ContractFunction) SetDefaultArgs(defaults ...interface{}) error {
+	numParams := len(cf.params.fields)
+
+	if len(defaults) > numParams {
+		return fmt.Errorf("too many default args. Expected at most %d, received %d", numParams, len(defaults))
+	}
+
+	values := []reflect.Value{}
+
+	for i, arg := range defaults {
+		index := numParams - len(defaults) + i
+		value, err := argValue(arg, cf.params.fields[index])
+
+		if err != nil {
+			return fmt.Errorf("default arg %d %s", index, err.Error())
+		}
+
+		values = append(values, value)
+	}
+
+	cf.params.defaults = values
+
+	return nil
+}
+
+// CheckArgCount returns an error if the number of args passed is fewer than the number of required
+// parameters of the function or more than the number of its parameters
+func (cf ContractFunction) CheckArgCount(numArgs int) error {
+	if numArgs < cf.requiredParams() || (numArgs > len(cf.params.fields) && !cf.params.variadic) {
+		return fmt.Errorf("incorrect number of params. Expected %s, received %d", cf.expectedParams(), numArgs)
+	}
+
+	return nil
+}
+
+// requiredParams returns the number of parameters which must be passed. Trailing parameters may be
+// omitted if each has a default or is a pointer or variadic
+func (cf ContractFunction) requiredParams() int {
+	required := len(cf.params.fields)
+
+	for required > 0 && cf.paramOptional(required-1) {
+		required--
+	}
+
+	return required
+}
+
+func (cf ContractFunction) paramOptional(index int) bool {
+	return cf.params.fields[index].Kind() == reflect.Pointer || index >= len(cf.params.fields)-len(cf.params.defaults) || (cf.params.variadic && index == len(cf.params.fields)-1)
+}
+
+// omittedArg returns the value used for a parameter which was not passed
+func (cf ContractFunction) omittedArg(index int) reflect.Value {
+	if defaultIndex := index - (len(cf.params.fields) - len(cf.params.defaults)); defaultIndex >= 0 {
+		return cf.params.defaults[defaultIndex]
+	}
+
+	return reflect.Zero(cf.params.fields[index])
+}
+
+func (cf ContractFunction) expectedParams() string {
+	required := cf.requiredParams()
+
+	if cf.params.variadic {
+		return fmt.Sprintf("at least %d", required)
+	}
+
+	if required == len(cf.params.fields) {
+		return fmt.Sprint(required)
+	}
+
+	return fmt.Sprintf("%d to %d", required, len(cf.params.fields))
+}
+
+// GetCallType returns whether the function should be submitted or evaluated
+func (cf ContractFunction) GetCallType() CallType {
+	return cf.callType
+}
+
 // ReflectMetadata returns the metadata for contract function
 func (cf ContractFunction) ReflectMetadata(name string, existingComponents *metadata.ComponentMetadata) metadata.TransactionMetadata {
+	return cf.ReflectMetadataWithOptions(name, existingComponents, metadata.SchemaOptions{})
+}
+
+// ReflectMetadataWithOptions returns the metadata for contract function with the schemas of its
+// parameters and return generated using the options
+func (cf ContractFunction) ReflectMetadataWithOptions(name string, existingComponents *metadata.ComponentMetadata, options metadata.SchemaOptions) metadata.TransactionMetadata {
 	transactionMetadata := metadata.TransactionMetadata{}
 	transactionMetadata.Name = name
 	transactionMetadata.Tag = []string{}
@@ -84,17 +478,40 @@ func (cf ContractFunction) ReflectMetadata(name string, existingComponents *meta
 	transactionMetadata.Tag = append(transactionMetadata.Tag, txType)
 
 	for index, field := range cf.params.fields {
-		schema, _ := metadata.GetSchema(field, existingComponents)
+		schema, _ := metadata.GetSchemaWithOptions(field, existingComponents, options)
 
 		param := metadata.ParameterMetadata{}
 		param.Name = fmt.Sprintf("param%d", index)
 		param.Schema = schema
 
+		if index >= cf.requiredParams() {
+			required := false
+			param.Required = &required
+		}
+
+		param.Variadic = cf.params.variadic && index == len(cf.params.fields)-1
+
 		transactionMetadata.Parameters = append(transactionMetadata.Parameters, param)
 	}
 
+	for index, param := range cf.params.transient {
+		valueType := param.wrapper.Field(0).Type
+		schema, _ := metadata.GetSchemaWithOptions(valueType, existingComponents, options)
+
+		transient := metadata.ParameterMetadata{}
+		transient.Name = fmt.Sprintf("transient%d", index)
+		transient.Schema = schema
+
+		if valueType.Kind() == reflect.Pointer {
+			required := false
+			transient.Required = &required
+		}
+
+		transactionMetadata.Transient = append(transactionMetadata.Transient, transient)
+	}
+
 	if cf.returns.success != nil {
-		schema, _ := metadata.GetSchema(cf.returns.success, existingComponents)
+		schema, _ := metadata.GetSchemaWithOptions(cf.returns.success, existingComponents, options)
 
 		transactionMetadata.Returns = metadata.ReturnMetadata{Schema: schema}
 	}
@@ -102,13 +519,171 @@ func (cf ContractFunction) ReflectMetadata(name string, existingComponents *meta
 	return transactionMetadata
 }
 
+func (cf *ContractFunction) argValues(ctx reflect.Value, args []interface{}) ([]reflect.Value, error) {
+	paramTypes := cf.paramTypes()
+
+	if len(args) != len(paramTypes) {
+		return nil, fmt.Errorf("incorrect number of args. Expected %d, received %d", len(paramTypes), len(args))
+	}
+
+	values := []reflect.Value{}
+
+	if cf.params.context != nil {
+		values = append(values, ctx)
+	}
+
+	for i, arg := range args {
+		value, err := argValue(arg, paramTypes[i])
+
+		if err != nil {
+			return nil, fmt.Errorf("arg %d %s", i, err.Error())
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// paramTypes returns the types of the parameters of the function other than the context, including
+// those of its transient parameters, in order
+func (cf ContractFunction) paramTypes() []reflect.Type {
+	paramTypes := make([]reflect.Type, 0, len(cf.params.fields)+len(cf.params.transient))
+	fields := cf.params.fields
+
+	for _, transient := range cf.params.transient {
+		numFields := transient.position - len(paramTypes)
+		paramTypes = append(paramTypes, fields[:numFields]...)
+		paramTypes = append(paramTypes, transient.wrapper)
+		fields = fields[numFields:]
+	}
+
+	return append(paramTypes, fields...)
+}
+
+// argValue returns an already decoded arg as a value of the type of a parameter
+func argValue(arg interface{}, fieldType reflect.Type) (reflect.Value, error) {
+	value := reflect.ValueOf(arg)
+
+	if !value.IsValid() {
+		return reflect.Zero(fieldType), nil
+	}
+
+	if !value.Type().AssignableTo(fieldType) {
+		if value.Kind() != fieldType.Kind() || !value.Type().ConvertibleTo(fieldType) {
+			return reflect.Value{}, fmt.Errorf("of type %s cannot be used for parameter of type %s", value.Type().String(), fieldType.String())
+		}
+
+		value = value.Convert(fieldType)
+	}
+
+	return value, nil
+}
+
 type formatArgResult struct {
+	index     int
 	paramName string
 	converted reflect.Value
 	err       error
 }
 
 func (cf *ContractFunction) formatArgs(ctx reflect.Value, supplementaryMetadata []metadata.ParameterMetadata, components *metadata.ComponentMetadata, params []string, serializer serializer.TransactionSerializer) ([]reflect.Value, error) {
+	return cf.convertArgs(ctx, supplementaryMetadata, len(params), true, func(i int, fieldType reflect.Type, paramMetadata *metadata.ParameterMetadata) (reflect.Value, error) {
+		return serializer.FromString(params[i], fieldType, paramMetadata, components)
+	})
+}
+
+func (cf *ContractFunction) formatBytesArgs(ctx reflect.Value, supplementaryMetadata []metadata.ParameterMetadata, components *metadata.ComponentMetadata, params [][]byte, serializer serializer.BytesTransactionSerializer) ([]reflect.Value, error) {
+	return cf.convertArgs(ctx, supplementaryMetadata, len(params), true, func(i int, fieldType reflect.Type, paramMetadata *metadata.ParameterMetadata) (reflect.Value, error) {
+		return serializer.FromBytes(params[i], fieldType, paramMetadata, components)
+	})
+}
+
+func (cf *ContractFunction) formatNamedArgs(ctx reflect.Value, supplementaryMetadata []metadata.ParameterMetadata, components *metadata.ComponentMetadata, params map[string]json.RawMessage, serializer serializer.TransactionSerializer) ([]reflect.Value, error) {
+	return cf.convertArgs(ctx, supplementaryMetadata, len(cf.params.fields), false, func(i int, fieldType reflect.Type, paramMetadata *metadata.ParameterMetadata) (reflect.Value, error) {
+		param, ok := params[paramMetadata.Name]
+		ok = ok && jsonType(param) != "null"
+
+		if !ok && i >= cf.requiredParams() {
+			return reflect.Value{}, nil
+		} else if !ok {
+			return reflect.Value{}, errors.New("named args do not include the parameter")
+		}
+
+		if err := checkJSONType(param, paramMetadata.Schema); err != nil {
+			return reflect.Value{}, err
+		}
+
+		var str string
+
+		if err := json.Unmarshal(param, &str); err != nil {
+			str = string(param)
+		}
+
+		return serializer.FromString(str, fieldType, paramMetadata, components)
+	})
+}
+
+// jsonType returns the type, as named in a schema, of a JSON value
+func jsonType(value json.RawMessage) string {
+	trimmed := bytes.TrimSpace(value)
+
+	if len(trimmed) == 0 {
+		return ""
+	}
+
+	switch trimmed[0] {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	}
+
+	return "number"
+}
+
+// checkJSONType returns an error if a JSON value is not of a type allowed by the schema. References
+// to components allow objects and schemas without a type, such as those of interfaces, allow any value
+func checkJSONType(value json.RawMessage, schema *spec.Schema) error {
+	if schema == nil {
+		return nil
+	}
+
+	allowed := []string(schema.Type)
+
+	if schema.Ref.String() != "" {
+		allowed = []string{"object"}
+	}
+
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	actual := jsonType(value)
+
+	for _, typ := range allowed {
+		if typ == actual || (typ == "integer" && actual == "number") {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("value is a JSON %s but the parameter is of type %s", actual, strings.Join(allowed, " or "))
+}
+
+// argConverter converts the arg at an index to the type of the parameter. It returns an invalid
+// value and no error for an optional arg which was not passed
+type argConverter func(int, reflect.Type, *metadata.ParameterMetadata) (reflect.Value, error)
+
+// convertArgs converts the args passed to the function to values of the types of its parameters. If spread is
+// true the args following those for the other parameters of a variadic function are each converted to the element
+// type of its variadic parameter, otherwise a single arg is converted to the slice type of the parameter
+func (cf *ContractFunction) convertArgs(ctx reflect.Value, supplementaryMetadata []metadata.ParameterMetadata, numArgs int, spread bool, converter argConverter) ([]reflect.Value, error) {
 	numParams := len(cf.params.fields)
 
 	if supplementaryMetadata != nil {
@@ -123,13 +698,19 @@ func (cf *ContractFunction) formatArgs(ctx reflect.Value, supplementaryMetadata
 		values = append(values, ctx)
 	}
 
-	if len(params) < numParams {
-		return nil, fmt.Errorf("incorrect number of params. Expected %d, received %d", numParams, len(params))
+	if numArgs < cf.requiredParams() {
+		return nil, fmt.Errorf("incorrect number of params. Expected %s, received %d", cf.expectedParams(), numArgs)
+	}
+
+	numFixed := numParams
+
+	if spread && cf.params.variadic {
+		numFixed--
 	}
 
 	channels := []chan formatArgResult{}
 
-	for i := 0; i < numParams; i++ {
+	for i := 0; i < numFixed; i++ {
 
 		fieldType := cf.params.fields[i]
 
@@ -139,14 +720,39 @@ func (cf *ContractFunction) formatArgs(ctx reflect.Value, supplementaryMetadata
 			paramMetadata = &supplementaryMetadata[i]
 		}
 
+		if i >= numArgs {
+			c := make(chan formatArgResult, 1)
+			c <- formatArgResult{index: i}
+			close(c)
+			channels = append(channels, c)
+			continue
+		}
+
 		c := make(chan formatArgResult)
 		go func(i int) {
 			defer close(c)
-			c <- cf.formatArg(params[i], fieldType, paramMetadata, components, serializer)
+			c <- cf.formatArg(i, fieldType, paramMetadata, converter)
 		}(i)
 		channels = append(channels, c)
 	}
 
+	var variadicValue reflect.Value
+
+	if numFixed < numParams {
+		variadicValue = reflect.MakeSlice(cf.params.fields[numFixed], 0, max(numArgs-numFixed, 0))
+		itemType := cf.params.fields[numFixed].Elem()
+		itemMetadata := variadicItemMetadata(supplementaryMetadata, numFixed)
+
+		for i := numFixed; i < numArgs; i++ {
+			c := make(chan formatArgResult)
+			go func(i int) {
+				defer close(c)
+				c <- cf.formatArg(i, itemType, itemMetadata, converter)
+			}(i)
+			channels = append(channels, c)
+		}
+	}
+
 	for _, channel := range channels {
 		for res := range channel {
 
@@ -154,15 +760,47 @@ func (cf *ContractFunction) formatArgs(ctx reflect.Value, supplementaryMetadata
 				return nil, fmt.Errorf("error managing parameter%s. %s", res.paramName, res.err.Error())
 			}
 
-			values = append(values, res.converted)
+			if !res.converted.IsValid() {
+				res.converted = cf.omittedArg(res.index)
+			}
+
+			if res.index >= numFixed {
+				variadicValue = reflect.Append(variadicValue, res.converted)
+			} else {
+				values = append(values, res.converted)
+			}
+		}
+	}
+
+	if numFixed < numParams {
+		if variadicValue.Len() == 0 {
+			variadicValue = cf.omittedArg(numFixed)
 		}
+
+		values = append(values, variadicValue)
 	}
 
 	return values, nil
 }
 
-func (cf *ContractFunction) formatArg(param string, fieldType reflect.Type, parameterMetadata *metadata.ParameterMetadata, components *metadata.ComponentMetadata, serializer serializer.TransactionSerializer) formatArgResult {
-	converted, err := serializer.FromString(param, fieldType, parameterMetadata, components)
+// variadicItemMetadata returns the metadata used to convert each arg passed for a variadic parameter,
+// or nil if the metadata does not describe the items of the parameter
+func variadicItemMetadata(supplementaryMetadata []metadata.ParameterMetadata, index int) *metadata.ParameterMetadata {
+	if supplementaryMetadata == nil || supplementaryMetadata[index].CompiledItemsSchema == nil {
+		return nil
+	}
+
+	param := supplementaryMetadata[index]
+
+	return &metadata.ParameterMetadata{
+		Name:           param.Name,
+		Schema:         param.Schema.Items.Schema,
+		CompiledSchema: param.CompiledItemsSchema,
+	}
+}
+
+func (cf *ContractFunction) formatArg(index int, fieldType reflect.Type, parameterMetadata *metadata.ParameterMetadata, converter argConverter) formatArgResult {
+	converted, err := converter(index, fieldType, parameterMetadata)
 
 	var paramName string
 
@@ -171,6 +809,7 @@ func (cf *ContractFunction) formatArg(param string, fieldType reflect.Type, para
 	}
 
 	return formatArgResult{
+		index:     index,
 		paramName: paramName,
 		converted: converted,
 		err:       err,
@@ -267,6 +906,10 @@ func NewContractFunctionFromReflect(typeMethod reflect.Method, valueMethod refle
 		return nil, err
 	}
 
+	if paramDetails.info {
+		return nil, fmt.Errorf("%s contains invalid parameter type. Only transaction handlers may take the TransactionInfo", typeMethod.Name)
+	}
+
 	return newContractFunction(valueMethod, callType, paramDetails, returnDetails), nil
 }
 
@@ -306,6 +949,27 @@ func methodToContractFunctionParams(typeMethod reflect.Method, contextHandlerTyp
 	for i := startIndex; i < numIn; i++ {
 		inType := typeMethod.Type.In(i)
 
+		if inType == transactionInfoType {
+			if i != numIn-1 {
+				return contractFunctionParams{}, fmt.Errorf("functions requiring the TransactionInfo must require it as the last parameter. %s takes it in as parameter %d", methodName, i-startIndex)
+			}
+
+			myContractFnParams.info = true
+			continue
+		}
+
+		if isTransientType(inType) {
+			if err := typeIsValid(inType.Field(0).Type, nil, false); err != nil {
+				return contractFunctionParams{}, fmt.Errorf("%s contains invalid transient parameter type. %s", methodName, err.Error())
+			}
+
+			myContractFnParams.transient = append(myContractFnParams.transient, transientParam{
+				position: len(myContractFnParams.fields) + len(myContractFnParams.transient),
+				wrapper:  inType,
+			})
+			continue
+		}
+
 		typeError := typeIsValid(inType, nil, false)
 
 		isCtx := inType == contextHandlerType
@@ -334,6 +998,7 @@ func methodToContractFunctionParams(typeMethod reflect.Method, contextHandlerTyp
 	}
 
 	myContractFnParams.context = usesCtx
+	myContractFnParams.variadic = typeMethod.Type.IsVariadic() && !myContractFnParams.info
 	return myContractFnParams, nil
 }
 
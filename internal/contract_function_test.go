@@ -4,12 +4,14 @@
 package internal
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/go-openapi/spec"
+	"github.com/hyperledger/fabric-contract-api-go/v2/contractapi/utils"
 	metadata "github.com/hyperledger/fabric-contract-api-go/v2/metadata"
 	"github.com/hyperledger/fabric-contract-api-go/v2/serializer"
 	"github.com/stretchr/testify/assert"
@@ -63,6 +65,14 @@ func (ss *simpleStruct) GoodMethodNoReturn(param1 string, param2 string) {
 	// do nothing
 }
 
+func (ss *simpleStruct) VariadicMethod(param1 string, param2 ...string) string {
+	for _, str := range param2 {
+		param1 += str
+	}
+
+	return param1
+}
+
 func (ss *simpleStruct) BadMethod(param1 complex64) complex64 {
 	return param1
 }
@@ -91,6 +101,26 @@ func (ss *simpleStruct) BadMethodSecondReturn(param1 string) (string, string) {
 	return param1, param1
 }
 
+func (ss *simpleStruct) InfoMethod(param1 string, info *utils.TransactionInfo) string {
+	return param1
+}
+
+func (ss *simpleStruct) BadInfoMethod(info *utils.TransactionInfo, param1 string) string {
+	return param1
+}
+
+func (ss *simpleStruct) TransientMethod(param1 string, secret utils.Transient[string], param2 string) string {
+	return param1 + secret.Value + param2
+}
+
+func (ss *simpleStruct) BadTransientMethod(secret utils.Transient[complex64]) string {
+	return ""
+}
+
+type namedString string
+
+type namedInt int
+
 type mockSerializer struct{}
 
 func (ms *mockSerializer) FromString(string, reflect.Type, *metadata.ParameterMetadata, *metadata.ComponentMetadata) (reflect.Value, error) {
@@ -325,6 +355,55 @@ func TestMethodToContractFunctionParams(t *testing.T) {
 			reflect.TypeOf(""),
 		},
 	}, params, "should return params without context when none specified for method from function")
+
+	variadicMethod, _ := getMethodByName(new(simpleStruct), "VariadicMethod")
+	params, err = methodToContractFunctionParams(variadicMethod, ctx)
+	require.NoError(t, err, "should not error for variadic function")
+	assert.Equal(t, contractFunctionParams{
+		fields: []reflect.Type{
+			reflect.TypeOf(""),
+			reflect.TypeOf([]string{}),
+		},
+		variadic: true,
+	}, params, "should return params of variadic function with slice for variadic param")
+
+	transientMethod, _ := getMethodByName(new(simpleStruct), "TransientMethod")
+	params, err = methodToContractFunctionParams(transientMethod, ctx)
+	require.NoError(t, err, "should not error for function with transient param")
+	assert.Equal(t, contractFunctionParams{
+		fields: []reflect.Type{
+			reflect.TypeOf(""),
+			reflect.TypeOf(""),
+		},
+		transient: []transientParam{
+			{position: 1, wrapper: reflect.TypeOf(utils.Transient[string]{})},
+		},
+	}, params, "should return transient param with its position separately from fields")
+
+	badTransientMethod, _ := getMethodByName(new(simpleStruct), "BadTransientMethod")
+	validTypeErr = typeIsValid(reflect.TypeOf(complex64(1)), []reflect.Type{}, false)
+	_, err = methodToContractFunctionParams(badTransientMethod, ctx)
+	require.EqualError(t, err, fmt.Sprintf("BadTransientMethod contains invalid transient parameter type. %s", validTypeErr.Error()), "should error when type of transient value is not valid")
+}
+
+func TestMethodToContractFunctionParamsInfo(t *testing.T) {
+	ctx := reflect.TypeOf(new(TransactionContext))
+
+	infoMethod, _ := getMethodByName(new(simpleStruct), "InfoMethod")
+	params, err := methodToContractFunctionParams(infoMethod, ctx)
+	require.NoError(t, err, "should not error when transaction info is last param")
+	assert.Equal(t, contractFunctionParams{
+		fields: []reflect.Type{reflect.TypeOf("")},
+		info:   true,
+	}, params, "should mark params as taking info without including it in fields")
+
+	badInfoMethod, _ := getMethodByName(new(simpleStruct), "BadInfoMethod")
+	_, err = methodToContractFunctionParams(badInfoMethod, ctx)
+	require.EqualError(t, err, "functions requiring the TransactionInfo must require it as the last parameter. BadInfoMethod takes it in as parameter 0", "should error when transaction info not last param")
+
+	infoMethodValue := reflect.ValueOf(new(simpleStruct)).MethodByName("InfoMethod")
+	_, err = NewContractFunctionFromReflect(infoMethod, infoMethodValue, CallTypeSubmit, ctx)
+	require.EqualError(t, err, "InfoMethod contains invalid parameter type. Only transaction handlers may take the TransactionInfo", "should error when contract function takes transaction info")
 }
 
 func TestMethodToContractFunctionReturns(t *testing.T) {
@@ -420,6 +499,10 @@ func TestNewContractFunction(t *testing.T) {
 	params := contractFunctionParams{
 		nil,
 		[]reflect.Type{reflect.TypeOf("")},
+		false,
+		nil,
+		false,
+		nil,
 	}
 
 	returns := contractFunctionReturns{
@@ -486,6 +569,499 @@ func TestNewContractFunctionFromReflect(t *testing.T) {
 	assert.Equal(t, expectedCf, cf, "should return contract function for good method from reflect")
 }
 
+func TestCallTypeString(t *testing.T) {
+	assert.Equal(t, "SUBMIT", CallType(CallTypeSubmit).String(), "should return submit tag")
+	assert.Equal(t, "EVALUATE", CallType(CallTypeEvaluate).String(), "should return evaluate tag")
+	assert.Equal(t, "", CallType(CallTypeNA).String(), "should return blank for non callable")
+}
+
+func TestGetCallType(t *testing.T) {
+	cf := ContractFunction{callType: CallTypeEvaluate}
+	assert.Equal(t, CallType(CallTypeEvaluate), cf.GetCallType(), "should return call type")
+}
+
+func TestDecodeArgs(t *testing.T) {
+	serializer := new(serializer.JSONSerializer)
+
+	testCf := ContractFunction{
+		params: contractFunctionParams{
+			context: basicContextPtrType,
+			fields:  []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(1)},
+		},
+	}
+
+	args, err := testCf.DecodeArgs(nil, nil, serializer, "hello", "NaN")
+	require.Error(t, err, "should error when args cannot be converted")
+	assert.Nil(t, args, "should not return args on error")
+
+	args, err = testCf.DecodeArgs(nil, nil, serializer, "hello", "1")
+	require.NoError(t, err, "should not error for valid args")
+	assert.Equal(t, []interface{}{"hello", 1}, args, "should return converted args without context")
+
+	schema := metadata.TransactionMetadata{Parameters: []metadata.ParameterMetadata{{Name: "one"}}}
+	_, err = testCf.DecodeArgs(&schema, nil, serializer, "hello", "1")
+	require.EqualError(t, err, "incorrect number of params in supplementary metadata. Expected 2, received 1", "should use supplementary metadata")
+}
+
+func TestSetDefaultArgs(t *testing.T) {
+	var err error
+
+	testCf := ContractFunction{
+		params: contractFunctionParams{
+			fields: []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(1)},
+		},
+	}
+
+	err = testCf.SetDefaultArgs("a", 1, 2)
+	require.EqualError(t, err, "too many default args. Expected at most 2, received 3", "should error when more defaults than params")
+
+	err = testCf.SetDefaultArgs("a")
+	require.EqualError(t, err, "default arg 1 of type string cannot be used for parameter of type int", "should error when default is of wrong type")
+
+	err = testCf.SetDefaultArgs(namedInt(1))
+	require.NoError(t, err, "should convert defaults of convertible types")
+	require.Len(t, testCf.params.defaults, 1, "should set defaults of last params")
+	assert.Equal(t, 1, testCf.params.defaults[0].Interface(), "should set defaults of last params")
+
+	err = testCf.SetDefaultArgs(nil, nil)
+	require.NoError(t, err, "should allow nil defaults")
+	assert.Equal(t, "", testCf.params.defaults[0].Interface(), "should use zero value for nil default")
+	assert.Equal(t, 0, testCf.params.defaults[1].Interface(), "should use zero value for nil default")
+}
+
+func TestCheckArgCount(t *testing.T) {
+	testCf := ContractFunction{
+		params: contractFunctionParams{
+			fields: []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(1)},
+		},
+	}
+
+	assert.NoError(t, testCf.CheckArgCount(2), "should not error for number of params")
+	assert.EqualError(t, testCf.CheckArgCount(1), "incorrect number of params. Expected 2, received 1", "should error for too few args")
+	assert.EqualError(t, testCf.CheckArgCount(3), "incorrect number of params. Expected 2, received 3", "should error for too many args")
+
+	err := testCf.SetDefaultArgs(1)
+	require.NoError(t, err)
+
+	assert.NoError(t, testCf.CheckArgCount(1), "should not error when omitting param with default")
+	assert.EqualError(t, testCf.CheckArgCount(0), "incorrect number of params. Expected 1 to 2, received 0", "should error for too few args with range")
+	assert.EqualError(t, testCf.CheckArgCount(3), "incorrect number of params. Expected 1 to 2, received 3", "should error for too many args with range")
+
+	pointerCf := ContractFunction{
+		params: contractFunctionParams{
+			fields: []reflect.Type{reflect.TypeOf(new(goodStruct)), reflect.TypeOf(1), reflect.TypeOf(new(goodStruct))},
+		},
+	}
+
+	assert.NoError(t, pointerCf.CheckArgCount(2), "should not error when omitting trailing pointer param")
+	assert.EqualError(t, pointerCf.CheckArgCount(1), "incorrect number of params. Expected 2 to 3, received 1", "should require pointer params followed by required params")
+}
+
+func TestDecodeArgsOptionalParams(t *testing.T) {
+	serializer := new(serializer.JSONSerializer)
+
+	testCf := ContractFunction{
+		params: contractFunctionParams{
+			fields: []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(1), reflect.TypeOf(new(goodStruct))},
+		},
+	}
+
+	err := testCf.SetDefaultArgs(5, nil)
+	require.NoError(t, err)
+
+	args, err := testCf.DecodeArgs(nil, nil, serializer, "a")
+	require.NoError(t, err, "should not error when omitting optional params")
+	assert.Equal(t, []interface{}{"a", 5, (*goodStruct)(nil)}, args, "should use defaults for omitted params")
+
+	args, err = testCf.DecodeArgs(nil, nil, serializer, "a", "1", `{"prop2":2}`)
+	require.NoError(t, err, "should not error when passing optional params")
+	assert.Equal(t, []interface{}{"a", 1, &goodStruct{Prop2: 2}}, args, "should use passed values over defaults")
+
+	args, err = testCf.DecodeArgs(nil, nil, serializer, "a", "1", `{"prop2":2}`, "3")
+	require.NoError(t, err, "should not error for surplus args")
+	assert.Equal(t, []interface{}{"a", 1, &goodStruct{Prop2: 2}}, args, "should ignore surplus args")
+
+	_, err = testCf.DecodeArgs(nil, nil, serializer)
+	require.EqualError(t, err, "incorrect number of params. Expected 1 to 3, received 0", "should error when required param omitted")
+
+	pointerCf := ContractFunction{
+		params: contractFunctionParams{
+			fields: []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(new(goodStruct))},
+		},
+	}
+
+	args, err = pointerCf.DecodeBytesArgs(nil, nil, serializer, []byte("a"))
+	require.NoError(t, err, "should not error when omitting trailing pointer param")
+	assert.Equal(t, []interface{}{"a", (*goodStruct)(nil)}, args, "should use nil for omitted pointer param")
+}
+
+func TestDecodeNamedArgs(t *testing.T) {
+	var args []interface{}
+	var err error
+
+	serializer := new(serializer.JSONSerializer)
+
+	testCf := ContractFunction{
+		params: contractFunctionParams{
+			context: basicContextPtrType,
+			fields:  []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(1), reflect.TypeOf(new(goodStruct))},
+		},
+	}
+
+	err = testCf.SetDefaultArgs(5, nil)
+	require.NoError(t, err)
+
+	txMetadata := &metadata.TransactionMetadata{
+		Parameters: []metadata.ParameterMetadata{
+			{Name: "id", Schema: spec.StringProperty(), CompiledSchema: createGoJSONSchemaSchema("id", spec.StringProperty(), nil)},
+			{Name: "amount", Schema: spec.Int64Property(), CompiledSchema: createGoJSONSchemaSchema("amount", spec.Int64Property(), nil)},
+			{Name: "asset", Schema: new(spec.Schema), CompiledSchema: createGoJSONSchemaSchema("asset", new(spec.Schema), nil)},
+		},
+	}
+
+	_, err = testCf.DecodeNamedArgs(nil, nil, serializer, map[string]json.RawMessage{"id": json.RawMessage(`"a"`)})
+	require.EqualError(t, err, "named args require the metadata of the function", "should error without metadata")
+
+	_, err = testCf.DecodeNamedArgs(txMetadata, nil, serializer, map[string]json.RawMessage{"id": json.RawMessage(`"a"`), "value": json.RawMessage("1")})
+	require.EqualError(t, err, "named args include value which is not a parameter of the function", "should error for unknown names")
+
+	_, err = testCf.DecodeNamedArgs(txMetadata, nil, serializer, map[string]json.RawMessage{"amount": json.RawMessage("1")})
+	require.EqualError(t, err, "error managing parameter id. named args do not include the parameter", "should error when required param omitted")
+
+	_, err = testCf.DecodeNamedArgs(txMetadata, nil, serializer, map[string]json.RawMessage{"id": json.RawMessage("null")})
+	require.EqualError(t, err, "error managing parameter id. named args do not include the parameter", "should error when required param null")
+
+	_, err = testCf.DecodeNamedArgs(txMetadata, nil, serializer, map[string]json.RawMessage{"id": json.RawMessage("5")})
+	require.EqualError(t, err, "error managing parameter id. value is a JSON number but the parameter is of type string", "should error when arg is not of the JSON type of the parameter")
+
+	_, err = testCf.DecodeNamedArgs(txMetadata, nil, serializer, map[string]json.RawMessage{"id": json.RawMessage(`"a"`), "amount": json.RawMessage(`"1"`)})
+	require.EqualError(t, err, "error managing parameter amount. value is a JSON string but the parameter is of type integer", "should error when number passed as string")
+
+	_, err = testCf.DecodeNamedArgs(txMetadata, nil, serializer, map[string]json.RawMessage{"id": json.RawMessage(`"a"`), "amount": json.RawMessage("1.5")})
+	require.Error(t, err, "should error when arg cannot be converted")
+
+	args, err = testCf.DecodeNamedArgs(txMetadata, nil, serializer, map[string]json.RawMessage{"asset": json.RawMessage(`{"prop2":2}`), "id": json.RawMessage(`"a"`), "amount": json.RawMessage("null")})
+	require.NoError(t, err, "should not error when omitting optional params before passed params")
+	assert.Equal(t, []interface{}{"a", 5, &goodStruct{Prop2: 2}}, args, "should convert args by name using defaults for omitted params")
+}
+
+func TestCheckJSONType(t *testing.T) {
+	assert.NoError(t, checkJSONType(json.RawMessage("5"), nil), "should allow any value without schema")
+	assert.NoError(t, checkJSONType(json.RawMessage("5"), new(spec.Schema)), "should allow any value for schema without type")
+	assert.NoError(t, checkJSONType(json.RawMessage(" 5"), spec.Int64Property()), "should allow number for integer")
+	assert.NoError(t, checkJSONType(json.RawMessage("true"), spec.BoolProperty()))
+	assert.NoError(t, checkJSONType(json.RawMessage(`["a"]`), spec.ArrayProperty(spec.StringProperty())))
+	assert.NoError(t, checkJSONType(json.RawMessage(`{}`), spec.RefSchema("#/components/schemas/goodStruct")), "should allow object for reference")
+	assert.NoError(t, checkJSONType(json.RawMessage(`"a"`), &spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"number", "string"}}}))
+	assert.EqualError(t, checkJSONType(json.RawMessage(`"a"`), spec.RefSchema("goodStruct")), "value is a JSON string but the parameter is of type object")
+	assert.EqualError(t, checkJSONType(json.RawMessage("1"), spec.BoolProperty()), "value is a JSON number but the parameter is of type boolean")
+	assert.EqualError(t, checkJSONType(json.RawMessage("true"), &spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string", "integer"}}}), "value is a JSON boolean but the parameter is of type string or integer")
+}
+
+func TestDecodeArgsVariadic(t *testing.T) {
+	var args []interface{}
+	var err error
+
+	serializer := new(serializer.JSONSerializer)
+
+	testCf := ContractFunction{
+		params: contractFunctionParams{
+			fields:   []reflect.Type{reflect.TypeOf(""), reflect.TypeOf([]int{})},
+			variadic: true,
+		},
+	}
+
+	args, err = testCf.DecodeArgs(nil, nil, serializer, "a", "1", "2", "3")
+	require.NoError(t, err, "should not error for variadic args")
+	assert.Equal(t, []interface{}{"a", []int{1, 2, 3}}, args, "should convert each variadic arg to element type")
+
+	args, err = testCf.DecodeArgs(nil, nil, serializer, "a")
+	require.NoError(t, err, "should not error when no variadic args passed")
+	assert.Equal(t, []interface{}{"a", []int(nil)}, args, "should use nil slice when no variadic args passed")
+
+	_, err = testCf.DecodeArgs(nil, nil, serializer)
+	require.EqualError(t, err, "incorrect number of params. Expected at least 1, received 0", "should error when too few args")
+
+	_, err = testCf.DecodeArgs(nil, nil, serializer, "a", "1", "NaN")
+	require.Error(t, err, "should error when variadic arg cannot be converted")
+
+	txMetadata := &metadata.TransactionMetadata{
+		Parameters: []metadata.ParameterMetadata{
+			{Name: "id", Schema: spec.StringProperty(), CompiledSchema: createGoJSONSchemaSchema("id", spec.StringProperty(), nil)},
+			{
+				Name:                "values",
+				Variadic:            true,
+				Schema:              spec.ArrayProperty(spec.Int64Property().WithMaximum(2, false)),
+				CompiledSchema:      createGoJSONSchemaSchema("values", spec.ArrayProperty(spec.Int64Property().WithMaximum(2, false)), nil),
+				CompiledItemsSchema: createGoJSONSchemaSchema("values", spec.Int64Property().WithMaximum(2, false), nil),
+			},
+		},
+	}
+
+	args, err = testCf.DecodeArgs(txMetadata, nil, serializer, "a", "1", "2")
+	require.NoError(t, err, "should not error for variadic args matching item schema")
+	assert.Equal(t, []interface{}{"a", []int{1, 2}}, args, "should convert variadic args using metadata")
+
+	_, err = testCf.DecodeArgs(txMetadata, nil, serializer, "a", "1", "3")
+	require.EqualError(t, err, "error managing parameter values. value did not match schema:\n1. values: Must be less than or equal to 2", "should validate each variadic arg against item schema")
+
+	args, err = testCf.DecodeNamedArgs(txMetadata, nil, serializer, map[string]json.RawMessage{"id": json.RawMessage(`"a"`), "values": json.RawMessage("[1,2]")})
+	require.NoError(t, err, "should not error for named variadic arg")
+	assert.Equal(t, []interface{}{"a", []int{1, 2}}, args, "should convert named variadic arg as slice")
+
+	err = testCf.SetDefaultArgs([]int{5})
+	require.NoError(t, err)
+
+	args, err = testCf.DecodeArgs(nil, nil, serializer, "a")
+	require.NoError(t, err, "should not error when no variadic args passed")
+	assert.Equal(t, []interface{}{"a", []int{5}}, args, "should use default when no variadic args passed")
+}
+
+func TestDecodeTransientArgs(t *testing.T) {
+	var args []interface{}
+	var err error
+
+	serializer := new(serializer.JSONSerializer)
+
+	testCf := ContractFunction{
+		params: contractFunctionParams{
+			fields: []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(1)},
+			transient: []transientParam{
+				{position: 0, wrapper: reflect.TypeOf(utils.Transient[goodStruct]{})},
+				{position: 2, wrapper: reflect.TypeOf(utils.Transient[*goodStruct]{})},
+			},
+		},
+	}
+
+	assert.True(t, testCf.TakesTransient(), "should take transient when function has transient params")
+	assert.Equal(t, []int{0, 2}, testCf.TransientPositions(), "should return positions of transient params")
+
+	_, err = testCf.DecodeTransientArgs(nil, nil, serializer, nil, []interface{}{"a", 1})
+	require.EqualError(t, err, "transient args require the metadata of the function", "should error without metadata")
+
+	components := new(metadata.ComponentMetadata)
+	reflected := testCf.ReflectMetadata("some tx", components)
+	txMetadata := &reflected
+
+	for i, name := range []string{"first", "second"} {
+		txMetadata.Transient[i].Name = name
+		txMetadata.Transient[i].CompiledSchema = createGoJSONSchemaSchema(name, txMetadata.Transient[i].Schema, components)
+	}
+
+	_, err = testCf.DecodeTransientArgs(&metadata.TransactionMetadata{Transient: txMetadata.Transient[:1]}, components, serializer, nil, []interface{}{"a", 1})
+	require.EqualError(t, err, "incorrect number of transient params in supplementary metadata. Expected 2, received 1", "should error when metadata does not match")
+
+	_, err = testCf.DecodeTransientArgs(txMetadata, components, serializer, map[string][]byte{}, []interface{}{"a", 1})
+	require.EqualError(t, err, "error managing transient parameter first. transient data does not include the parameter", "should error when transient data is missing value type param")
+
+	_, err = testCf.DecodeTransientArgs(txMetadata, components, serializer, map[string][]byte{"first": []byte("bad")}, []interface{}{"a", 1})
+	require.ErrorContains(t, err, "error managing transient parameter first. ", "should error when transient value cannot be converted")
+
+	args, err = testCf.DecodeTransientArgs(txMetadata, components, serializer, map[string][]byte{"first": []byte(`{"Prop1":"b","prop2":2}`)}, []interface{}{"a", 1})
+	require.NoError(t, err, "should not error when pointer param omitted")
+	assert.Equal(t, []interface{}{utils.Transient[goodStruct]{Value: goodStruct{Prop1: "b", Prop2: 2}}, "a", utils.Transient[*goodStruct]{}, 1}, args, "should insert transient args in positions of their params")
+
+	args, err = testCf.DecodeTransientArgs(txMetadata, components, serializer, map[string][]byte{"first": []byte(`{"Prop1":"b","prop2":2}`), "second": []byte(`{"Prop1":"c","prop2":3}`)}, []interface{}{"a", 1})
+	require.NoError(t, err)
+	assert.Equal(t, utils.Transient[*goodStruct]{Value: &goodStruct{Prop1: "c", Prop2: 3}}, args[2], "should convert pointer transient param")
+
+	noTransientCf := ContractFunction{params: contractFunctionParams{fields: []reflect.Type{reflect.TypeOf("")}}}
+	assert.False(t, noTransientCf.TakesTransient(), "should not take transient when function has no transient params")
+	args, err = noTransientCf.DecodeTransientArgs(nil, nil, serializer, nil, []interface{}{"a"})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a"}, args, "should return args unchanged for function without transient params")
+}
+
+func TestDecodeBytesArgs(t *testing.T) {
+	serializer := new(serializer.JSONSerializer)
+
+	testCf := ContractFunction{
+		params: contractFunctionParams{
+			context: basicContextPtrType,
+			fields:  []reflect.Type{reflect.TypeOf([]byte{}), reflect.TypeOf(1)},
+		},
+	}
+
+	args, err := testCf.DecodeBytesArgs(nil, nil, serializer, []byte("hello"), []byte("NaN"))
+	require.Error(t, err, "should error when args cannot be converted")
+	assert.Nil(t, args, "should not return args on error")
+
+	_, err = testCf.DecodeBytesArgs(nil, nil, serializer, []byte("hello"))
+	require.EqualError(t, err, "incorrect number of params. Expected 2, received 1", "should error when too few args")
+
+	args, err = testCf.DecodeBytesArgs(nil, nil, serializer, []byte("hello"), []byte("1"))
+	require.NoError(t, err, "should not error for valid args")
+	assert.Equal(t, []interface{}{[]byte("hello"), 1}, args, "should return converted args without context")
+}
+
+func TestCallWithArgs(t *testing.T) {
+	var actualStr string
+	var actualIface interface{}
+	var err error
+
+	ctx := reflect.ValueOf(TransactionContext{})
+	serializer := new(serializer.JSONSerializer)
+
+	testCf := ContractFunction{
+		function: reflect.ValueOf(new(simpleStruct).GoodMethod),
+		params: contractFunctionParams{
+			fields: []reflect.Type{reflect.TypeOf(""), reflect.TypeOf("")},
+		},
+		returns: contractFunctionReturns{
+			success: reflect.TypeOf(""),
+		},
+	}
+
+	_, _, err = testCf.CallWithArgs(ctx, nil, nil, serializer, "hello")
+	require.EqualError(t, err, "incorrect number of args. Expected 2, received 1", "should error when wrong number of args")
+
+	variadicCf := ContractFunction{
+		function: reflect.ValueOf(new(simpleStruct).VariadicMethod),
+		params: contractFunctionParams{
+			fields:   []reflect.Type{reflect.TypeOf(""), reflect.TypeOf([]string{})},
+			variadic: true,
+		},
+		returns: contractFunctionReturns{
+			success: reflect.TypeOf(""),
+		},
+	}
+
+	actualStr, _, err = variadicCf.CallWithArgs(ctx, nil, nil, serializer, "a", []string{"b", "c"})
+	require.NoError(t, err, "should call variadic function")
+	assert.Equal(t, "abc", actualStr, "should pass slice as variadic args")
+
+	_, _, err = testCf.CallWithArgs(ctx, nil, nil, serializer, "hello", 1)
+	require.EqualError(t, err, "arg 1 of type int cannot be used for parameter of type string", "should error when arg is of wrong type")
+
+	actualStr, actualIface, err = testCf.CallWithArgs(ctx, nil, nil, serializer, "hello", namedString("world"))
+	require.NoError(t, err, "should convert args of convertible types")
+	assert.Equal(t, "helloworld", actualStr, "should return formatted response")
+	assert.Equal(t, "helloworld", actualIface, "should return response value")
+
+	actualStr, _, err = testCf.CallWithArgs(ctx, nil, nil, serializer, "hello", nil)
+	require.NoError(t, err, "should use zero value for nil args")
+	assert.Equal(t, "hello", actualStr, "should pass zero value")
+
+	ctxCf := ContractFunction{
+		function: reflect.ValueOf(new(simpleStruct).GoodTransactionMethod),
+		params: contractFunctionParams{
+			context: basicContextPtrType,
+			fields:  []reflect.Type{reflect.TypeOf(""), reflect.TypeOf("")},
+		},
+		returns: contractFunctionReturns{
+			success: reflect.TypeOf(""),
+		},
+	}
+
+	actualStr, _, err = ctxCf.CallWithArgs(reflect.ValueOf(new(TransactionContext)), nil, nil, serializer, "hello", "world")
+	require.NoError(t, err, "should pass context")
+	assert.Equal(t, "helloworld", actualStr, "should return formatted response when passing context")
+}
+
+func TestExecute(t *testing.T) {
+	testCf := ContractFunction{
+		function: reflect.ValueOf(func(param1 string) (string, error) {
+			return param1, errors.New("some error")
+		}),
+		params: contractFunctionParams{
+			fields: []reflect.Type{reflect.TypeOf("")},
+		},
+		returns: contractFunctionReturns{
+			success: reflect.TypeOf(""),
+			error:   true,
+		},
+	}
+
+	_, err := testCf.Execute(reflect.Value{})
+	require.EqualError(t, err, "incorrect number of args. Expected 1, received 0", "should error when args do not match params")
+
+	result, err := testCf.Execute(reflect.Value{}, "some value")
+	require.EqualError(t, err, "some error", "should return error of function")
+	assert.Equal(t, "some value", result, "should return unformatted value of function")
+}
+
+func TestFormatReturn(t *testing.T) {
+	var str string
+	var err error
+
+	serializer := new(serializer.JSONSerializer)
+
+	testCf := ContractFunction{
+		returns: contractFunctionReturns{
+			success: reflect.TypeOf(1),
+		},
+	}
+
+	str, err = testCf.FormatReturn(nil, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, str, "should not format without serializer")
+
+	str, err = (&ContractFunction{}).FormatReturn("some value", nil, nil, serializer)
+	require.NoError(t, err)
+	assert.Empty(t, str, "should not format when function has no success return")
+
+	str, err = testCf.FormatReturn(10, nil, nil, serializer)
+	require.NoError(t, err)
+	assert.Equal(t, "10", str, "should format value")
+
+	str, err = testCf.FormatReturn(nil, nil, nil, serializer)
+	require.NoError(t, err)
+	assert.Equal(t, "0", str, "should format zero value when nil")
+
+	str, err = testCf.FormatReturn(namedInt(10), nil, nil, serializer)
+	require.NoError(t, err)
+	assert.Equal(t, "10", str, "should format value convertible to return type")
+
+	_, err = testCf.FormatReturn("10", nil, nil, serializer)
+	require.EqualError(t, err, "error handling success response. Value of type string cannot be used for return type int", "should error for value of wrong type")
+
+	_, err = testCf.FormatReturn(10, nil, nil, new(mockSerializer))
+	require.EqualError(t, err, "error handling success response. Serializer error", "should error when serializer errors")
+
+	ifaceCf := ContractFunction{
+		returns: contractFunctionReturns{
+			success: reflect.TypeOf((*interface{})(nil)).Elem(),
+		},
+	}
+
+	str, err = ifaceCf.FormatReturn("some value", nil, nil, serializer)
+	require.NoError(t, err)
+	assert.Equal(t, "some value", str, "should format value for interface return")
+}
+
+func TestFormatReturnBytes(t *testing.T) {
+	var bytes []byte
+	var err error
+
+	serializer := new(serializer.JSONSerializer)
+
+	testCf := ContractFunction{
+		returns: contractFunctionReturns{
+			success: reflect.TypeOf(1),
+		},
+	}
+
+	bytes, err = testCf.FormatReturnBytes(nil, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, bytes, "should not format without serializer")
+
+	bytes, err = testCf.FormatReturnBytes(10, nil, nil, serializer)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("10"), bytes, "should format value")
+
+	_, err = testCf.FormatReturnBytes("10", nil, nil, serializer)
+	require.EqualError(t, err, "error handling success response. Value of type string cannot be used for return type int", "should error for value of wrong type")
+
+	float := float64(20)
+	schema := spec.Int64Property()
+	schema.Minimum = &float
+	txMetadata := metadata.TransactionMetadata{Returns: metadata.ReturnMetadata{Schema: schema}}
+	ccMetadata := metadata.ContractChaincodeMetadata{Contracts: map[string]metadata.ContractMetadata{"contract": {Transactions: []metadata.TransactionMetadata{txMetadata}}}}
+	require.NoError(t, ccMetadata.CompileSchemas())
+	_, err = testCf.FormatReturnBytes(10, &ccMetadata.Contracts["contract"].Transactions[0], nil, serializer)
+	require.ErrorContains(t, err, "error handling success response. value did not match schema", "should error when serializer errors")
+}
+
 func TestReflectMetadata(t *testing.T) {
 	var txMetadata metadata.TransactionMetadata
 
@@ -493,6 +1069,10 @@ func TestReflectMetadata(t *testing.T) {
 		params: contractFunctionParams{
 			nil,
 			[]reflect.Type{reflect.TypeOf(""), reflect.TypeOf(true)},
+			false,
+			nil,
+			false,
+			nil,
 		},
 		returns: contractFunctionReturns{
 			success: reflect.TypeOf(1),
@@ -517,6 +1097,57 @@ func TestReflectMetadata(t *testing.T) {
 	assert.Equal(t, expectedMetadata, txMetadata, "should return metadata for evaluate transaction")
 }
 
+func TestReflectMetadataOptionalParams(t *testing.T) {
+	testCf := ContractFunction{
+		params: contractFunctionParams{
+			fields: []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(1), reflect.TypeOf(new(goodStruct))},
+		},
+	}
+
+	err := testCf.SetDefaultArgs(2, nil)
+	require.NoError(t, err)
+
+	txMetadata := testCf.ReflectMetadata("some tx", new(metadata.ComponentMetadata))
+	notRequired := false
+	expectedParameters := []metadata.ParameterMetadata{
+		{Name: "param0", Schema: spec.StringProperty()},
+		{Name: "param1", Schema: spec.Int64Property(), Required: &notRequired},
+		{Name: "param2", Schema: spec.RefSchema("#/components/schemas/goodStruct"), Required: &notRequired},
+	}
+	assert.Equal(t, expectedParameters, txMetadata.Parameters, "should record trailing parameters with defaults or of pointer types as not required")
+
+	variadicCf := ContractFunction{
+		params: contractFunctionParams{
+			fields:   []reflect.Type{reflect.TypeOf(""), reflect.TypeOf([]bool{})},
+			variadic: true,
+		},
+	}
+
+	txMetadata = variadicCf.ReflectMetadata("some tx", nil)
+	expectedParameters = []metadata.ParameterMetadata{
+		{Name: "param0", Schema: spec.StringProperty()},
+		{Name: "param1", Schema: spec.ArrayProperty(spec.BoolProperty()), Required: &notRequired, Variadic: true},
+	}
+	assert.Equal(t, expectedParameters, txMetadata.Parameters, "should record variadic parameter as not required array")
+
+	transientCf := ContractFunction{
+		params: contractFunctionParams{
+			fields: []reflect.Type{reflect.TypeOf("")},
+			transient: []transientParam{
+				{position: 0, wrapper: reflect.TypeOf(utils.Transient[string]{})},
+				{position: 2, wrapper: reflect.TypeOf(utils.Transient[*goodStruct]{})},
+			},
+		},
+	}
+
+	txMetadata = transientCf.ReflectMetadata("some tx", new(metadata.ComponentMetadata))
+	assert.Equal(t, []metadata.ParameterMetadata{{Name: "param0", Schema: spec.StringProperty()}}, txMetadata.Parameters, "should not record transient params as parameters")
+	assert.Equal(t, []metadata.ParameterMetadata{
+		{Name: "transient0", Schema: spec.StringProperty()},
+		{Name: "transient1", Schema: spec.RefSchema("#/components/schemas/goodStruct"), Required: &notRequired},
+	}, txMetadata.Transient, "should record transient params, those of pointer types as not required")
+}
+
 func TestCall(t *testing.T) {
 	var expectedStr string
 	var expectedIface interface{}
@@ -532,6 +1163,10 @@ func TestCall(t *testing.T) {
 		params: contractFunctionParams{
 			nil,
 			[]reflect.Type{reflect.TypeOf(""), reflect.TypeOf("")},
+			false,
+			nil,
+			false,
+			nil,
 		},
 		returns: contractFunctionReturns{
 			success: reflect.TypeOf(""),
@@ -46,6 +46,11 @@ type TransactionHandler struct {
 
 // Call calls tranaction function using string args and handles formatting the response into useful types
 func (th TransactionHandler) Call(ctx reflect.Value, data interface{}, serializer serializer.TransactionSerializer) (string, interface{}, error) {
+	return th.CallWithInfo(ctx, data, nil, serializer)
+}
+
+// CallWithInfo calls tranaction function passing the transaction info if the function takes it
+func (th TransactionHandler) CallWithInfo(ctx reflect.Value, data interface{}, info *utils.TransactionInfo, serializer serializer.TransactionSerializer) (string, interface{}, error) {
 	values := []reflect.Value{}
 
 	if th.params.context != nil {
@@ -60,11 +65,20 @@ func (th TransactionHandler) Call(ctx reflect.Value, data interface{}, serialize
 		}
 	}
 
+	if th.params.info {
+		values = append(values, reflect.ValueOf(info))
+	}
+
 	someResp := th.function.Call(values)
 
 	return th.handleResponse(someResp, nil, nil, serializer)
 }
 
+// TakesInfo returns whether the handler takes the transaction info
+func (th TransactionHandler) TakesInfo() bool {
+	return th.params.info
+}
+
 // NewTransactionHandler create a new transaction handler from a given function
 func NewTransactionHandler(fn interface{}, contextHandlerType reflect.Type, handlesType TransactionHandlerType) (*TransactionHandler, error) {
 	cf, err := NewContractFunctionFromFunc(fn, 0, contextHandlerType)
@@ -72,6 +86,9 @@ func NewTransactionHandler(fn interface{}, contextHandlerType reflect.Type, hand
 	if err != nil {
 		str, _ := handlesType.String()
 		return nil, fmt.Errorf("error creating %s. %s", str, err.Error())
+	} else if len(cf.params.transient) > 0 {
+		str, _ := handlesType.String()
+		return nil, fmt.Errorf("%s transactions may not take transient params", str)
 	} else if handlesType != TransactionHandlerTypeAfter && len(cf.params.fields) > 0 {
 		str, _ := handlesType.String()
 		return nil, fmt.Errorf("%s transactions may not take any params other than the transaction context", str)
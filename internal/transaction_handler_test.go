@@ -45,6 +45,18 @@ func (ms *transactionHandlerStruct) GoodAfterFunctionForUndefinedInterface(iface
 	return ok
 }
 
+func (ms *transactionHandlerStruct) GoodBeforeFunctionWithInfo(ctx *TransactionContext, info *utils.TransactionInfo) string {
+	return ctx.Str + " " + info.FunctionName
+}
+
+func (ms *transactionHandlerStruct) GoodAfterFunctionWithInfo(iface interface{}, info *utils.TransactionInfo) string {
+	return fmt.Sprintf("%v %v", iface, info.Result)
+}
+
+func (ms *transactionHandlerStruct) TransientFunction(secret utils.Transient[string]) string {
+	return secret.Value
+}
+
 func (ms *transactionHandlerStruct) BadFunction(param1 complex64) complex64 {
 	return param1
 }
@@ -99,6 +111,9 @@ func TestNewTransactionHandler(t *testing.T) {
 	_, err = NewTransactionHandler(ms.BasicFunction, basicContextPtrType, TransactionHandlerTypeAfter)
 	require.EqualError(t, err, "after transaction must take type interface{} as their only non-context param", "should error when after function takes correct number of non-context args but not interface type")
 
+	_, err = NewTransactionHandler(ms.TransientFunction, basicContextPtrType, TransactionHandlerTypeBefore)
+	require.EqualError(t, err, "Before transactions may not take transient params", "should error when function takes transient params")
+
 	_, expectedErr := NewContractFunctionFromFunc(ms.BadFunction, 0, basicContextPtrType)
 	_, err = NewTransactionHandler(ms.BadFunction, basicContextPtrType, TransactionHandlerTypeAfter)
 	require.EqualError(t, err, fmt.Sprintf("error creating After. %s", expectedErr.Error()), "should error when new contract function errors")
@@ -174,3 +189,35 @@ func TestTHCall(t *testing.T) {
 	assert.Equal(t, expectedIFace.(bool), actualIFace.(bool), "should produce same interface as handle response on real function for after with undefined interface")
 	assert.Equal(t, expectedErr, actualErr, "should produce same error as handle response on real function for after with undefined interface")
 }
+
+func TestTHCallWithInfo(t *testing.T) {
+	var th *TransactionHandler
+	var err error
+	var ctx = &TransactionContext{Str: "HELLO WORLD"}
+
+	serializer := new(serializer.JSONSerializer)
+	ms := transactionHandlerStruct{}
+	info := &utils.TransactionInfo{FunctionName: "SomeFunction", Result: "some result"}
+
+	th, err = NewTransactionHandler(ms.GoodBeforeFunctionWithInfo, basicContextPtrType, TransactionHandlerTypeBefore)
+	require.NoError(t, err, "should create before handler taking info")
+	assert.True(t, th.TakesInfo(), "should take info")
+	_, actualIFace, actualErr := th.CallWithInfo(reflect.ValueOf(ctx), nil, info, serializer)
+	require.NoError(t, actualErr)
+	assert.Equal(t, "HELLO WORLD SomeFunction", actualIFace, "should pass context and info")
+
+	th, err = NewTransactionHandler(ms.GoodAfterFunctionWithInfo, basicContextPtrType, TransactionHandlerTypeAfter)
+	require.NoError(t, err, "should create after handler taking data and info")
+	assert.True(t, th.TakesInfo(), "should take info")
+	_, actualIFace, actualErr = th.CallWithInfo(reflect.ValueOf(ctx), "some data", info, serializer)
+	require.NoError(t, actualErr)
+	assert.Equal(t, "some data some result", actualIFace, "should pass data and info")
+
+	_, err = NewTransactionHandler(ms.BasicFunction, basicContextPtrType, TransactionHandlerTypeBefore)
+	require.Error(t, err, "should still error for before handlers taking other params")
+
+	th, _ = NewTransactionHandler(ms.GoodBeforeUnknownAfterFunction, basicContextPtrType, TransactionHandlerTypeUnknown)
+	assert.False(t, th.TakesInfo(), "should not take info")
+	_, actualIFace, _ = th.CallWithInfo(reflect.ValueOf(ctx), nil, info, serializer)
+	assert.Equal(t, "CALLED GoodBeforeUnknownAfterFunction", actualIFace, "should not pass info to handlers not taking it")
+}
@@ -4,13 +4,18 @@
 package types
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/go-openapi/spec"
+	"google.golang.org/protobuf/proto"
 )
 
 type basicType interface {
@@ -336,6 +341,175 @@ func (st *interfaceType) GetSchema() *spec.Schema {
 	return new(spec.Schema)
 }
 
+type scalarType interface {
+	Convert(string) (reflect.Value, error)
+	Format(reflect.Value) string
+	GetSchema() *spec.Schema
+}
+
+type bigIntType struct{}
+
+func (bt *bigIntType) Convert(value string) (reflect.Value, error) {
+	bigVal, ok := new(big.Int).SetString(value, 10)
+
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("cannot convert passed value %s to *big.Int", value)
+	}
+
+	return reflect.ValueOf(bigVal), nil
+}
+
+func (bt *bigIntType) Format(value reflect.Value) string {
+	return value.Interface().(*big.Int).String()
+}
+
+func (bt *bigIntType) GetSchema() *spec.Schema {
+	return spec.StringProperty().WithPattern(`^-?[0-9]+$`)
+}
+
+type bigRatType struct{}
+
+func (bt *bigRatType) Convert(value string) (reflect.Value, error) {
+	bigVal, ok := new(big.Rat).SetString(value)
+
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("cannot convert passed value %s to *big.Rat", value)
+	}
+
+	return reflect.ValueOf(bigVal), nil
+}
+
+func (bt *bigRatType) Format(value reflect.Value) string {
+	return value.Interface().(*big.Rat).RatString()
+}
+
+func (bt *bigRatType) GetSchema() *spec.Schema {
+	return spec.StringProperty().WithPattern(`^-?[0-9]+(\.[0-9]+|/[0-9]+)?$`)
+}
+
+type bigFloatType struct{}
+
+func (bt *bigFloatType) Convert(value string) (reflect.Value, error) {
+	bigVal, ok := new(big.Float).SetString(value)
+
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("cannot convert passed value %s to *big.Float", value)
+	}
+
+	return reflect.ValueOf(bigVal), nil
+}
+
+func (bt *bigFloatType) Format(value reflect.Value) string {
+	return value.Interface().(*big.Float).Text('g', -1)
+}
+
+func (bt *bigFloatType) GetSchema() *spec.Schema {
+	return spec.StringProperty().WithPattern(`^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+}
+
+// BigIntType reflect type for arbitrary precision integers
+var BigIntType = reflect.TypeOf(new(big.Int))
+
+// BigRatType reflect type for arbitrary precision rationals
+var BigRatType = reflect.TypeOf(new(big.Rat))
+
+// BigFloatType reflect type for arbitrary precision floats
+var BigFloatType = reflect.TypeOf(new(big.Float))
+
+// BigNumberTypes the arbitrary precision number types usable in the contract api. Their values
+// are passed as strings so that clients do not lose precision. *big.Rat values may be passed as
+// fractions or decimals and are returned as fractions, or integers when whole. *big.Float values
+// are parsed with the default precision of 64 bits
+var BigNumberTypes = map[reflect.Type]scalarType{
+	BigIntType:   new(bigIntType),
+	BigRatType:   new(bigRatType),
+	BigFloatType: new(bigFloatType),
+}
+
+type registeredType struct {
+	typ    reflect.Type
+	parse  func(string) (reflect.Value, error)
+	format func(reflect.Value) string
+	schema *spec.Schema
+}
+
+func (rt *registeredType) Convert(value string) (reflect.Value, error) {
+	converted, err := rt.parse(value)
+
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("cannot convert passed value %s to %s. %s", value, rt.typ.String(), err.Error())
+	}
+
+	return converted, nil
+}
+
+func (rt *registeredType) Format(value reflect.Value) string {
+	return rt.format(value)
+}
+
+func (rt *registeredType) GetSchema() *spec.Schema {
+	schema := *rt.schema
+	return &schema
+}
+
+var registeredTypesLock sync.RWMutex
+var registeredTypes = map[reflect.Type]scalarType{}
+
+// RegisterType registers a type whose values are passed as the strings produced by format and
+// converted back by parse. The schema describes those strings, and if nil any string is allowed.
+// Only named types, or pointers to them, declared in a package may be registered and each only once
+func RegisterType(typ reflect.Type, parse func(string) (reflect.Value, error), format func(reflect.Value) string, schema *spec.Schema) error {
+	named := typ
+
+	if named.Kind() == reflect.Pointer {
+		named = named.Elem()
+	}
+
+	if named.Name() == "" || named.PkgPath() == "" || typ.Kind() == reflect.Interface {
+		return fmt.Errorf("cannot register type %s. Only named types declared in a package, and pointers to them, may be registered", typ.String())
+	}
+
+	if schema == nil {
+		schema = spec.StringProperty()
+	}
+
+	registeredTypesLock.Lock()
+	defer registeredTypesLock.Unlock()
+
+	if _, ok := registeredTypes[typ]; ok {
+		return fmt.Errorf("cannot register type %s. The type is already registered", typ.String())
+	}
+
+	registeredTypes[typ] = &registeredType{typ: typ, parse: parse, format: format, schema: schema}
+
+	return nil
+}
+
+// GetRegisteredType returns the conversions and schema of a type registered using RegisterType
+func GetRegisteredType(typ reflect.Type) (scalarType, bool) {
+	registeredTypesLock.RLock()
+	defer registeredTypesLock.RUnlock()
+
+	rt, ok := registeredTypes[typ]
+
+	return rt, ok
+}
+
+// Int64StringSchema returns the schema of 64 bit integer types when they are passed as strings
+func Int64StringSchema(kind reflect.Kind) *spec.Schema {
+	if kind == reflect.Uint || kind == reflect.Uint64 {
+		return spec.StringProperty().WithPattern(`^[0-9]+$`)
+	}
+
+	return spec.StringProperty().WithPattern(`^-?[0-9]+$`)
+}
+
+// IsInt64 returns whether the type is one of the 64 bit integer types, int, int64, uint and uint64
+func IsInt64(t reflect.Type) bool {
+	kind := t.Kind()
+	return kind == reflect.Int || kind == reflect.Int64 || kind == reflect.Uint || kind == reflect.Uint64
+}
+
 // BasicTypes the base types usable in the contract api
 var BasicTypes = map[reflect.Kind]basicType{
 	reflect.Bool:      new(boolType),
@@ -364,3 +538,48 @@ var ErrorType = reflect.TypeOf((*error)(nil)).Elem()
 
 // TimeType reflect type for time
 var TimeType = reflect.TypeOf(time.Time{})
+
+// ProtoMessageType reflect type for protobuf messages
+var ProtoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+
+// TextMarshalerType reflect type for types which marshal themselves to text
+var TextMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// TextUnmarshalerType reflect type for types which unmarshal themselves from text
+var TextUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// JSONMarshalerType reflect type for types which marshal themselves to JSON
+var JSONMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// JSONUnmarshalerType reflect type for types which unmarshal themselves from JSON
+var JSONUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// IsTextMarshaler returns whether values of the type marshal themselves to text and unmarshal
+// themselves from it, and so are passed as strings. As the standard Go JSON marshaller prefers
+// JSON marshalling, types which also marshal themselves to JSON are not text marshalers
+func IsTextMarshaler(t reflect.Type) bool {
+	return implementsMarshaler(t, TextMarshalerType, TextUnmarshalerType) && !IsJSONMarshaler(t)
+}
+
+// IsJSONMarshaler returns whether values of the type marshal themselves to JSON and unmarshal
+// themselves from it, and so are passed as whatever JSON they produce
+func IsJSONMarshaler(t reflect.Type) bool {
+	return implementsMarshaler(t, JSONMarshalerType, JSONUnmarshalerType)
+}
+
+func implementsMarshaler(t reflect.Type, marshaler reflect.Type, unmarshaler reflect.Type) bool {
+	if t.Kind() == reflect.Interface || !t.Implements(marshaler) {
+		return false
+	}
+
+	if t.Kind() == reflect.Pointer {
+		return t.Implements(unmarshaler)
+	}
+
+	return reflect.PointerTo(t).Implements(unmarshaler)
+}
+
+// IsProtoMessage returns whether the type is a pointer to a generated protobuf message
+func IsProtoMessage(t reflect.Type) bool {
+	return t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct && t.Implements(ProtoMessageType)
+}
@@ -473,3 +473,154 @@ func TestInterfaceType(t *testing.T) {
 	require.NoError(t, err, "should never return error for interface")
 	assert.Equal(t, "hello world", val.Interface().(string), "should return string that went in")
 }
+
+func TestBigIntType(t *testing.T) {
+	var bigIntTypeVar = BigNumberTypes[BigIntType]
+
+	assert.Equal(t, spec.StringProperty().WithPattern(`^-?[0-9]+$`), bigIntTypeVar.GetSchema(), "should return string schema for big ints")
+
+	val, err := bigIntTypeVar.Convert("-123456789012345678901234567890")
+	require.NoError(t, err, "should not return error for valid big int")
+	assert.Equal(t, "-123456789012345678901234567890", bigIntTypeVar.Format(val), "should format big int as decimal string")
+
+	_, err = bigIntTypeVar.Convert("1.5")
+	require.EqualError(t, err, fmt.Sprintf(convertError, "1.5", "*big.Int"), "should return error for invalid big int")
+}
+
+func TestBigRatType(t *testing.T) {
+	var bigRatTypeVar = BigNumberTypes[BigRatType]
+
+	assert.Equal(t, spec.StringProperty().WithPattern(`^-?[0-9]+(\.[0-9]+|/[0-9]+)?$`), bigRatTypeVar.GetSchema(), "should return string schema for big rats")
+
+	val, err := bigRatTypeVar.Convert("1.25")
+	require.NoError(t, err, "should not return error for decimal big rat")
+	assert.Equal(t, "5/4", bigRatTypeVar.Format(val), "should format big rat as fraction")
+
+	val, err = bigRatTypeVar.Convert("6/3")
+	require.NoError(t, err, "should not return error for fraction big rat")
+	assert.Equal(t, "2", bigRatTypeVar.Format(val), "should format whole big rat as integer")
+
+	_, err = bigRatTypeVar.Convert("a/b")
+	require.EqualError(t, err, fmt.Sprintf(convertError, "a/b", "*big.Rat"), "should return error for invalid big rat")
+}
+
+func TestBigFloatType(t *testing.T) {
+	var bigFloatTypeVar = BigNumberTypes[BigFloatType]
+
+	assert.Equal(t, spec.StringProperty().WithPattern(`^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`), bigFloatTypeVar.GetSchema(), "should return string schema for big floats")
+
+	val, err := bigFloatTypeVar.Convert("1.5e100")
+	require.NoError(t, err, "should not return error for valid big float")
+	assert.Equal(t, "1.5e+100", bigFloatTypeVar.Format(val), "should format big float")
+
+	_, err = bigFloatTypeVar.Convert("not a number")
+	require.EqualError(t, err, fmt.Sprintf(convertError, "not a number", "*big.Float"), "should return error for invalid big float")
+}
+
+func TestInt64AsString(t *testing.T) {
+	assert.True(t, IsInt64(reflect.TypeOf(1)), "should be true for int")
+	assert.True(t, IsInt64(reflect.TypeOf(uint64(1))), "should be true for uint64")
+	assert.False(t, IsInt64(reflect.TypeOf(int32(1))), "should be false for int32")
+
+	assert.Equal(t, spec.StringProperty().WithPattern(`^-?[0-9]+$`), Int64StringSchema(reflect.Int64), "should allow negative int64 strings")
+	assert.Equal(t, spec.StringProperty().WithPattern(`^[0-9]+$`), Int64StringSchema(reflect.Uint), "should not allow negative uint strings")
+}
+
+type registrableType string
+
+func TestRegisterType(t *testing.T) {
+	parse := func(str string) (reflect.Value, error) {
+		if str == "" {
+			return reflect.Value{}, fmt.Errorf("value is empty")
+		}
+
+		return reflect.ValueOf(registrableType(str)), nil
+	}
+
+	format := func(value reflect.Value) string {
+		return value.String()
+	}
+
+	var err error
+
+	err = RegisterType(reflect.TypeOf(""), parse, format, nil)
+	require.EqualError(t, err, "cannot register type string. Only named types declared in a package, and pointers to them, may be registered", "should error for predeclared type")
+
+	err = RegisterType(reflect.TypeOf([]registrableType{}), parse, format, nil)
+	require.EqualError(t, err, "cannot register type []types.registrableType. Only named types declared in a package, and pointers to them, may be registered", "should error for unnamed type")
+
+	err = RegisterType(reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), parse, format, nil)
+	require.EqualError(t, err, "cannot register type fmt.Stringer. Only named types declared in a package, and pointers to them, may be registered", "should error for interface type")
+
+	_, ok := GetRegisteredType(reflect.TypeOf(registrableType("")))
+	require.False(t, ok, "should not return type which is not registered")
+
+	err = RegisterType(reflect.TypeOf(registrableType("")), parse, format, nil)
+	require.NoError(t, err, "should register named type")
+
+	err = RegisterType(reflect.TypeOf(registrableType("")), parse, format, nil)
+	require.EqualError(t, err, "cannot register type types.registrableType. The type is already registered", "should error for type already registered")
+
+	err = RegisterType(reflect.TypeOf(new(registrableType)), parse, format, spec.StringProperty().WithMinLength(1))
+	require.NoError(t, err, "should register pointer to named type")
+
+	registered, ok := GetRegisteredType(reflect.TypeOf(registrableType("")))
+	require.True(t, ok, "should return registered type")
+	assert.Equal(t, spec.StringProperty(), registered.GetSchema(), "should use string schema when none given")
+
+	registered.GetSchema().WithPattern("changed")
+	assert.Equal(t, spec.StringProperty(), registered.GetSchema(), "should return copy of schema")
+
+	val, err := registered.Convert("abc")
+	require.NoError(t, err, "should not return error for valid value")
+	assert.Equal(t, registrableType("abc"), val.Interface(), "should convert using parse function")
+	assert.Equal(t, "abc", registered.Format(val), "should format using format function")
+
+	_, err = registered.Convert("")
+	require.EqualError(t, err, fmt.Sprintf(convertError, "", "types.registrableType")+". value is empty", "should return error from parse function")
+
+	registered, _ = GetRegisteredType(reflect.TypeOf(new(registrableType)))
+	assert.Equal(t, spec.StringProperty().WithMinLength(1), registered.GetSchema(), "should use given schema")
+}
+
+type textValue [2]byte
+
+func (tv textValue) MarshalText() ([]byte, error) {
+	return tv[:], nil
+}
+
+func (tv *textValue) UnmarshalText(text []byte) error {
+	copy(tv[:], text)
+	return nil
+}
+
+type jsonValue struct{}
+
+func (jv *jsonValue) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
+
+func (jv *jsonValue) UnmarshalJSON([]byte) error {
+	return nil
+}
+
+type marshalOnlyValue string
+
+func (mv marshalOnlyValue) MarshalText() ([]byte, error) {
+	return []byte(mv), nil
+}
+
+func TestIsTextMarshaler(t *testing.T) {
+	assert.True(t, IsTextMarshaler(reflect.TypeOf(textValue{})), "should be true for type with text marshalling")
+	assert.True(t, IsTextMarshaler(reflect.TypeOf(new(textValue))), "should be true for pointer to type with text marshalling")
+	assert.False(t, IsTextMarshaler(reflect.TypeOf(marshalOnlyValue(""))), "should be false for type without text unmarshalling")
+	assert.False(t, IsTextMarshaler(TextMarshalerType), "should be false for interface")
+	assert.False(t, IsTextMarshaler(TimeType), "should be false for type also with JSON marshalling")
+}
+
+func TestIsJSONMarshaler(t *testing.T) {
+	assert.True(t, IsJSONMarshaler(reflect.TypeOf(new(jsonValue))), "should be true for pointer to type with JSON marshalling")
+	assert.False(t, IsJSONMarshaler(reflect.TypeOf(jsonValue{})), "should be false for type only marshalling itself through a pointer")
+	assert.True(t, IsJSONMarshaler(TimeType), "should be true for time")
+	assert.False(t, IsJSONMarshaler(reflect.TypeOf(textValue{})), "should be false for type with text marshalling")
+}
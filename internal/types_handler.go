@@ -71,7 +71,11 @@ func typeInSlice(a reflect.Type, list []reflect.Type) bool {
 
 func typeIsValid(t reflect.Type, additionalTypes []reflect.Type, allowError bool) error {
 	kind := t.Kind()
-	if kind == reflect.Array {
+	if _, ok := types.GetRegisteredType(t); ok {
+		return nil
+	} else if _, ok := types.BigNumberTypes[t]; ok || types.IsProtoMessage(t) || types.IsTextMarshaler(t) || types.IsJSONMarshaler(t) {
+		return nil
+	} else if kind == reflect.Array {
 		array := reflect.New(t).Elem()
 		return arrayOfValidType(array, additionalTypes)
 	} else if kind == reflect.Slice {
@@ -101,7 +105,7 @@ func typeIsValid(t reflect.Type, additionalTypes []reflect.Type, allowError bool
 				errStr = " error,"
 			}
 
-			return fmt.Errorf("type %s is not valid. Expected a struct or one of the basic types%s %s or an array/slice of these", t.String(), errStr, listBasicTypes())
+			return fmt.Errorf("type %s is not valid. Expected a struct, a protobuf message or one of the basic types%s %s or an array/slice of these", t.String(), errStr, listBasicTypes())
 		}
 	}
 
@@ -6,12 +6,15 @@ package internal
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/hyperledger/fabric-contract-api-go/v2/internal/types"
 	"github.com/hyperledger/fabric-contract-api-go/v2/internal/utils"
+	"github.com/hyperledger/fabric-protos-go-apiv2/orderer"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,7 +22,7 @@ import (
 // ================================
 // HELPERS
 // ================================
-const basicErr = "type %s is not valid. Expected a struct or one of the basic types %s or an array/slice of these"
+const basicErr = "type %s is not valid. Expected a struct, a protobuf message or one of the basic types %s or an array/slice of these"
 
 type goodStruct struct {
 	Prop1 string
@@ -45,6 +48,28 @@ type badStructWithMetadataPrivateFields struct {
 
 type UsefulInterface interface{}
 
+type textComplex complex64
+
+func (tc textComplex) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprint(complex64(tc))), nil
+}
+
+func (tc *textComplex) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscan(string(text), (*complex64)(tc))
+	return err
+}
+
+type jsonComplex complex64
+
+func (jc jsonComplex) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("[%g,%g]", real(jc), imag(jc))), nil
+}
+
+func (jc *jsonComplex) UnmarshalJSON(data []byte) error {
+	_, err := fmt.Sscanf(string(data), "[%g,%g]", new(float32), new(float32))
+	return err
+}
+
 var badType = reflect.TypeOf(complex64(1))
 var badArrayType = reflect.TypeOf([1]complex64{})
 var badSliceType = reflect.TypeOf([]complex64{})
@@ -184,6 +209,14 @@ func TestTypeIsValid(t *testing.T) {
 
 	require.NoError(t, typeIsValid(types.ErrorType, []reflect.Type{}, true), "should not return an error for error type on allow error")
 	require.NoError(t, typeIsValid(types.TimeType, []reflect.Type{}, false), "should not return an error for time type on allow error")
+	require.NoError(t, typeIsValid(reflect.TypeOf(&peer.ChaincodeSpec{}), []reflect.Type{}, false), "should not return an error for protobuf message type")
+	require.NoError(t, typeIsValid(reflect.TypeOf(&orderer.SeekPosition{}), []reflect.Type{}, false), "should not return an error for protobuf message type with oneof")
+	require.NoError(t, typeIsValid(reflect.TypeOf([]*peer.ChaincodeSpec{}), []reflect.Type{}, false), "should not return an error for slice of protobuf message type")
+	require.NoError(t, typeIsValid(reflect.TypeOf(new(big.Int)), []reflect.Type{}, false), "should not return an error for big int type")
+	require.NoError(t, typeIsValid(reflect.TypeOf(new(big.Rat)), []reflect.Type{}, false), "should not return an error for big rat type")
+	require.NoError(t, typeIsValid(reflect.TypeOf(map[string]*big.Float{}), []reflect.Type{}, false), "should not return an error for map of big float type")
+	require.NoError(t, typeIsValid(reflect.TypeOf(textComplex(1)), []reflect.Type{}, false), "should not return an error for type marshalling itself to text")
+	require.NoError(t, typeIsValid(reflect.TypeOf([]*jsonComplex{}), []reflect.Type{}, false), "should not return an error for slice of type marshalling itself to JSON")
 
 	require.NoError(t, typeIsValid(reflect.TypeOf([1]string{}), []reflect.Type{}, false), "should not return an error for a string array type")
 	require.NoError(t, typeIsValid(reflect.TypeOf([1]bool{}), []reflect.Type{}, false), "should not return an error for a bool array type")
@@ -0,0 +1,83 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// CollectionConfig is the definition of a private data collection in the collections config
+// of chaincode, the collections_config.json file passed when the chaincode is approved
+type CollectionConfig struct {
+	Name              string                       `json:"name"`
+	Policy            string                       `json:"policy"`
+	RequiredPeerCount int32                        `json:"requiredPeerCount"`
+	MaxPeerCount      int32                        `json:"maxPeerCount"`
+	BlockToLive       uint64                       `json:"blockToLive"`
+	MemberOnlyRead    bool                         `json:"memberOnlyRead"`
+	MemberOnlyWrite   bool                         `json:"memberOnlyWrite"`
+	EndorsementPolicy *CollectionEndorsementPolicy `json:"endorsementPolicy,omitempty"`
+}
+
+// ToCollectionsConfig converts the collections declared by the contracts of chaincode into
+// the definitions of its collections config, ordered by name. A collection may be declared by
+// more than one contract as long as each declares it with the same definition. An error is
+// returned if the definitions differ or a collection has no policy
+func ToCollectionsConfig(ccm ContractChaincodeMetadata) ([]CollectionConfig, error) {
+	contractNames := []string{}
+	for name := range ccm.Contracts {
+		contractNames = append(contractNames, name)
+	}
+	sort.Strings(contractNames)
+
+	configs := make(map[string]CollectionConfig)
+	declaredBy := make(map[string]string)
+
+	for _, contractName := range contractNames {
+		for _, collection := range ccm.Contracts[contractName].Collections {
+			config := toCollectionConfig(collection)
+
+			if existing, ok := configs[config.Name]; ok {
+				if !reflect.DeepEqual(existing, config) {
+					return nil, fmt.Errorf("collection %s is declared differently by contracts %s and %s", config.Name, declaredBy[config.Name], contractName)
+				}
+
+				continue
+			}
+
+			if config.Policy == "" {
+				return nil, fmt.Errorf("collection %s of contract %s has no policy", config.Name, contractName)
+			}
+
+			configs[config.Name] = config
+			declaredBy[config.Name] = contractName
+		}
+	}
+
+	sorted := []CollectionConfig{}
+	for _, config := range configs {
+		sorted = append(sorted, config)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	return sorted, nil
+}
+
+func toCollectionConfig(collection CollectionMetadata) CollectionConfig {
+	return CollectionConfig{
+		Name:              collection.Name,
+		Policy:            collection.Policy,
+		RequiredPeerCount: collection.RequiredPeerCount,
+		MaxPeerCount:      collection.MaxPeerCount,
+		BlockToLive:       collection.BlockToLive,
+		MemberOnlyRead:    collection.MemberOnlyRead,
+		MemberOnlyWrite:   collection.MemberOnlyWrite,
+		EndorsementPolicy: collection.EndorsementPolicy,
+	}
+}
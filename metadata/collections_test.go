@@ -0,0 +1,75 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// Tests
+// ================================
+
+func TestToCollectionsConfig(t *testing.T) {
+	var configs []CollectionConfig
+	var err error
+
+	configs, err = ToCollectionsConfig(ContractChaincodeMetadata{})
+	require.NoError(t, err)
+	assert.Empty(t, configs, "should have no collections when none are declared")
+
+	shared := CollectionMetadata{
+		Name:              "Org1AndOrg2",
+		Description:       "Shared by both orgs",
+		Policy:            "OR('Org1MSP.member', 'Org2MSP.member')",
+		RequiredPeerCount: 1,
+		MaxPeerCount:      2,
+		MemberOnlyRead:    true,
+	}
+
+	ccm := ContractChaincodeMetadata{
+		Contracts: map[string]ContractMetadata{
+			"BContract": {
+				Collections: []CollectionMetadata{
+					shared,
+					{Name: "AOnly", Policy: "OR('Org1MSP.member')", BlockToLive: 100, EndorsementPolicy: &CollectionEndorsementPolicy{SignaturePolicy: "OR('Org1MSP.peer')"}},
+				},
+			},
+			"AContract": {
+				Collections: []CollectionMetadata{shared},
+			},
+			"CContract": {},
+		},
+	}
+
+	configs, err = ToCollectionsConfig(ccm)
+	require.NoError(t, err)
+	assert.Equal(t, []CollectionConfig{
+		{Name: "AOnly", Policy: "OR('Org1MSP.member')", BlockToLive: 100, EndorsementPolicy: &CollectionEndorsementPolicy{SignaturePolicy: "OR('Org1MSP.peer')"}},
+		{Name: "Org1AndOrg2", Policy: "OR('Org1MSP.member', 'Org2MSP.member')", RequiredPeerCount: 1, MaxPeerCount: 2, MemberOnlyRead: true},
+	}, configs, "should list each collection once ordered by name")
+
+	configJSON, err := json.Marshal(configs[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Org1AndOrg2","policy":"OR('Org1MSP.member', 'Org2MSP.member')","requiredPeerCount":1,"maxPeerCount":2,"blockToLive":0,"memberOnlyRead":true,"memberOnlyWrite":false}`, string(configJSON), "should format collection as in collections config file")
+
+	differing := shared
+	differing.Description = "Described differently"
+	ccm.Contracts["AContract"] = ContractMetadata{Collections: []CollectionMetadata{differing}}
+	_, err = ToCollectionsConfig(ccm)
+	assert.NoError(t, err, "should not error when only descriptions differ")
+
+	differing.MaxPeerCount = 3
+	ccm.Contracts["AContract"] = ContractMetadata{Collections: []CollectionMetadata{differing}}
+	_, err = ToCollectionsConfig(ccm)
+	assert.EqualError(t, err, "collection Org1AndOrg2 is declared differently by contracts AContract and BContract", "should error when contracts declare collection differently")
+
+	ccm.Contracts["AContract"] = ContractMetadata{Collections: []CollectionMetadata{{Name: "NoPolicy"}}}
+	_, err = ToCollectionsConfig(ccm)
+	assert.EqualError(t, err, "collection NoPolicy of contract AContract has no policy", "should error when collection has no policy")
+}
@@ -58,12 +58,17 @@ func GetJSONSchema() []byte {
 	return contractSchemaJSON
 }
 
-// ParameterMetadata details about a parameter used for a transaction.
+// ParameterMetadata details about a parameter used for a transaction. Parameters are
+// required unless Required is set to false, in which case they may be omitted by callers.
+// A variadic parameter is passed as any number of args, each an item of its array schema
 type ParameterMetadata struct {
-	Description    string               `json:"description,omitempty"`
-	Name           string               `json:"name"`
-	Schema         *spec.Schema         `json:"schema"`
-	CompiledSchema *gojsonschema.Schema `json:"-"`
+	Description         string               `json:"description,omitempty"`
+	Name                string               `json:"name"`
+	Required            *bool                `json:"required,omitempty"`
+	Variadic            bool                 `json:"variadic,omitempty"`
+	Schema              *spec.Schema         `json:"schema"`
+	CompiledSchema      *gojsonschema.Schema `json:"-"`
+	CompiledItemsSchema *gojsonschema.Schema `json:"-"`
 }
 
 // ReturnMetadata details about the return type for a transaction
@@ -72,13 +77,80 @@ type ReturnMetadata struct {
 	CompiledSchema *gojsonschema.Schema
 }
 
+// ErrorMetadata details about an error that a transaction may return. Schema describes
+// the details sent with the error if it has any
+type ErrorMetadata struct {
+	Status      int32        `json:"status"`
+	Code        string       `json:"code,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Schema      *spec.Schema `json:"schema,omitempty"`
+}
+
+// EventMetadata details about an event that a contract may emit. Schema describes the
+// payload of the event if it has one
+type EventMetadata struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Schema      *spec.Schema `json:"schema,omitempty"`
+}
+
+// AccessPolicy describes the clients permitted to call a transaction. A client must belong
+// to one of the MSPIDs and have one of the OUs in its certificate when these are set, and
+// must have every one of the attributes with the given value. An attribute with a blank value
+// only needs to be present
+type AccessPolicy struct {
+	MSPIDs     []string          `json:"mspids,omitempty"`
+	OUs        []string          `json:"ous,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// CollectionEndorsementPolicy the endorsement policy of a private data collection, either a
+// signature policy or the name of a policy in the channel config
+type CollectionEndorsementPolicy struct {
+	SignaturePolicy     string `json:"signaturePolicy,omitempty"`
+	ChannelConfigPolicy string `json:"channelConfigPolicy,omitempty"`
+}
+
+// CollectionMetadata details about a private data collection used by a contract. Other than
+// Description its fields are those of the definition of the collection in the collections
+// config of the chaincode, which can be generated using ToCollectionsConfig
+type CollectionMetadata struct {
+	Name              string                       `json:"name"`
+	Description       string                       `json:"description,omitempty"`
+	Policy            string                       `json:"policy,omitempty"`
+	RequiredPeerCount int32                        `json:"requiredPeerCount,omitempty"`
+	MaxPeerCount      int32                        `json:"maxPeerCount,omitempty"`
+	BlockToLive       uint64                       `json:"blockToLive,omitempty"`
+	MemberOnlyRead    bool                         `json:"memberOnlyRead,omitempty"`
+	MemberOnlyWrite   bool                         `json:"memberOnlyWrite,omitempty"`
+	EndorsementPolicy *CollectionEndorsementPolicy `json:"endorsementPolicy,omitempty"`
+}
+
+// CollectionUsage details how a transaction uses a private data collection, whether it
+// reads values from the collection, writes values to it or both
+type CollectionUsage struct {
+	Name  string `json:"name"`
+	Read  bool   `json:"read,omitempty"`
+	Write bool   `json:"write,omitempty"`
+}
+
 // TransactionMetadata contains information on what makes up a transaction
-// When JSON serialized the Returns object is flattened to contain the schema
+// When JSON serialized the Returns object is flattened to contain the schema.
+// Encoding is the encoding of the arguments and return value of the transaction, given
+// by the serializer the transaction uses. Transient holds the parameters
+// of the transaction read from the transient data of the proposal rather than its args and
+// Collections the private data collections the transaction uses
 type TransactionMetadata struct {
-	Parameters []ParameterMetadata `json:"parameters,omitempty"`
-	Returns    ReturnMetadata      `json:"-"`
-	Tag        []string            `json:"tag,omitempty"`
-	Name       string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Parameters  []ParameterMetadata `json:"parameters,omitempty"`
+	Transient   []ParameterMetadata `json:"transient,omitempty"`
+	Returns     ReturnMetadata      `json:"-"`
+	Errors      []ErrorMetadata     `json:"errors,omitempty"`
+	Access      *AccessPolicy       `json:"access,omitempty"`
+	Encoding    string              `json:"encoding,omitempty"`
+	Collections []CollectionUsage   `json:"collections,omitempty"`
+	Tag         []string            `json:"tag,omitempty"`
+	Name        string              `json:"name"`
 }
 
 type tmAlias TransactionMetadata
@@ -112,6 +184,26 @@ func (tm *TransactionMetadata) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&jtm)
 }
 
+// ParameterDocs names and describes a parameter of a transaction function
+type ParameterDocs struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// TransactionDocs describes a transaction function. Parameters may list every parameter
+// of the function in order, including the transaction context if the function takes it,
+// or only those parameters passed by callers
+type TransactionDocs struct {
+	Description string          `json:"description,omitempty"`
+	Parameters  []ParameterDocs `json:"parameters,omitempty"`
+}
+
+// ContractDocs describes a contract and its transaction functions, keyed by function name
+type ContractDocs struct {
+	Description  string                     `json:"description,omitempty"`
+	Transactions map[string]TransactionDocs `json:"transactions,omitempty"`
+}
+
 // ContactMetadata contains contact details about an author of a contract/chaincode
 type ContactMetadata struct {
 	Name  string `json:"name,omitempty"`
@@ -139,6 +231,8 @@ type ContractMetadata struct {
 	Info         *InfoMetadata         `json:"info,omitempty"`
 	Name         string                `json:"name"`
 	Transactions []TransactionMetadata `json:"transactions"`
+	Events       []EventMetadata       `json:"events,omitempty"`
+	Collections  []CollectionMetadata  `json:"collections,omitempty"`
 	Default      bool                  `json:"default"`
 }
 
@@ -189,7 +283,8 @@ func (ccm *ContractChaincodeMetadata) Append(source ContractChaincodeMetadata) {
 // When validating against the compiled schema you will need to make the
 // comparison json have a key of the parameter name for parameters or
 // return for return values e.g {"param1": "value"}. Compilation process
-// resolves references to components
+// resolves references to components. The schemas of the items of variadic
+// parameters are also compiled, for validating each arg passed for them
 func (ccm *ContractChaincodeMetadata) CompileSchemas() error {
 	compileSchema := func(propName string, schema *spec.Schema, components ComponentMetadata) (*gojsonschema.Schema, error) {
 		combined := make(map[string]interface{})
@@ -212,9 +307,31 @@ func (ccm *ContractChaincodeMetadata) CompileSchemas() error {
 				}
 
 				param.CompiledSchema = gjsSchema
+
+				if param.Variadic && param.Schema.Items != nil && param.Schema.Items.Schema != nil {
+					gjsSchema, err = compileSchema(param.Name, param.Schema.Items.Schema, ccm.Components)
+
+					if err != nil {
+						return fmt.Errorf("error compiling schema for %s [%s]. %s items schema invalid. %s", contractName, tx.Name, param.Name, err.Error())
+					}
+
+					param.CompiledItemsSchema = gjsSchema
+				}
+
 				tx.Parameters[paramIdx] = param
 			}
 
+			for paramIdx, param := range tx.Transient {
+				gjsSchema, err := compileSchema(param.Name, param.Schema, ccm.Components)
+
+				if err != nil {
+					return fmt.Errorf("error compiling schema for %s [%s]. Transient %s schema invalid. %s", contractName, tx.Name, param.Name, err.Error())
+				}
+
+				param.CompiledSchema = gjsSchema
+				tx.Transient[paramIdx] = param
+			}
+
 			if tx.Returns.Schema != nil {
 				gjsSchema, err := compileSchema("return", tx.Returns.Schema, ccm.Components)
 
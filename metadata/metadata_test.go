@@ -264,6 +264,46 @@ func TestCompileSchemas(t *testing.T) {
 	validateCompiledSchema(t, "goodParam1", make(map[string]interface{}), ccm.Contracts["someContract"].Transactions[0].Parameters[0].CompiledSchema)
 	validateCompiledSchema(t, "goodParam2", "abc", ccm.Contracts["someContract"].Transactions[0].Parameters[1].CompiledSchema)
 	assert.Nil(t, ccm.Contracts["someContract"].Transactions[0].Returns.CompiledSchema, "should set compiled schema nil on no return")
+	assert.Nil(t, ccm.Contracts["someContract"].Transactions[0].Parameters[1].CompiledItemsSchema, "should not compile items schema of parameter which is not variadic")
+
+	variadicParameter := ParameterMetadata{
+		Name:     "variadicParam",
+		Variadic: true,
+		Schema:   spec.ArrayProperty(spec.Int64Property()),
+	}
+
+	someTransaction.Parameters = []ParameterMetadata{goodParameter2, variadicParameter}
+	someContract.Transactions[0] = someTransaction
+	ccm.Contracts["someContract"] = someContract
+	err = ccm.CompileSchemas()
+	require.NoError(t, err, "should not error on good metadata with variadic parameter")
+	compiledItems := ccm.Contracts["someContract"].Transactions[0].Parameters[1].CompiledItemsSchema
+	require.NotNil(t, compiledItems, "should compile items schema of variadic parameter")
+	result, _ := compiledItems.Validate(gojsonschema.NewGoLoader(map[string]interface{}{"variadicParam": 1}))
+	assert.True(t, result.Valid(), "should validate item against compiled items schema")
+	result, _ = compiledItems.Validate(gojsonschema.NewGoLoader(map[string]interface{}{"variadicParam": "abc"}))
+	assert.False(t, result.Valid(), "should reject item not matching compiled items schema")
+
+	variadicParameter.Schema = spec.ArrayProperty(spec.RefProperty("non-existent"))
+	someTransaction.Parameters = []ParameterMetadata{variadicParameter}
+	someContract.Transactions[0] = someTransaction
+	ccm.Contracts["someContract"] = someContract
+	err = ccm.CompileSchemas()
+	assert.ErrorContains(t, err, "error compiling schema for someContract [someTransaction]. variadicParam", "should error on bad schema for variadic param")
+
+	someTransaction.Parameters = []ParameterMetadata{goodParameter2}
+	someTransaction.Transient = []ParameterMetadata{badParameter}
+	someContract.Transactions[0] = someTransaction
+	ccm.Contracts["someContract"] = someContract
+	err = ccm.CompileSchemas()
+	assert.ErrorContains(t, err, "error compiling schema for someContract [someTransaction]. Transient badParam schema invalid.", "should error on bad schema for transient param")
+
+	someTransaction.Transient = []ParameterMetadata{goodParameter1}
+	someContract.Transactions[0] = someTransaction
+	ccm.Contracts["someContract"] = someContract
+	err = ccm.CompileSchemas()
+	require.NoError(t, err, "should not error on good metadata with transient parameter")
+	validateCompiledSchema(t, "goodParam1", make(map[string]interface{}), ccm.Contracts["someContract"].Transactions[0].Transient[0].CompiledSchema)
 }
 
 func validateCompiledSchema(t *testing.T, propName string, propValue interface{}, compiledSchema *gojsonschema.Schema) {
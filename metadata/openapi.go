@@ -0,0 +1,369 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// OpenAPIVersion is the version of the OpenAPI specification documents produced by
+// ToOpenAPI3 follow
+const OpenAPIVersion = "3.0.3"
+
+const (
+	componentsRefPrefix = "#/components/schemas/"
+	jsonMediaType       = "application/json"
+)
+
+// OpenAPIDocument is an OpenAPI 3 document describing chaincode
+type OpenAPIDocument struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       InfoMetadata               `json:"info"`
+	Tags       []OpenAPITag               `json:"tags,omitempty"`
+	Paths      map[string]OpenAPIPathItem `json:"paths"`
+	Components OpenAPIComponents          `json:"components"`
+}
+
+// OpenAPITag describes a contract, which is used to group its transactions
+type OpenAPITag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPIPathItem describes the operations available at the path of a transaction
+type OpenAPIPathItem struct {
+	Post *OpenAPIOperation `json:"post,omitempty"`
+}
+
+// OpenAPIOperation describes a transaction. TransactionType is SUBMIT or EVALUATE
+// according to the tags of the transaction, Access the access policy of the transaction
+// and Encoding the encoding of the transaction if it does not use that of the chaincode.
+// Transient describes the parameters read from the transient data of the proposal as the
+// properties of an object, each the key of an entry of the transient data
+type OpenAPIOperation struct {
+	OperationID     string                     `json:"operationId"`
+	Description     string                     `json:"description,omitempty"`
+	Tags            []string                   `json:"tags,omitempty"`
+	RequestBody     *OpenAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses       map[string]OpenAPIResponse `json:"responses"`
+	TransactionType string                     `json:"x-fabric-transaction-type,omitempty"`
+	Access          *AccessPolicy              `json:"x-fabric-access,omitempty"`
+	Encoding        string                     `json:"x-fabric-encoding,omitempty"`
+	Transient       *spec.Schema               `json:"x-fabric-transient,omitempty"`
+}
+
+// OpenAPIRequestBody describes the parameters of a transaction as the properties of an object
+type OpenAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponse describes a response of a transaction
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType holds the schema of a request body or response
+type OpenAPIMediaType struct {
+	Schema *spec.Schema `json:"schema"`
+}
+
+// OpenAPIComponents holds the schemas referenced by the document
+type OpenAPIComponents struct {
+	Schemas map[string]*spec.Schema `json:"schemas"`
+}
+
+// ToOpenAPI3 converts chaincode metadata into an OpenAPI 3 document. Each transaction of each
+// contract is described as a POST operation at the path /{contract}/{transaction} taking its
+// parameters as the properties of a JSON object in the request body. The successful response
+// is described by the return schema of the transaction and the errors of the transaction by
+// responses with their status. References to components, including the bare references used
+// within components, are resolved to the components of the document. An error is returned if
+// a schema references a component that does not exist
+func ToOpenAPI3(ccm ContractChaincodeMetadata) (*OpenAPIDocument, error) {
+	doc := &OpenAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Info:    InfoMetadata{Title: "undefined", Version: "latest"},
+		Paths:   make(map[string]OpenAPIPathItem),
+	}
+	doc.Components.Schemas = make(map[string]*spec.Schema)
+
+	if ccm.Info != nil {
+		doc.Info = *ccm.Info
+	}
+
+	for name, object := range ccm.Components.Schemas {
+		schema := spec.Schema{}
+		schema.Typed("object", "")
+		schema.Properties = spec.SchemaProperties(object.Properties)
+		schema.Required = object.Required
+		schema.AdditionalProperties = &spec.SchemaOrBool{Allows: object.AdditionalProperties}
+
+		resolved, err := resolveRefs(&schema, ccm.Components)
+		if err != nil {
+			return nil, fmt.Errorf("error converting component %s. %s", name, err.Error())
+		}
+
+		doc.Components.Schemas[name] = resolved
+	}
+
+	contractNames := []string{}
+	for name := range ccm.Contracts {
+		contractNames = append(contractNames, name)
+	}
+	sort.Strings(contractNames)
+
+	for _, contractName := range contractNames {
+		contract := ccm.Contracts[contractName]
+
+		tag := OpenAPITag{Name: contractName}
+		if contract.Info != nil {
+			tag.Description = contract.Info.Description
+		}
+		doc.Tags = append(doc.Tags, tag)
+
+		for _, tx := range contract.Transactions {
+			operation, err := toOpenAPIOperation(contractName, tx, ccm.Components)
+			if err != nil {
+				return nil, fmt.Errorf("error converting %s [%s]. %s", contractName, tx.Name, err.Error())
+			}
+
+			doc.Paths["/"+contractName+"/"+tx.Name] = OpenAPIPathItem{Post: operation}
+		}
+	}
+
+	return doc, nil
+}
+
+func toOpenAPIOperation(contractName string, tx TransactionMetadata, components ComponentMetadata) (*OpenAPIOperation, error) {
+	operation := &OpenAPIOperation{
+		OperationID:     contractName + ":" + tx.Name,
+		Description:     tx.Description,
+		Tags:            []string{contractName},
+		Responses:       make(map[string]OpenAPIResponse),
+		TransactionType: "SUBMIT",
+		Access:          tx.Access,
+		Encoding:        tx.Encoding,
+	}
+
+	for _, tag := range tx.Tag {
+		if strings.EqualFold(tag, "evaluate") {
+			operation.TransactionType = "EVALUATE"
+		}
+	}
+
+	if len(tx.Parameters) > 0 {
+		body, err := toOpenAPIParameters(tx.Parameters, components)
+		if err != nil {
+			return nil, err
+		}
+
+		operation.RequestBody = &OpenAPIRequestBody{
+			Required: len(body.Required) > 0,
+			Content:  map[string]OpenAPIMediaType{jsonMediaType: {Schema: body}},
+		}
+	}
+
+	if len(tx.Transient) > 0 {
+		transient, err := toOpenAPIParameters(tx.Transient, components)
+		if err != nil {
+			return nil, err
+		}
+
+		operation.Transient = transient
+	}
+
+	success := OpenAPIResponse{Description: "The transaction succeeded"}
+
+	if tx.Returns.Schema != nil {
+		resolved, err := resolveRefs(tx.Returns.Schema, components)
+		if err != nil {
+			return nil, fmt.Errorf("return invalid. %s", err.Error())
+		}
+
+		success.Content = map[string]OpenAPIMediaType{jsonMediaType: {Schema: resolved}}
+	}
+
+	operation.Responses["200"] = success
+
+	errorResponses, err := toOpenAPIErrorResponses(tx.Errors, components)
+	if err != nil {
+		return nil, err
+	}
+
+	for status, response := range errorResponses {
+		operation.Responses[status] = response
+	}
+
+	return operation, nil
+}
+
+// toOpenAPIParameters describes parameters as the properties of an object, requiring those
+// which callers may not omit. Siblings of a $ref are ignored, so the schema of a described
+// parameter referencing a component is wrapped in allOf for the description to sit beside it
+func toOpenAPIParameters(params []ParameterMetadata, components ComponentMetadata) (*spec.Schema, error) {
+	body := new(spec.Schema).Typed("object", "")
+	body.AdditionalProperties = &spec.SchemaOrBool{Allows: false}
+
+	for _, param := range params {
+		resolved, err := resolveRefs(param.Schema, components)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s invalid. %s", param.Name, err.Error())
+		}
+
+		if param.Description != "" && resolved.Ref.String() != "" {
+			resolved = spec.ComposedSchema(*resolved)
+		}
+
+		resolved.Description = param.Description
+		body.SetProperty(param.Name, *resolved)
+
+		if param.Required == nil || *param.Required {
+			body.AddRequired(param.Name)
+		}
+	}
+
+	return body, nil
+}
+
+// toOpenAPIErrorResponses describes the errors of a transaction as responses keyed by status.
+// Errors with the same status share a response listing each of their codes
+func toOpenAPIErrorResponses(errors []ErrorMetadata, components ComponentMetadata) (map[string]OpenAPIResponse, error) {
+	byStatus := make(map[int32][]ErrorMetadata)
+	for _, errMetadata := range errors {
+		byStatus[errMetadata.Status] = append(byStatus[errMetadata.Status], errMetadata)
+	}
+
+	responses := make(map[string]OpenAPIResponse)
+
+	for status, statusErrors := range byStatus {
+		payload := new(spec.Schema).Typed("object", "")
+		payload.SetProperty("status", *spec.Int32Property())
+		payload.SetProperty("code", *spec.StringProperty())
+		payload.SetProperty("message", *spec.StringProperty())
+		payload.AddRequired("status", "message")
+
+		descriptions := []string{}
+		codes := []interface{}{}
+
+		for _, errMetadata := range statusErrors {
+			if errMetadata.Description != "" {
+				descriptions = append(descriptions, errMetadata.Description)
+			}
+
+			if errMetadata.Code != "" {
+				codes = append(codes, errMetadata.Code)
+			}
+		}
+
+		if len(codes) == len(statusErrors) {
+			code := payload.Properties["code"]
+			code.Enum = codes
+			payload.Properties["code"] = code
+		}
+
+		if len(statusErrors) == 1 && statusErrors[0].Schema != nil {
+			details, err := resolveRefs(statusErrors[0].Schema, components)
+			if err != nil {
+				return nil, fmt.Errorf("error details invalid. %s", err.Error())
+			}
+
+			payload.SetProperty("details", *details)
+		}
+
+		description := strings.Join(descriptions, ". ")
+		if description == "" {
+			description = "The transaction failed"
+		}
+
+		responses[strconv.Itoa(int(status))] = OpenAPIResponse{
+			Description: description,
+			Content:     map[string]OpenAPIMediaType{jsonMediaType: {Schema: payload}},
+		}
+	}
+
+	return responses, nil
+}
+
+// resolveRefs returns a copy of the schema in which every reference to a component, including
+// those nested within other schemas, references the component within the components of an
+// OpenAPI document
+func resolveRefs(schema *spec.Schema, components ComponentMetadata) (*spec.Schema, error) {
+	if schema == nil {
+		return new(spec.Schema), nil
+	}
+
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := new(spec.Schema)
+	if err := json.Unmarshal(schemaJSON, resolved); err != nil {
+		return nil, err
+	}
+
+	if err := resolveNestedRefs(resolved, components); err != nil {
+		return nil, err
+	}
+
+	return resolved, nil
+}
+
+func resolveNestedRefs(schema *spec.Schema, components ComponentMetadata) error {
+	if ref := schema.Ref.String(); ref != "" {
+		name := strings.TrimPrefix(ref, componentsRefPrefix)
+
+		if _, ok := components.Schemas[name]; !ok {
+			return fmt.Errorf("reference to unknown component %s", ref)
+		}
+
+		schema.Ref = spec.MustCreateRef(componentsRefPrefix + name)
+	}
+
+	for name, property := range schema.Properties {
+		if err := resolveNestedRefs(&property, components); err != nil {
+			return err
+		}
+
+		schema.Properties[name] = property
+	}
+
+	nested := []*spec.Schema{}
+
+	if schema.Items != nil {
+		if schema.Items.Schema != nil {
+			nested = append(nested, schema.Items.Schema)
+		}
+
+		for i := range schema.Items.Schemas {
+			nested = append(nested, &schema.Items.Schemas[i])
+		}
+	}
+
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		nested = append(nested, schema.AdditionalProperties.Schema)
+	}
+
+	for _, composed := range [][]spec.Schema{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for i := range composed {
+			nested = append(nested, &composed[i])
+		}
+	}
+
+	for _, nestedSchema := range nested {
+		if err := resolveNestedRefs(nestedSchema, components); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// Tests
+// ================================
+
+func TestToOpenAPI3(t *testing.T) {
+	var doc *OpenAPIDocument
+	var err error
+
+	notRequired := false
+
+	doc, err = ToOpenAPI3(ContractChaincodeMetadata{})
+	require.NoError(t, err)
+	assert.Equal(t, OpenAPIVersion, doc.OpenAPI, "should set OpenAPI version")
+	assert.Equal(t, InfoMetadata{Title: "undefined", Version: "latest"}, doc.Info, "should default info")
+	assert.Empty(t, doc.Paths, "should have no paths")
+
+	ccm := ContractChaincodeMetadata{
+		Info: &InfoMetadata{Title: "assets", Version: "1.0.0"},
+		Contracts: map[string]ContractMetadata{
+			"AssetContract": {
+				Name: "AssetContract",
+				Info: &InfoMetadata{Description: "Manages assets"},
+				Transactions: []TransactionMetadata{
+					{
+						Name:        "Create",
+						Description: "Creates an asset",
+						Parameters: []ParameterMetadata{
+							{Name: "id", Description: "The ID", Schema: spec.StringProperty()},
+							{Name: "asset", Schema: spec.RefSchema("#/components/schemas/asset")},
+							{Name: "note", Required: &notRequired, Schema: spec.StringProperty()},
+							{Name: "previous", Description: "The previous owner", Required: &notRequired, Schema: spec.RefSchema("#/components/schemas/owner")},
+						},
+						Transient: []ParameterMetadata{
+							{Name: "secret", Description: "The secret", Schema: spec.StringProperty()},
+							{Name: "owner", Required: &notRequired, Schema: spec.RefSchema("#/components/schemas/owner")},
+						},
+						Errors: []ErrorMetadata{
+							{Status: 409, Code: "EXISTS", Description: "The asset exists", Schema: spec.StringProperty()},
+							{Status: 400, Code: "INVALID"},
+							{Status: 400, Code: "MISSING"},
+						},
+						Tag: []string{"submit"},
+					},
+					{
+						Name: "List",
+						Parameters: []ParameterMetadata{
+							{Name: "owner", Required: &notRequired, Schema: spec.StringProperty()},
+						},
+						Tag: []string{"evaluate", "EVALUATE"},
+					},
+					{
+						Name:     "Read",
+						Returns:  ReturnMetadata{Schema: spec.RefSchema("#/components/schemas/asset")},
+						Encoding: "protobuf",
+						Tag:      []string{"evaluate", "EVALUATE"},
+					},
+				},
+			},
+		},
+		Components: ComponentMetadata{
+			Schemas: map[string]ObjectMetadata{
+				"asset": {
+					ID:                   "asset",
+					Properties:           map[string]spec.Schema{"owner": *spec.RefSchema("owner")},
+					Required:             []string{"owner"},
+					AdditionalProperties: false,
+				},
+				"owner": {
+					ID:                   "owner",
+					Properties:           map[string]spec.Schema{"name": *spec.StringProperty()},
+					AdditionalProperties: false,
+				},
+			},
+		},
+	}
+
+	doc, err = ToOpenAPI3(ccm)
+	require.NoError(t, err)
+	assert.Equal(t, *ccm.Info, doc.Info, "should use metadata info")
+	assert.Equal(t, []OpenAPITag{{Name: "AssetContract", Description: "Manages assets"}}, doc.Tags, "should tag contract")
+	owner := doc.Components.Schemas["asset"].Properties["owner"]
+	assert.Equal(t, "#/components/schemas/owner", owner.Ref.String(), "should resolve bare reference within component")
+
+	create := doc.Paths["/AssetContract/Create"].Post
+	require.NotNil(t, create, "should describe transaction as POST operation")
+	assert.Equal(t, "AssetContract:Create", create.OperationID)
+	assert.Equal(t, "SUBMIT", create.TransactionType)
+	body := create.RequestBody.Content[jsonMediaType].Schema
+	assert.Equal(t, []string{"id", "asset"}, body.Required, "should require each parameter not marked as optional")
+	assert.Contains(t, body.Properties, "note", "should describe optional parameter")
+	assert.Equal(t, "The ID", body.Properties["id"].Description, "should describe parameter")
+	asset := body.Properties["asset"]
+	assert.Equal(t, "#/components/schemas/asset", asset.Ref.String())
+	previous := body.Properties["previous"]
+	assert.Equal(t, "The previous owner", previous.Description, "should describe parameter referencing component")
+	assert.Empty(t, previous.Ref.String(), "should not set description beside reference")
+	require.Len(t, previous.AllOf, 1)
+	assert.Equal(t, "#/components/schemas/owner", previous.AllOf[0].Ref.String(), "should wrap reference of described parameter in allOf")
+	assert.True(t, create.RequestBody.Required, "should require request body when a parameter is required")
+	assert.False(t, doc.Paths["/AssetContract/List"].Post.RequestBody.Required, "should not require request body when every parameter is optional")
+	require.NotNil(t, create.Transient, "should describe transient parameters")
+	assert.Equal(t, []string{"secret"}, create.Transient.Required, "should require each transient parameter not marked as optional")
+	assert.Equal(t, "The secret", create.Transient.Properties["secret"].Description, "should describe transient parameter")
+	assert.NotContains(t, body.Properties, "secret", "should not describe transient parameter in request body")
+	assert.Equal(t, "The transaction succeeded", create.Responses["200"].Description)
+	assert.Nil(t, create.Responses["200"].Content, "should have no content when transaction returns nothing")
+
+	conflict := create.Responses["409"]
+	assert.Equal(t, "The asset exists", conflict.Description)
+	assert.Equal(t, []interface{}{"EXISTS"}, conflict.Content[jsonMediaType].Schema.Properties["code"].Enum)
+	assert.Contains(t, conflict.Content[jsonMediaType].Schema.Properties, "details", "should describe details of single error")
+
+	invalid := create.Responses["400"]
+	assert.Equal(t, "The transaction failed", invalid.Description, "should use default description")
+	assert.Equal(t, []interface{}{"INVALID", "MISSING"}, invalid.Content[jsonMediaType].Schema.Properties["code"].Enum, "should list codes of errors sharing status")
+	assert.NotContains(t, invalid.Content[jsonMediaType].Schema.Properties, "details")
+
+	read := doc.Paths["/AssetContract/Read"].Post
+	require.NotNil(t, read)
+	assert.Equal(t, "EVALUATE", read.TransactionType)
+	assert.Equal(t, "protobuf", read.Encoding, "should describe encoding of transaction")
+	assert.Nil(t, read.RequestBody, "should have no request body without parameters")
+	assert.Nil(t, read.Transient, "should have no transient parameters")
+	assert.Equal(t, "#/components/schemas/asset", read.Responses["200"].Content[jsonMediaType].Schema.Ref.String())
+
+	_, err = json.Marshal(doc)
+	assert.NoError(t, err, "should marshal document")
+
+	ccm.Components.Schemas["asset"] = ObjectMetadata{ID: "asset", Properties: map[string]spec.Schema{"owner": *spec.RefSchema("missing")}}
+	_, err = ToOpenAPI3(ccm)
+	assert.EqualError(t, err, "error converting component asset. reference to unknown component missing", "should error for unknown nested reference")
+
+	delete(ccm.Components.Schemas, "asset")
+	_, err = ToOpenAPI3(ccm)
+	assert.EqualError(t, err, "error converting AssetContract [Create]. parameter asset invalid. reference to unknown component #/components/schemas/asset", "should error for unknown parameter reference")
+}
@@ -0,0 +1,165 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"reflect"
+
+	"github.com/go-openapi/spec"
+	"github.com/hyperledger/fabric-contract-api-go/v2/internal/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const wellKnownPackage = "google.protobuf"
+
+// wrapperNames are the names of the well known messages which wrap a single value, and are
+// represented in JSON by that value
+var wrapperNames = map[protoreflect.Name]bool{
+	"BoolValue": true, "BytesValue": true, "DoubleValue": true, "FloatValue": true, "Int32Value": true,
+	"Int64Value": true, "StringValue": true, "UInt32Value": true, "UInt64Value": true,
+}
+
+// buildProtoSchema returns the schema of a protobuf message type. Schemas generated for protobuf
+// messages describe the canonical protobuf JSON form of the message, using the JSON names of the
+// fields, rather than the fields of the generated Go struct
+func buildProtoSchema(field reflect.Type, components *ComponentMetadata, nested bool) (*spec.Schema, error) {
+	message := reflect.New(field.Elem()).Interface().(proto.Message)
+
+	return getMessageSchema(message.ProtoReflect().Descriptor(), components, nested), nil
+}
+
+func getMessageSchema(desc protoreflect.MessageDescriptor, components *ComponentMetadata, nested bool) *spec.Schema {
+	if schema := getWellKnownSchema(desc); schema != nil {
+		return schema
+	}
+
+	addMessageComponentIfNotExists(desc, components)
+
+	refPath := "#/components/schemas/"
+
+	if nested {
+		refPath = ""
+	}
+
+	return spec.RefSchema(refPath + string(desc.FullName()))
+}
+
+// getWellKnownSchema returns the schema of well known messages, which have a special
+// JSON form, or nil if the message is not one of these
+func getWellKnownSchema(desc protoreflect.MessageDescriptor) *spec.Schema {
+	if desc.ParentFile() == nil || desc.ParentFile().Package() != wellKnownPackage {
+		return nil
+	}
+
+	if wrapperNames[desc.Name()] {
+		return getFieldKindSchema(desc.Fields().ByName("value"), nil)
+	}
+
+	switch desc.Name() {
+	case "Timestamp":
+		return spec.DateTimeProperty()
+	case "Duration":
+		return spec.StringProperty().WithPattern(`^-?[0-9]+(\.[0-9]+)?s$`)
+	case "FieldMask":
+		return spec.StringProperty()
+	case "Struct", "Empty":
+		return new(spec.Schema).Typed("object", "")
+	case "Any":
+		return new(spec.Schema).Typed("object", "").WithRequired("@type")
+	case "ListValue":
+		return spec.ArrayProperty(new(spec.Schema))
+	case "Value":
+		return new(spec.Schema)
+	}
+
+	return nil
+}
+
+func addMessageComponentIfNotExists(desc protoreflect.MessageDescriptor, components *ComponentMetadata) {
+	name := string(desc.FullName())
+
+	if _, ok := components.Schemas[name]; ok {
+		return
+	}
+
+	schema := ObjectMetadata{}
+	schema.ID = name
+	schema.Required = []string{}
+	schema.Properties = make(map[string]spec.Schema)
+	schema.AdditionalProperties = false
+
+	if components.Schemas == nil {
+		components.Schemas = make(map[string]ObjectMetadata)
+	}
+
+	components.Schemas[name] = schema // lock up slot for cyclic
+
+	fields := desc.Fields()
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+
+		if field.Cardinality() == protoreflect.Required {
+			schema.Required = append(schema.Required, field.JSONName())
+		}
+
+		schema.Properties[field.JSONName()] = *getFieldSchema(field, components)
+	}
+
+	components.Schemas[name] = schema // include changes
+}
+
+func getFieldSchema(field protoreflect.FieldDescriptor, components *ComponentMetadata) *spec.Schema {
+	if field.IsMap() {
+		return spec.MapProperty(getFieldKindSchema(field.MapValue(), components))
+	}
+
+	if field.IsList() {
+		return spec.ArrayProperty(getFieldKindSchema(field, components))
+	}
+
+	return getFieldKindSchema(field, components)
+}
+
+// getFieldKindSchema returns the schema of a single value of a field. 64 bit integers are
+// represented as strings in the JSON form of protobuf messages and enums by the names of
+// their values
+func getFieldKindSchema(field protoreflect.FieldDescriptor, components *ComponentMetadata) *spec.Schema {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return spec.BooleanProperty()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return types.BasicTypes[reflect.Int32].GetSchema()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return types.BasicTypes[reflect.Uint32].GetSchema()
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return spec.StringProperty().WithPattern(`^-?[0-9]+$`)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return spec.StringProperty().WithPattern(`^[0-9]+$`)
+	case protoreflect.FloatKind:
+		return types.BasicTypes[reflect.Float32].GetSchema()
+	case protoreflect.DoubleKind:
+		return types.BasicTypes[reflect.Float64].GetSchema()
+	case protoreflect.StringKind:
+		return spec.StringProperty()
+	case protoreflect.BytesKind:
+		return spec.StrFmtProperty("byte")
+	case protoreflect.EnumKind:
+		if field.Enum().FullName() == wellKnownPackage+".NullValue" {
+			return new(spec.Schema).Typed("null", "")
+		}
+
+		values := field.Enum().Values()
+		schema := spec.StringProperty()
+
+		for i := 0; i < values.Len(); i++ {
+			schema.Enum = append(schema.Enum, string(values.Get(i).Name()))
+		}
+
+		return schema
+	}
+
+	return getMessageSchema(field.Message(), components, true)
+}
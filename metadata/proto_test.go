@@ -0,0 +1,74 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// ================================
+// Tests
+// ================================
+
+func TestBuildProtoSchema(t *testing.T) {
+	var schema *spec.Schema
+	var err error
+
+	components := new(ComponentMetadata)
+
+	schema, err = GetSchema(reflect.TypeOf(&peer.ChaincodeSpec{}), components)
+	require.NoError(t, err)
+	assert.Equal(t, spec.RefSchema("#/components/schemas/protos.ChaincodeSpec"), schema, "should reference component named by full name of message")
+
+	chaincodeSpec := components.Schemas["protos.ChaincodeSpec"]
+	assert.Equal(t, "protos.ChaincodeSpec", chaincodeSpec.ID)
+	assert.Equal(t, []string{}, chaincodeSpec.Required, "should not require proto3 fields")
+	assert.False(t, chaincodeSpec.AdditionalProperties)
+	assert.Equal(t, []interface{}{"UNDEFINED", "GOLANG", "NODE", "CAR", "JAVA"}, chaincodeSpec.Properties["type"].Enum, "should use names of enum values")
+	chaincodeID := chaincodeSpec.Properties["chaincodeId"]
+	assert.Equal(t, "protos.ChaincodeID", chaincodeID.Ref.String(), "should use JSON name and bare reference for nested message")
+	assert.Equal(t, "integer", chaincodeSpec.Properties["timeout"].Type[0])
+
+	input := components.Schemas["protos.ChaincodeInput"]
+	assert.Equal(t, "array", input.Properties["args"].Type[0])
+	assert.Equal(t, "byte", input.Properties["args"].Items.Schema.Format, "should describe bytes as base64 strings")
+	assert.Equal(t, "byte", input.Properties["decorations"].AdditionalProperties.Schema.Format, "should describe map values")
+	assert.Equal(t, "boolean", input.Properties["isInit"].Type[0])
+	assert.Contains(t, components.Schemas, "protos.ChaincodeID")
+
+	_, err = GetSchema(reflect.TypeOf(&common.ChannelHeader{}), components)
+	require.NoError(t, err)
+	header := components.Schemas["common.ChannelHeader"]
+	assert.Equal(t, *spec.DateTimeProperty(), header.Properties["timestamp"], "should describe timestamp as date-time")
+	assert.Equal(t, "string", header.Properties["epoch"].Type[0], "should describe 64 bit integers as strings")
+
+	schema, err = GetSchema(reflect.TypeOf(&timestamppb.Timestamp{}), components)
+	require.NoError(t, err)
+	assert.Equal(t, "date-time", schema.Format, "should describe well known type directly")
+
+	schema, err = GetSchema(reflect.TypeOf(&durationpb.Duration{}), components)
+	require.NoError(t, err)
+	assert.Equal(t, "string", schema.Type[0])
+
+	schema, err = GetSchema(reflect.TypeOf(&wrapperspb.BoolValue{}), components)
+	require.NoError(t, err)
+	assert.Equal(t, spec.BooleanProperty(), schema, "should describe wrapper by wrapped value")
+
+	schema, err = GetSchema(reflect.TypeOf([]*peer.ChaincodeID{}), components)
+	require.NoError(t, err)
+	assert.Equal(t, "#/components/schemas/protos.ChaincodeID", schema.Items.Schema.Ref.String(), "should handle slices of messages")
+
+	ccm := ContractChaincodeMetadata{Info: &InfoMetadata{Title: "some chaincode", Version: "1.0.0"}, Contracts: map[string]ContractMetadata{}, Components: *components}
+	assert.NoError(t, ValidateAgainstSchema(ccm), "should produce valid metadata")
+}
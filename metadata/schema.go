@@ -4,6 +4,7 @@
 package metadata
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -18,18 +19,64 @@ import (
 // name used in the generated schema will be the name of the property unless a metadata or json
 // tag exists for the property. Metadata tags take precedence over json tags. Private properties
 // without a metadata tag will be ignored. Json tags are not used for private properties. Components
-// will be added to component metadata if the field is a struct type. The schema will then reference
-// this component
+// will be added to component metadata if the field is a struct type or protobuf message. The schema
+// will then reference this component. Components for protobuf messages are named by the full name
+// of the message and describe the protobuf JSON form of the message. Types registered with the
+// contract api use the schema given when they were registered. Types which marshal themselves to
+// text, implementing encoding.TextMarshaler and encoding.TextUnmarshaler, are described as strings
+// and those which marshal themselves to JSON, implementing json.Marshaler and json.Unmarshaler, by
+// an empty schema allowing any value, unless they implement SchemaProvider. Types implementing
+// EnumProvider are described as only allowing the values they give. The schemas of struct
+// properties can be given further rules using a validate tag, which sets the JSON schema keywords
+// minimum, maximum, minLength, maxLength, pattern, format, enum, minItems, maxItems and description,
+// for example `validate:"minLength=1,pattern=^[A-Z]+$,enum=GBP|USD"`
 func GetSchema(field reflect.Type, components *ComponentMetadata) (*spec.Schema, error) {
-	return getSchema(field, components, false)
+	return getSchema(field, components, false, SchemaOptions{})
 }
 
-func getSchema(field reflect.Type, components *ComponentMetadata, nested bool) (*spec.Schema, error) {
-	if types.IsBytes(field) {
-		return spec.StrFmtProperty("byte"), nil
+// SchemaProvider can be implemented by types which marshal themselves to text or JSON to give the
+// schema of what they marshal to. Without it the schema of types which marshal themselves to text is
+// a string and that of types which marshal themselves to JSON allows any value. The method is called
+// on a new zero value of the type
+type SchemaProvider interface {
+	GetSchema() *spec.Schema
+}
+
+// EnumProvider can be implemented by named types with a fixed set of values, for example a string
+// type with a constant for each status of an asset, to give those values. The schema of the type then
+// only allows those values, so that values outside the set fail validation against the metadata. The
+// method is called on a new zero value of the type
+type EnumProvider interface {
+	EnumValues() []interface{}
+}
+
+// SchemaOptions options which change the schemas generated for types to match how a
+// transaction serializer represents their values
+type SchemaOptions struct {
+	// Int64AsString describes the 64 bit integer types, int, int64, uint and uint64, as
+	// strings of digits rather than numbers
+	Int64AsString bool
+}
+
+// Int64AsStringSuffix is added to the names of the components of structs containing 64 bit
+// integer types when their schemas are generated with the Int64AsString option
+const Int64AsStringSuffix = "_Int64AsString"
+
+// GetSchemaWithOptions returns the open api spec schema for a given type in the same way as
+// GetSchema, with the schemas of types changed as set out by the options. Structs whose schemas
+// are changed by the options are given components of their own, named with the suffix
+// Int64AsStringSuffix, so that schemas of the same struct generated with different options
+// can share the components
+func GetSchemaWithOptions(field reflect.Type, components *ComponentMetadata, options SchemaOptions) (*spec.Schema, error) {
+	return getSchema(field, components, false, options)
+}
+
+func getSchema(field reflect.Type, components *ComponentMetadata, nested bool, options SchemaOptions) (*spec.Schema, error) {
+	if rt, ok := types.GetRegisteredType(field); ok {
+		return rt.GetSchema(), nil
 	}
 
-	if bt, ok := types.BasicTypes[field.Kind()]; ok {
+	if bt, ok := types.BigNumberTypes[field]; ok {
 		return bt.GetSchema(), nil
 	}
 
@@ -37,31 +84,111 @@ func getSchema(field reflect.Type, components *ComponentMetadata, nested bool) (
 		return spec.DateTimeProperty(), nil
 	}
 
+	if types.IsTextMarshaler(field) || types.IsJSONMarshaler(field) {
+		return addEnumValues(field, buildMarshalerSchema(field), options)
+	}
+
+	if types.IsBytes(field) {
+		return spec.StrFmtProperty("byte"), nil
+	}
+
+	if bt, ok := types.BasicTypes[field.Kind()]; ok {
+		if options.Int64AsString && types.IsInt64(field) {
+			return addEnumValues(field, types.Int64StringSchema(field.Kind()), options)
+		}
+
+		return addEnumValues(field, bt.GetSchema(), options)
+	}
+
+	if types.IsProtoMessage(field) {
+		return buildProtoSchema(field, components, nested)
+	}
+
 	if field.Kind() == reflect.Array {
-		return buildArraySchema(reflect.New(field).Elem(), components, nested)
+		return buildArraySchema(reflect.New(field).Elem(), components, nested, options)
 	}
 
 	if field.Kind() == reflect.Slice {
-		return buildSliceSchema(reflect.MakeSlice(field, 1, 1), components, nested)
+		return buildSliceSchema(reflect.MakeSlice(field, 1, 1), components, nested, options)
 	}
 
 	if field.Kind() == reflect.Map {
-		return buildMapSchema(reflect.MakeMap(field), components, nested)
+		return buildMapSchema(reflect.MakeMap(field), components, nested, options)
 	}
 
 	if field.Kind() == reflect.Struct || (field.Kind() == reflect.Pointer && field.Elem().Kind() == reflect.Struct) {
-		return buildStructSchema(field, components, nested)
+		return buildStructSchema(field, components, nested, options)
 	}
 
 	return nil, fmt.Errorf("%s was not a valid type", field.String())
 }
 
-func buildArraySchema(array reflect.Value, components *ComponentMetadata, nested bool) (*spec.Schema, error) {
+// newValue returns a pointer to a new zero value of the type, or for pointer types a new pointer,
+// so that optional interfaces implemented by the type can be checked for and called
+func newValue(field reflect.Type) interface{} {
+	if field.Kind() == reflect.Pointer {
+		return reflect.New(field.Elem()).Interface()
+	}
+
+	return reflect.New(field).Interface()
+}
+
+func buildMarshalerSchema(field reflect.Type) *spec.Schema {
+	if provider, ok := newValue(field).(SchemaProvider); ok {
+		if schema := provider.GetSchema(); schema != nil {
+			return schema
+		}
+	}
+
+	if types.IsTextMarshaler(field) {
+		return spec.StringProperty()
+	}
+
+	return new(spec.Schema)
+}
+
+func addEnumValues(field reflect.Type, schema *spec.Schema, options SchemaOptions) (*spec.Schema, error) {
+	provider, ok := newValue(field).(EnumProvider)
+
+	if !ok {
+		return schema, nil
+	}
+
+	values := provider.EnumValues()
+	enum := make([]interface{}, 0, len(values))
+
+	for _, value := range values {
+		if reflect.TypeOf(value) != field {
+			return nil, fmt.Errorf("enum value %v of type %s is not valid. Expected values of type %s", value, reflect.TypeOf(value), field.String())
+		}
+
+		if options.Int64AsString && types.IsInt64(field) && !types.IsTextMarshaler(field) && !types.IsJSONMarshaler(field) {
+			enum = append(enum, fmt.Sprintf("%d", value))
+			continue
+		}
+
+		// enum values are given in the form they are passed in, which is their JSON
+		marshalled, err := json.Marshal(value)
+
+		if err != nil {
+			return nil, fmt.Errorf("enum value %v of type %s is not valid. %s", value, field.String(), err.Error())
+		}
+
+		var enumValue interface{}
+		_ = json.Unmarshal(marshalled, &enumValue)
+
+		enum = append(enum, enumValue)
+	}
+
+	return schema.WithEnum(enum...), nil
+}
+
+func buildArraySchema(array reflect.Value, components *ComponentMetadata, nested bool, options SchemaOptions) (*spec.Schema, error) {
 	if array.Len() < 1 {
 		return nil, errors.New("arrays must have length greater than 0")
 	}
 
-	lowerSchema, err := getSchema(array.Index(0).Type(), components, nested)
+	lowerSchema, err := getSchema(array.Index(0).Type(), components, nested, options)
 
 	if err != nil {
 		return nil, err
@@ -70,12 +197,12 @@ func buildArraySchema(array reflect.Value, components *ComponentMetadata, nested
 	return spec.ArrayProperty(lowerSchema), nil
 }
 
-func buildSliceSchema(slice reflect.Value, components *ComponentMetadata, nested bool) (*spec.Schema, error) {
+func buildSliceSchema(slice reflect.Value, components *ComponentMetadata, nested bool, options SchemaOptions) (*spec.Schema, error) {
 	if slice.Len() < 1 {
 		slice = reflect.MakeSlice(slice.Type(), 1, 10)
 	}
 
-	lowerSchema, err := getSchema(slice.Index(0).Type(), components, nested)
+	lowerSchema, err := getSchema(slice.Index(0).Type(), components, nested, options)
 
 	if err != nil {
 		return nil, err
@@ -84,8 +211,8 @@ func buildSliceSchema(slice reflect.Value, components *ComponentMetadata, nested
 	return spec.ArrayProperty(lowerSchema), nil
 }
 
-func buildMapSchema(rmap reflect.Value, components *ComponentMetadata, nested bool) (*spec.Schema, error) {
-	lowerSchema, err := getSchema(rmap.Type().Elem(), components, nested)
+func buildMapSchema(rmap reflect.Value, components *ComponentMetadata, nested bool, options SchemaOptions) (*spec.Schema, error) {
+	lowerSchema, err := getSchema(rmap.Type().Elem(), components, nested, options)
 
 	if err != nil {
 		return nil, err
@@ -94,17 +221,61 @@ func buildMapSchema(rmap reflect.Value, components *ComponentMetadata, nested bo
 	return spec.MapProperty(lowerSchema), nil
 }
 
-func addComponentIfNotExists(obj reflect.Type, components *ComponentMetadata) error {
+// componentName returns the name of the component of a struct generated with the options
+func componentName(obj reflect.Type, options SchemaOptions) string {
+	if options.Int64AsString && containsInt64(obj, make(map[reflect.Type]bool)) {
+		return obj.Name() + Int64AsStringSuffix
+	}
+
+	return obj.Name()
+}
+
+// containsInt64 returns whether the schema of a type describes a 64 bit integer type, either
+// as the type itself or as part of an element or property of the type
+func containsInt64(field reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[field] {
+		return false
+	}
+
+	seen[field] = true
+
+	if _, ok := types.GetRegisteredType(field); ok {
+		return false
+	}
+
+	if _, ok := types.BigNumberTypes[field]; ok || field == types.TimeType || types.IsTextMarshaler(field) || types.IsJSONMarshaler(field) || types.IsProtoMessage(field) {
+		return false
+	}
+
+	switch field.Kind() {
+	case reflect.Pointer, reflect.Array, reflect.Slice, reflect.Map:
+		return containsInt64(field.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < field.NumField(); i++ {
+			if containsInt64(field.Field(i).Type, seen) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return types.IsInt64(field)
+}
+
+func addComponentIfNotExists(obj reflect.Type, components *ComponentMetadata, options SchemaOptions) error {
 	if obj.Kind() == reflect.Pointer {
 		obj = obj.Elem()
 	}
 
-	if _, ok := components.Schemas[obj.Name()]; ok {
+	name := componentName(obj, options)
+
+	if _, ok := components.Schemas[name]; ok {
 		return nil
 	}
 
 	schema := ObjectMetadata{}
-	schema.ID = obj.Name()
+	schema.ID = name
 	schema.Required = []string{}
 	schema.Properties = make(map[string]spec.Schema)
 	schema.AdditionalProperties = false
@@ -113,27 +284,27 @@ func addComponentIfNotExists(obj reflect.Type, components *ComponentMetadata) er
 		components.Schemas = make(map[string]ObjectMetadata)
 	}
 
-	components.Schemas[obj.Name()] = schema // lock up slot for cyclic
+	components.Schemas[name] = schema // lock up slot for cyclic
 
 	for i := 0; i < obj.NumField(); i++ {
-		err := getField(obj.Field(i), &schema, components)
+		err := getField(obj.Field(i), &schema, components, options)
 
 		if err != nil {
-			delete(components.Schemas, obj.Name())
+			delete(components.Schemas, name)
 			return err
 		}
 	}
 
-	components.Schemas[obj.Name()] = schema // include changes
+	components.Schemas[name] = schema // include changes
 
 	return nil
 }
 
-func getField(field reflect.StructField, schema *ObjectMetadata, components *ComponentMetadata) error {
+func getField(field reflect.StructField, schema *ObjectMetadata, components *ComponentMetadata, options SchemaOptions) error {
 	if field.Anonymous {
 		if field.Type.Kind() == reflect.Struct {
 			for i := 0; i < field.Type.NumField(); i++ {
-				err := getField(field.Type.Field(i), schema, components)
+				err := getField(field.Type.Field(i), schema, components, options)
 
 				if err != nil {
 					return err
@@ -177,12 +348,23 @@ func getField(field reflect.StructField, schema *ObjectMetadata, components *Com
 
 	var err error
 
-	propSchema, err := getSchema(field.Type, components, true)
+	propSchema, err := getSchema(field.Type, components, true, options)
 
 	if err != nil {
 		return err
 	}
 
+	if tag, ok := field.Tag.Lookup("validate"); ok {
+		// the schema may be shared by other properties so is copied before rules are added
+		ruled := *propSchema
+
+		if err := applyValidateTag(tag, &ruled); err != nil {
+			return fmt.Errorf("validate tag of field %s is not valid. %s", field.Name, err.Error())
+		}
+
+		propSchema = &ruled
+	}
+
 	if required {
 		schema.Required = append(schema.Required, name)
 	}
@@ -192,12 +374,12 @@ func getField(field reflect.StructField, schema *ObjectMetadata, components *Com
 	return nil
 }
 
-func buildStructSchema(obj reflect.Type, components *ComponentMetadata, nested bool) (*spec.Schema, error) {
+func buildStructSchema(obj reflect.Type, components *ComponentMetadata, nested bool, options SchemaOptions) (*spec.Schema, error) {
 	if obj.Kind() == reflect.Pointer {
 		obj = obj.Elem()
 	}
 
-	err := addComponentIfNotExists(obj, components)
+	err := addComponentIfNotExists(obj, components, options)
 
 	if err != nil {
 		return nil, err
@@ -209,5 +391,5 @@ func buildStructSchema(obj reflect.Type, components *ComponentMetadata, nested b
 		refPath = ""
 	}
 
-	return spec.RefSchema(refPath + obj.Name()), nil
+	return spec.RefSchema(refPath + componentName(obj, options)), nil
 }
@@ -4,8 +4,11 @@
 package metadata
 
 import (
+	"encoding/json"
 	"errors"
+	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/go-openapi/spec"
@@ -105,6 +108,55 @@ type badStruct struct {
 	Prop1 complex64
 }
 
+type assetStatus string
+
+func (as assetStatus) EnumValues() []interface{} {
+	return []interface{}{assetStatus("active"), assetStatus("closed")}
+}
+
+type assetLevel int
+
+func (al assetLevel) EnumValues() []interface{} {
+	return []interface{}{assetLevel(1), assetLevel(2)}
+}
+
+type badEnum string
+
+func (be badEnum) EnumValues() []interface{} {
+	return []interface{}{"active"}
+}
+
+type assetID string
+
+func (id assetID) MarshalText() ([]byte, error) {
+	return []byte("asset:" + id), nil
+}
+
+func (id *assetID) UnmarshalText(text []byte) error {
+	*id = assetID(strings.TrimPrefix(string(text), "asset:"))
+	return nil
+}
+
+type assetRef struct {
+	ID assetID
+}
+
+func (ar assetRef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ar.ID)
+}
+
+func (ar *assetRef) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &ar.ID)
+}
+
+type describedAssetRef struct {
+	assetRef
+}
+
+func (dar *describedAssetRef) GetSchema() *spec.Schema {
+	return spec.StringProperty().WithPattern("^asset:")
+}
+
 var badType = reflect.TypeOf(complex64(1))
 var badArrayType = reflect.TypeOf([1]complex64{})
 var badSliceType = reflect.TypeOf([]complex64{})
@@ -146,17 +198,17 @@ func TestBuildArraySchema(t *testing.T) {
 	var err error
 
 	zeroArr := [0]int{}
-	schema, err = buildArraySchema(reflect.ValueOf(zeroArr), nil, false)
+	schema, err = buildArraySchema(reflect.ValueOf(zeroArr), nil, false, SchemaOptions{})
 	assert.Equal(t, errors.New("arrays must have length greater than 0"), err, "should throw error when 0 length array passed")
 	assert.Nil(t, schema, "should not have returned a schema for zero array")
 
-	schema, err = buildArraySchema(reflect.ValueOf([1]complex128{}), nil, false)
-	_, expectedErr := getSchema(reflect.TypeOf(complex128(1)), nil, false)
+	schema, err = buildArraySchema(reflect.ValueOf([1]complex128{}), nil, false, SchemaOptions{})
+	_, expectedErr := getSchema(reflect.TypeOf(complex128(1)), nil, false, SchemaOptions{})
 	assert.Nil(t, schema, "spec should be nil when GetSchema fails for array")
 	assert.Equal(t, expectedErr, err, "should have same error as GetSchema for array")
 
-	schema, err = buildArraySchema(reflect.ValueOf([1]string{}), nil, false)
-	expectedLowerSchema, _ := getSchema(reflect.TypeOf(""), nil, false)
+	schema, err = buildArraySchema(reflect.ValueOf([1]string{}), nil, false, SchemaOptions{})
+	expectedLowerSchema, _ := getSchema(reflect.TypeOf(""), nil, false, SchemaOptions{})
 	require.NoError(t, err, "should not error for valid array")
 	assert.Equal(t, spec.ArrayProperty(expectedLowerSchema), schema, "should return array of lower schema")
 }
@@ -165,13 +217,13 @@ func TestBuildSliceSchema(t *testing.T) {
 	var schema *spec.Schema
 	var err error
 
-	schema, err = buildSliceSchema(reflect.ValueOf([]complex128{}), nil, false)
+	schema, err = buildSliceSchema(reflect.ValueOf([]complex128{}), nil, false, SchemaOptions{})
 	_, expectedErr := GetSchema(reflect.TypeOf(complex128(1)), nil)
 	assert.Nil(t, schema, "spec should be nil when GetSchema errors for slice")
 	assert.Equal(t, expectedErr, err, "should have same error as Getschema for slice")
 
-	schema, err = buildSliceSchema(reflect.ValueOf([]string{}), nil, false)
-	expectedLowerSchema, _ := getSchema(reflect.TypeOf(""), nil, false)
+	schema, err = buildSliceSchema(reflect.ValueOf([]string{}), nil, false, SchemaOptions{})
+	expectedLowerSchema, _ := getSchema(reflect.TypeOf(""), nil, false, SchemaOptions{})
 	require.NoError(t, err, "should not error for valid slice")
 	assert.Equal(t, spec.ArrayProperty(expectedLowerSchema), schema, "should return spec array of lower schema for slice")
 }
@@ -180,13 +232,13 @@ func TestBuildMapSchema(t *testing.T) {
 	var schema *spec.Schema
 	var err error
 
-	schema, err = buildMapSchema(reflect.ValueOf(make(map[string]complex128)), nil, false)
-	_, expectedErr := getSchema(reflect.TypeOf(complex128(1)), nil, false)
+	schema, err = buildMapSchema(reflect.ValueOf(make(map[string]complex128)), nil, false, SchemaOptions{})
+	_, expectedErr := getSchema(reflect.TypeOf(complex128(1)), nil, false, SchemaOptions{})
 	assert.Nil(t, schema, "spec should be nil when GetSchema errors for map")
 	assert.Equal(t, expectedErr, err, "should have same error as Getschema for map")
 
-	schema, err = buildMapSchema(reflect.ValueOf(make(map[string]string)), nil, false)
-	expectedLowerSchema, _ := getSchema(reflect.TypeOf(""), nil, false)
+	schema, err = buildMapSchema(reflect.ValueOf(make(map[string]string)), nil, false, SchemaOptions{})
+	expectedLowerSchema, _ := getSchema(reflect.TypeOf(""), nil, false, SchemaOptions{})
 	require.NoError(t, err, "should not error for valid map")
 	assert.Equal(t, spec.MapProperty(expectedLowerSchema), schema, "should return spec map of lower schema")
 }
@@ -204,30 +256,30 @@ func TestAddComponentIfNotExists(t *testing.T) {
 	components.Schemas = make(map[string]ObjectMetadata)
 	components.Schemas["simpleStruct"] = someObject
 
-	err = addComponentIfNotExists(reflect.TypeOf(simpleStruct{}), components)
+	err = addComponentIfNotExists(reflect.TypeOf(simpleStruct{}), components, SchemaOptions{})
 	require.NoError(t, err, "should return nil for error when component of name already exists")
 	assert.Len(t, components.Schemas, 1, "should not have added a new component when one already exists")
 	_, ok = components.Schemas["simpleStruct"].Properties["some property"]
 	assert.True(t, ok, "should not overwrite existing component")
 
-	err = addComponentIfNotExists(reflect.TypeOf(new(simpleStruct)), components)
+	err = addComponentIfNotExists(reflect.TypeOf(new(simpleStruct)), components, SchemaOptions{})
 	require.NoError(t, err, "should return nil for error when component of name already exists for pointer")
 	assert.Len(t, components.Schemas, 1, "should not have added a new component when one already exists for pointer")
 	_, ok = components.Schemas["simpleStruct"].Properties["some property"]
 	assert.True(t, ok, "should not overwrite existing component when already exists and pointer passed")
 
-	err = addComponentIfNotExists(reflect.TypeOf(badStruct{}), components)
+	err = addComponentIfNotExists(reflect.TypeOf(badStruct{}), components, SchemaOptions{})
 	_, expectedError := GetSchema(reflect.TypeOf(complex64(1)), components)
 	require.EqualError(t, err, expectedError.Error(), "should use the same error as GetSchema when GetSchema errors")
 
 	components.Schemas = nil
-	err = addComponentIfNotExists(reflect.TypeOf(simpleStruct{}), components)
+	err = addComponentIfNotExists(reflect.TypeOf(simpleStruct{}), components, SchemaOptions{})
 	require.NoError(t, err, "should not error when adding new component when schemas not initialised")
 	assert.Equal(t, components.Schemas["simpleStruct"], simpleStructMetadata, "should set correct metadata for new component when schemas not initialised")
 
 	delete(components.Schemas, "simpleStruct")
 	components.Schemas["otherStruct"] = someObject
-	err = addComponentIfNotExists(reflect.TypeOf(simpleStruct{}), components)
+	err = addComponentIfNotExists(reflect.TypeOf(simpleStruct{}), components, SchemaOptions{})
 	require.NoError(t, err, "should not error when adding new component")
 	assert.Equal(t, components.Schemas["simpleStruct"], simpleStructMetadata, "should set correct metadata for new component")
 	assert.Equal(t, components.Schemas["otherStruct"], someObject, "should not affect existing components")
@@ -240,25 +292,25 @@ func TestBuildStructSchema(t *testing.T) {
 	components := new(ComponentMetadata)
 	components.Schemas = make(map[string]ObjectMetadata)
 
-	schema, err = buildStructSchema(reflect.TypeOf(badStruct{}), components, false)
-	expectedErr := addComponentIfNotExists(reflect.TypeOf(badStruct{}), components)
+	schema, err = buildStructSchema(reflect.TypeOf(badStruct{}), components, false, SchemaOptions{})
+	expectedErr := addComponentIfNotExists(reflect.TypeOf(badStruct{}), components, SchemaOptions{})
 	assert.Nil(t, schema, "spec should be nil when buildStructSchema fails from addComponentIfNotExists")
 	require.Error(t, err, "error should not be nil")
 	assert.Equal(t, expectedErr, err, "should have same error as addComponentIfNotExists")
 
-	schema, err = buildStructSchema(reflect.TypeOf(simpleStruct{}), components, false)
+	schema, err = buildStructSchema(reflect.TypeOf(simpleStruct{}), components, false, SchemaOptions{})
 	require.NoError(t, err, "should not return error when struct is good")
 	assert.Equal(t, schema, spec.RefSchema("#/components/schemas/simpleStruct"), "should make schema ref to component")
 	_, ok := components.Schemas["simpleStruct"]
 	assert.True(t, ok, "should have added component")
 
-	schema, err = buildStructSchema(reflect.TypeOf(simpleStruct{}), components, true)
+	schema, err = buildStructSchema(reflect.TypeOf(simpleStruct{}), components, true, SchemaOptions{})
 	require.NoError(t, err, "should not return error when struct is good")
 	assert.Equal(t, schema, spec.RefSchema("simpleStruct"), "should make schema ref to component for nested ref")
 	_, ok = components.Schemas["simpleStruct"]
 	assert.True(t, ok, "should have added component for nested ref")
 
-	schema, err = buildStructSchema(reflect.TypeOf(new(simpleStruct)), components, false)
+	schema, err = buildStructSchema(reflect.TypeOf(new(simpleStruct)), components, false, SchemaOptions{})
 	require.NoError(t, err, "should not return error when pointer to struct is good")
 	assert.Equal(t, schema, spec.RefSchema("#/components/schemas/simpleStruct"), "should make schema ref to component")
 
@@ -278,22 +330,22 @@ func TestGetSchema(t *testing.T) {
 	assert.Nil(t, schema, "should return no schema for bad type")
 
 	schema, err = GetSchema(badArrayType, components)
-	_, expectedErr = buildArraySchema(reflect.New(badArrayType).Elem(), components, false)
+	_, expectedErr = buildArraySchema(reflect.New(badArrayType).Elem(), components, false, SchemaOptions{})
 	require.EqualError(t, err, expectedErr.Error(), "should return error when build array errors")
 	assert.Nil(t, schema, "should return no schema when build array errors")
 
 	schema, err = GetSchema(badSliceType, components)
-	_, expectedErr = buildSliceSchema(reflect.MakeSlice(badSliceType, 1, 1), components, false)
+	_, expectedErr = buildSliceSchema(reflect.MakeSlice(badSliceType, 1, 1), components, false, SchemaOptions{})
 	require.EqualError(t, err, expectedErr.Error(), "should return error when build slice errors")
 	assert.Nil(t, schema, "should return no schema when build slice errors")
 
 	schema, err = GetSchema(badMapItemType, components)
-	_, expectedErr = buildMapSchema(reflect.MakeMap(badMapItemType), components, false)
+	_, expectedErr = buildMapSchema(reflect.MakeMap(badMapItemType), components, false, SchemaOptions{})
 	require.EqualError(t, err, expectedErr.Error(), "should return error when build map errors")
 	assert.Nil(t, schema, "should return no schema when build map errors")
 
 	schema, err = GetSchema(reflect.TypeOf(badStruct{}), components)
-	_, expectedErr = buildStructSchema(reflect.TypeOf(badStruct{}), components, false)
+	_, expectedErr = buildStructSchema(reflect.TypeOf(badStruct{}), components, false, SchemaOptions{})
 	require.EqualError(t, err, expectedErr.Error(), "should return error when build struct errors")
 	assert.Nil(t, schema, "should return no schema when build struct errors")
 
@@ -315,6 +367,13 @@ func TestGetSchema(t *testing.T) {
 
 	// Test advanced types
 	testGetSchema(t, types.TimeType, spec.DateTimeProperty())
+	testGetSchema(t, types.BigIntType, spec.StringProperty().WithPattern(`^-?[0-9]+$`))
+	testGetSchema(t, types.BigRatType, spec.StringProperty().WithPattern(`^-?[0-9]+(\.[0-9]+|/[0-9]+)?$`))
+	testGetSchema(t, types.BigFloatType, spec.StringProperty().WithPattern(`^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`))
+	testGetSchema(t, reflect.TypeOf(assetID("")), spec.StringProperty())
+	testGetSchema(t, reflect.TypeOf(assetRef{}), new(spec.Schema))
+	testGetSchema(t, reflect.TypeOf(describedAssetRef{}), spec.StringProperty().WithPattern("^asset:"))
+	testGetSchema(t, reflect.TypeOf(&describedAssetRef{}), spec.StringProperty().WithPattern("^asset:"))
 
 	// Should return schema for arrays made of each of the valid types
 	stringArraySchema := spec.ArrayProperty(types.BasicTypes[reflect.String].GetSchema())
@@ -517,3 +576,85 @@ func TestGetSchema(t *testing.T) {
 	require.EqualError(t, err, "complex64 was not a valid type", "should return err when invalid object")
 	assert.Empty(t, components.Schemas, "should not have added new component")
 }
+
+func TestGetSchemaWithOptions(t *testing.T) {
+	type amounts struct {
+		Count   int      `json:"count"`
+		Balance uint64   `json:"balance"`
+		Small   int32    `json:"small"`
+		Total   *big.Int `json:"total"`
+	}
+
+	components := new(ComponentMetadata)
+	options := SchemaOptions{Int64AsString: true}
+
+	schema, err := GetSchemaWithOptions(reflect.TypeOf(int64(1)), components, options)
+	require.NoError(t, err)
+	assert.Equal(t, spec.StringProperty().WithPattern(`^-?[0-9]+$`), schema, "should describe int64 as string")
+
+	schema, err = GetSchemaWithOptions(reflect.TypeOf([]uint{}), components, options)
+	require.NoError(t, err)
+	assert.Equal(t, spec.ArrayProperty(spec.StringProperty().WithPattern(`^[0-9]+$`)), schema, "should describe uint in slice as string")
+
+	schema, err = GetSchemaWithOptions(reflect.TypeOf(int32(1)), components, options)
+	require.NoError(t, err)
+	assert.Equal(t, types.BasicTypes[reflect.Int32].GetSchema(), schema, "should not change schema of smaller integers")
+
+	schema, err = GetSchemaWithOptions(reflect.TypeOf(amounts{}), components, options)
+	require.NoError(t, err)
+	assert.Equal(t, spec.RefSchema("#/components/schemas/amounts_Int64AsString"), schema, "should reference component named with suffix")
+	properties := components.Schemas["amounts_Int64AsString"].Properties
+	assert.Equal(t, *spec.StringProperty().WithPattern(`^-?[0-9]+$`), properties["count"], "should describe int field as string")
+	assert.Equal(t, *spec.StringProperty().WithPattern(`^[0-9]+$`), properties["balance"], "should describe uint64 field as string")
+	assert.Equal(t, *types.BasicTypes[reflect.Int32].GetSchema(), properties["small"], "should not change schema of int32 field")
+	assert.Equal(t, *types.BigNumberTypes[types.BigIntType].GetSchema(), properties["total"], "should describe big int field as string")
+
+	schema, err = GetSchemaWithOptions(reflect.TypeOf(amounts{}), components, SchemaOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, spec.RefSchema("#/components/schemas/amounts"), schema, "should reference component without suffix when generated without options")
+	assert.Equal(t, *types.BasicTypes[reflect.Int].GetSchema(), components.Schemas["amounts"].Properties["count"], "should describe int field as number when generated without options")
+	assert.Equal(t, *spec.StringProperty().WithPattern(`^-?[0-9]+$`), components.Schemas["amounts_Int64AsString"].Properties["count"], "should keep int field as string in component generated with options")
+
+	type labels struct {
+		Name  string `json:"name"`
+		Small int32  `json:"small"`
+	}
+
+	schema, err = GetSchemaWithOptions(reflect.TypeOf(labels{}), components, options)
+	require.NoError(t, err)
+	assert.Equal(t, spec.RefSchema("#/components/schemas/labels"), schema, "should not add suffix to component of struct without 64 bit integers")
+}
+
+func TestGetSchemaEnum(t *testing.T) {
+	type asset struct {
+		Status assetStatus `json:"status"`
+	}
+
+	var schema *spec.Schema
+	var err error
+
+	components := new(ComponentMetadata)
+
+	schema, err = GetSchema(reflect.TypeOf(assetStatus("")), components)
+	require.NoError(t, err)
+	assert.Equal(t, spec.StringProperty().WithEnum("active", "closed"), schema, "should describe string enum values")
+
+	schema, err = GetSchema(reflect.TypeOf(assetLevel(0)), components)
+	require.NoError(t, err)
+	assert.Equal(t, spec.Int64Property().WithEnum(1.0, 2.0), schema, "should describe integer enum values")
+
+	schema, err = GetSchemaWithOptions(reflect.TypeOf(assetLevel(0)), components, SchemaOptions{Int64AsString: true})
+	require.NoError(t, err)
+	assert.Equal(t, spec.StringProperty().WithPattern(`^-?[0-9]+$`).WithEnum("1", "2"), schema, "should describe integer enum values as strings when int64 passed as string")
+
+	schema, err = GetSchema(reflect.TypeOf([]assetStatus{}), components)
+	require.NoError(t, err)
+	assert.Equal(t, spec.ArrayProperty(spec.StringProperty().WithEnum("active", "closed")), schema, "should describe enum values of slice items")
+
+	_, err = GetSchema(reflect.TypeOf(asset{}), components)
+	require.NoError(t, err)
+	assert.Equal(t, *spec.StringProperty().WithEnum("active", "closed"), components.Schemas["asset"].Properties["status"], "should describe enum values of struct properties")
+
+	_, err = GetSchema(reflect.TypeOf(badEnum("")), components)
+	assert.EqualError(t, err, "enum value active of type string is not valid. Expected values of type metadata.badEnum", "should error for enum value of wrong type")
+}
@@ -0,0 +1,156 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// validateKeywords the JSON schema keywords which can be set using the validate tag of a struct
+// field, mapped to the type of value the keyword applies to, if only one
+var validateKeywords = map[string]string{
+	"minimum":     "number",
+	"maximum":     "number",
+	"minLength":   "string",
+	"maxLength":   "string",
+	"pattern":     "string",
+	"format":      "string",
+	"minItems":    "array",
+	"maxItems":    "array",
+	"enum":        "",
+	"description": "",
+}
+
+// parseValidateTag splits the value of a validate tag into its keywords and their values. Rules are
+// separated by commas, so commas within a value, for example in a pattern, are kept as part of the
+// value when what follows them is not a keyword
+func parseValidateTag(tag string) ([][2]string, error) {
+	rules := [][2]string{}
+
+	for _, part := range strings.Split(tag, ",") {
+		keyword, value, found := strings.Cut(part, "=")
+		keyword = strings.TrimSpace(keyword)
+
+		if _, ok := validateKeywords[keyword]; found && ok {
+			rules = append(rules, [2]string{keyword, value})
+		} else if len(rules) > 0 {
+			rules[len(rules)-1][1] += "," + part
+		} else if strings.TrimSpace(part) != "" {
+			return nil, fmt.Errorf("%s is not a supported rule", strings.TrimSpace(part))
+		}
+	}
+
+	return rules, nil
+}
+
+// applyValidateTag sets the JSON schema keywords given in a validate tag on the schema of a property,
+// for example `validate:"minLength=1,maxLength=10,pattern=^[a-z]+$"`. Enum values are separated by |
+func applyValidateTag(tag string, schema *spec.Schema) error {
+	rules, err := parseValidateTag(tag)
+
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		keyword, value := rule[0], rule[1]
+
+		if appliesTo := validateKeywords[keyword]; appliesTo != "" && len(schema.Type) > 0 && !schemaHasType(schema, appliesTo) {
+			return fmt.Errorf("%s does not apply to values of type %s", keyword, schema.Type[0])
+		}
+
+		switch keyword {
+		case "minimum", "maximum":
+			num, err := strconv.ParseFloat(value, 64)
+
+			if err != nil {
+				return fmt.Errorf("%s must be a number", keyword)
+			}
+
+			if keyword == "minimum" {
+				schema.WithMinimum(num, false)
+			} else {
+				schema.WithMaximum(num, false)
+			}
+		case "minLength", "maxLength", "minItems", "maxItems":
+			num, err := strconv.ParseInt(value, 10, 64)
+
+			if err != nil || num < 0 {
+				return fmt.Errorf("%s must be a non-negative integer", keyword)
+			}
+
+			switch keyword {
+			case "minLength":
+				schema.WithMinLength(num)
+			case "maxLength":
+				schema.WithMaxLength(num)
+			case "minItems":
+				schema.WithMinItems(num)
+			case "maxItems":
+				schema.WithMaxItems(num)
+			}
+		case "pattern":
+			if _, err := regexp.Compile(value); err != nil {
+				return fmt.Errorf("pattern must be a regular expression. %s", err.Error())
+			}
+
+			schema.WithPattern(value)
+		case "format":
+			schema.Format = value
+		case "enum":
+			enum := []interface{}{}
+
+			for _, option := range strings.Split(value, "|") {
+				enumValue, err := parseEnumValue(option, schema)
+
+				if err != nil {
+					return err
+				}
+
+				enum = append(enum, enumValue)
+			}
+
+			schema.WithEnum(enum...)
+		case "description":
+			schema.WithDescription(value)
+		}
+	}
+
+	return nil
+}
+
+func schemaHasType(schema *spec.Schema, typ string) bool {
+	return schema.Type.Contains(typ) || (typ == "number" && schema.Type.Contains("integer"))
+}
+
+// parseEnumValue converts an enum value given in a validate tag to a value of the type of the schema
+func parseEnumValue(value string, schema *spec.Schema) (interface{}, error) {
+	var parsed interface{}
+	var err error
+
+	switch {
+	case schema.Type.Contains("integer"):
+		parsed, err = strconv.ParseInt(value, 10, 64)
+	case schema.Type.Contains("number"):
+		parsed, err = strconv.ParseFloat(value, 64)
+	case schema.Type.Contains("boolean"):
+		parsed, err = strconv.ParseBool(value)
+	case schema.Type.Contains("string"):
+		parsed = value
+	default:
+		return nil, errors.New("enum does not apply to values of the field")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("enum value %s is not a valid %s", value, schema.Type[0])
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,122 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// HELPERS
+// ================================
+
+type validatedAsset struct {
+	ID       string            `json:"id" validate:"minLength=1,maxLength=10,pattern=^[a-z]{1,3}[0-9]+$"`
+	Value    int               `json:"value" validate:"minimum=0,maximum=100,description=value of the asset"`
+	Price    float64           `json:"price" validate:"enum=0.5|1.5"`
+	Owner    string            `json:"owner" validate:"format=email,enum=a@example.com|b@example.com"`
+	Tags     []string          `json:"tags" validate:"minItems=1,maxItems=5"`
+	Labels   map[string]string `json:"labels" validate:"description=labels, as key value pairs"`
+	Next     *validatedAsset   `metadata:"next,optional" validate:"description=the next asset"`
+	Archived bool              `json:"archived" validate:"enum=false"`
+}
+
+// ================================
+// TESTS
+// ================================
+
+func TestParseValidateTag(t *testing.T) {
+	var rules [][2]string
+	var err error
+
+	rules, err = parseValidateTag("")
+	require.NoError(t, err)
+	assert.Empty(t, rules, "should return no rules for empty tag")
+
+	rules, err = parseValidateTag("minimum=1, maximum=2")
+	require.NoError(t, err)
+	assert.Equal(t, [][2]string{{"minimum", "1"}, {"maximum", "2"}}, rules, "should split rules on commas")
+
+	rules, err = parseValidateTag("pattern=^a{1,3}$,description=a, b or c,maxLength=3")
+	require.NoError(t, err)
+	assert.Equal(t, [][2]string{{"pattern", "^a{1,3}$"}, {"description", "a, b or c"}, {"maxLength", "3"}}, rules, "should keep commas not followed by a keyword in values")
+
+	_, err = parseValidateTag("required,minimum=1")
+	assert.EqualError(t, err, "required is not a supported rule", "should error for unknown rule")
+}
+
+func TestApplyValidateTag(t *testing.T) {
+	var schema *spec.Schema
+	var err error
+
+	schema = spec.StringProperty()
+	err = applyValidateTag("minLength=2,maxLength=4,pattern=^[a-z]+$,format=email,enum=ab|cd", schema)
+	require.NoError(t, err)
+	assert.Equal(t, spec.StrFmtProperty("email").WithMinLength(2).WithMaxLength(4).WithPattern("^[a-z]+$").WithEnum("ab", "cd"), schema, "should set string keywords")
+
+	schema = spec.Int64Property()
+	err = applyValidateTag("minimum=-1,maximum=1.5,enum=-1|0|1", schema)
+	require.NoError(t, err)
+	assert.Equal(t, spec.Int64Property().WithMinimum(-1, false).WithMaximum(1.5, false).WithEnum(int64(-1), int64(0), int64(1)), schema, "should set number keywords for integers")
+
+	schema = spec.ArrayProperty(spec.StringProperty())
+	err = applyValidateTag("minItems=1,maxItems=2,description=some items", schema)
+	require.NoError(t, err)
+	assert.Equal(t, spec.ArrayProperty(spec.StringProperty()).WithMinItems(1).WithMaxItems(2).WithDescription("some items"), schema, "should set array keywords")
+
+	schema = spec.RefSchema("someStruct")
+	err = applyValidateTag("description=a struct", schema)
+	require.NoError(t, err)
+	assert.Equal(t, spec.RefSchema("someStruct").WithDescription("a struct"), schema, "should set description of reference")
+
+	err = applyValidateTag("unknown=1", spec.StringProperty())
+	assert.EqualError(t, err, "unknown=1 is not a supported rule", "should error for unknown keyword")
+
+	err = applyValidateTag("minLength=1", spec.Int64Property())
+	assert.EqualError(t, err, "minLength does not apply to values of type integer", "should error for keyword not applying to type")
+
+	err = applyValidateTag("minimum=low", spec.Int64Property())
+	assert.EqualError(t, err, "minimum must be a number", "should error for minimum which is not a number")
+
+	err = applyValidateTag("maxLength=-1", spec.StringProperty())
+	assert.EqualError(t, err, "maxLength must be a non-negative integer", "should error for negative length")
+
+	err = applyValidateTag("pattern=[a-", spec.StringProperty())
+	assert.ErrorContains(t, err, "pattern must be a regular expression. ", "should error for invalid pattern")
+
+	err = applyValidateTag("enum=1|two", spec.Int64Property())
+	assert.EqualError(t, err, "enum value two is not a valid integer", "should error for enum value not of type")
+
+	err = applyValidateTag("enum=a", spec.ArrayProperty(nil))
+	assert.EqualError(t, err, "enum does not apply to values of the field", "should error for enum of type without values")
+}
+
+func TestGetSchemaValidateTag(t *testing.T) {
+	type badValidatedAsset struct {
+		Value int `validate:"pattern=^1$"`
+	}
+
+	components := new(ComponentMetadata)
+
+	_, err := GetSchema(reflect.TypeOf(validatedAsset{}), components)
+	require.NoError(t, err)
+
+	properties := components.Schemas["validatedAsset"].Properties
+	assert.Equal(t, *spec.StringProperty().WithMinLength(1).WithMaxLength(10).WithPattern("^[a-z]{1,3}[0-9]+$"), properties["id"], "should set rules of string property")
+	assert.Equal(t, *spec.Int64Property().WithMinimum(0, false).WithMaximum(100, false).WithDescription("value of the asset"), properties["value"], "should set rules of integer property")
+	assert.Equal(t, *spec.Float64Property().WithEnum(0.5, 1.5), properties["price"], "should set enum of number property")
+	assert.Equal(t, *spec.StrFmtProperty("email").WithEnum("a@example.com", "b@example.com"), properties["owner"], "should set format of string property")
+	assert.Equal(t, *spec.ArrayProperty(spec.StringProperty()).WithMinItems(1).WithMaxItems(5), properties["tags"], "should set rules of slice property")
+	assert.Equal(t, *spec.MapProperty(spec.StringProperty()).WithDescription("labels, as key value pairs"), properties["labels"], "should set description of map property")
+	assert.Equal(t, *spec.RefSchema("validatedAsset").WithDescription("the next asset"), properties["next"], "should set description of struct property")
+	assert.Equal(t, *spec.BooleanProperty().WithEnum(false), properties["archived"], "should set enum of boolean property")
+
+	_, err = GetSchema(reflect.TypeOf(badValidatedAsset{}), components)
+	assert.EqualError(t, err, "validate tag of field Value is not valid. pattern does not apply to values of type integer", "should error for invalid validate tag")
+}
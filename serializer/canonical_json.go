@@ -0,0 +1,207 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package serializer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// MarshalCanonicalJSON returns the JSON of a value in the canonical form defined by RFC 8785,
+// the JSON Canonicalization Scheme. The value is first marshalled using the standard Go JSON
+// marshaller, so JSON tags and custom marshallers are respected, and the output then
+// canonicalized. Contracts can use it when writing JSON to the world state so that every
+// endorsing peer writes identical bytes
+func MarshalCanonicalJSON(value interface{}) ([]byte, error) {
+	marshalled, err := json.Marshal(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return CanonicalizeJSON(marshalled)
+}
+
+// CanonicalizeJSON converts JSON to the canonical form defined by RFC 8785. Whitespace is
+// removed, object properties are sorted by the UTF-16 code units of their names, strings only
+// escape the characters JSON requires to be escaped and numbers are written as ECMAScript
+// would write them. As numbers are treated as IEEE 754 doubles integers with a magnitude
+// greater than 2^53 may lose precision
+func CanonicalizeJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+
+	err := decoder.Decode(&value)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to canonicalize JSON. %s", err.Error())
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("failed to canonicalize JSON. unexpected data after top-level value")
+	}
+
+	var canonical bytes.Buffer
+
+	err = writeCanonicalJSON(&canonical, value)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to canonicalize JSON. %s", err.Error())
+	}
+
+	return canonical.Bytes(), nil
+}
+
+func canonicalizeJSONString(str string) (string, error) {
+	canonical, err := CanonicalizeJSON([]byte(str))
+
+	return string(canonical), err
+}
+
+func writeCanonicalJSON(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		f, err := strconv.ParseFloat(string(v), 64)
+
+		if err != nil {
+			return fmt.Errorf("number %s cannot be represented as a double", v)
+		}
+
+		num, err := formatCanonicalNumber(f)
+
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString(num)
+	case string:
+		writeCanonicalString(buf, v)
+	case []interface{}:
+		buf.WriteByte('[')
+
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeCanonicalJSON(buf, elem); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Slice(keys, func(i, j int) bool {
+			return lessUTF16(keys[i], keys[j])
+		})
+
+		buf.WriteByte('{')
+
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			writeCanonicalString(buf, key)
+			buf.WriteByte(':')
+
+			if err := writeCanonicalJSON(buf, v[key]); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte('}')
+	}
+
+	return nil
+}
+
+// formatCanonicalNumber formats a number as ECMAScript's Number.prototype.toString would
+func formatCanonicalNumber(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("number %v is not valid JSON", f)
+	}
+
+	if f == 0 {
+		return "0", nil
+	}
+
+	if abs := math.Abs(f); abs >= 1e-6 && abs < 1e21 {
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+
+	// Go pads the exponent to two digits, ECMAScript does not
+	mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(f, 'e', -1, 64), "e")
+	exp, _ := strconv.Atoi(exponent)
+
+	if exp > 0 {
+		return fmt.Sprintf("%se+%d", mantissa, exp), nil
+	}
+
+	return fmt.Sprintf("%se%d", mantissa, exp), nil
+}
+
+func writeCanonicalString(buf *bytes.Buffer, str string) {
+	buf.WriteByte('"')
+
+	for _, r := range str {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+
+	buf.WriteByte('"')
+}
+
+func lessUTF16(a string, b string) bool {
+	unitsA := utf16.Encode([]rune(a))
+	unitsB := utf16.Encode([]rune(b))
+
+	for i := 0; i < len(unitsA) && i < len(unitsB); i++ {
+		if unitsA[i] != unitsB[i] {
+			return unitsA[i] < unitsB[i]
+		}
+	}
+
+	return len(unitsA) < len(unitsB)
+}
@@ -0,0 +1,84 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package serializer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// TESTS
+// ================================
+
+func TestCanonicalizeJSON(t *testing.T) {
+	var canonical []byte
+	var err error
+
+	canonical, err = CanonicalizeJSON([]byte(`{
+		"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+		"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+		"literals": [null, true, false]
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`, string(canonical), "should canonicalize RFC 8785 example")
+
+	canonical, err = CanonicalizeJSON([]byte(`{"\u20ac":1,"\r":2,"\ufb33":3,"1":4,"\ud83d\ude00":5,"\u0080":6,"\u00f6":7}`))
+	require.NoError(t, err)
+	assert.Equal(t, "{\"\\r\":2,\"1\":4,\"\u0080\":6,\"ö\":7,\"€\":1,\"😀\":5,\"\ufb33\":3}", string(canonical), "should sort properties by UTF-16 code units")
+
+	canonical, err = CanonicalizeJSON([]byte(`"<a & b>\u2028\b\t\f"`))
+	require.NoError(t, err)
+	assert.Equal(t, "\"<a & b>\u2028\\b\\t\\f\"", string(canonical), "should not escape HTML characters")
+
+	_, err = CanonicalizeJSON([]byte(`{"a":`))
+	assert.EqualError(t, err, "failed to canonicalize JSON. unexpected EOF", "should error for invalid JSON")
+
+	_, err = CanonicalizeJSON([]byte(`{} {}`))
+	assert.EqualError(t, err, "failed to canonicalize JSON. unexpected data after top-level value", "should error for trailing data")
+
+	_, err = CanonicalizeJSON([]byte(`1e400`))
+	assert.EqualError(t, err, "failed to canonicalize JSON. number 1e400 cannot be represented as a double", "should error for number out of range")
+}
+
+func TestFormatCanonicalNumber(t *testing.T) {
+	expected := map[float64]string{
+		0:                       "0",
+		math.Copysign(0, -1):    "0",
+		1:                       "1",
+		-1.5:                    "-1.5",
+		9007199254740993:        "9007199254740992",
+		1e21:                    "1e+21",
+		1e20:                    "100000000000000000000",
+		1e-6:                    "0.000001",
+		1e-7:                    "1e-7",
+		5e-324:                  "5e-324",
+		-1.7976931348623157e308: "-1.7976931348623157e+308",
+		295147905179352830000:   "295147905179352830000",
+	}
+
+	for f, str := range expected {
+		actual, err := formatCanonicalNumber(f)
+		require.NoError(t, err)
+		assert.Equal(t, str, actual, "should format %v as ECMAScript", f)
+	}
+
+	_, err := formatCanonicalNumber(math.NaN())
+	assert.EqualError(t, err, "number NaN is not valid JSON", "should error for NaN")
+
+	_, err = formatCanonicalNumber(math.Inf(1))
+	assert.EqualError(t, err, "number +Inf is not valid JSON", "should error for infinity")
+}
+
+func TestMarshalCanonicalJSON(t *testing.T) {
+	canonical, err := MarshalCanonicalJSON(map[string]interface{}{"b": "<b>", "a": []float64{1e21, 0.1}})
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":[1e+21,0.1],"b":"<b>"}`, string(canonical), "should marshal value in canonical form")
+
+	_, err = MarshalCanonicalJSON(make(chan int))
+	assert.EqualError(t, err, "json: unsupported type: chan int", "should error when value cannot be marshalled")
+}
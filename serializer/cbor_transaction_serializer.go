@@ -0,0 +1,601 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package serializer
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/hyperledger/fabric-contract-api-go/v2/internal/types"
+	"github.com/hyperledger/fabric-contract-api-go/v2/internal/utils"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/xeipuuv/gojsonschema"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// rfc3339Tag is the CBOR tag number for date-time strings
+const rfc3339Tag = 0
+
+var cborEncMode, _ = cbor.CoreDetEncOptions().EncMode()
+
+var cborDecMode, _ = cbor.DecOptions{
+	DefaultMapType: reflect.TypeOf(map[string]interface{}{}),
+	DupMapKey:      cbor.DupMapKeyEnforcedAPF,
+}.DecMode()
+
+// CBORSerializer an implementation of TransactionSerializer for handling conversion of values to and
+// from CBOR. Values are encoded in the deterministic core encoding of RFC 8949, with map keys sorted,
+// so that endorsing peers produce identical bytes for identical values. Byte slices are encoded as CBOR
+// byte strings, date-times as RFC3339 strings tagged as such, arbitrary precision numbers, registered
+// types and types marshalling themselves to text as the same strings as by the JSONSerializer, types
+// marshalling themselves to JSON in the form of that JSON, and structs as maps keyed by the same
+// property names as are used in the metadata of the struct, taken from its metadata and json tags.
+// Nil values of properties marked optional in the metadata tag are left out. Protobuf messages are
+// encoded in the form of their protobuf JSON
+type CBORSerializer struct{}
+
+// Encoding returns cbor, the name of the encoding produced by the serializer
+func (cs *CBORSerializer) Encoding() string {
+	return "cbor"
+}
+
+// FromString converts a parameter in the same way as FromBytes
+func (cs *CBORSerializer) FromString(param string, fieldType reflect.Type, paramMetadata *metadata.ParameterMetadata, components *metadata.ComponentMetadata) (reflect.Value, error) {
+	return cs.FromBytes([]byte(param), fieldType, paramMetadata, components)
+}
+
+// FromBytes takes a CBOR encoded parameter and converts it to a reflect value representing the goal data
+// type. If a parameter metadata is passed it will validate that the decoded value meets the rules specified
+// by that parameter's compiled schema
+func (cs *CBORSerializer) FromBytes(param []byte, fieldType reflect.Type, paramMetadata *metadata.ParameterMetadata, components *metadata.ComponentMetadata) (reflect.Value, error) {
+	var decoded interface{}
+
+	err := cborDecMode.Unmarshal(param, &decoded)
+
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("conversion error. value was not passed in expected format %s. %s", fieldType.String(), err.Error())
+	}
+
+	converted, err := fromCBORValue(decoded, fieldType)
+
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("conversion error. %s", err.Error())
+	}
+
+	if paramMetadata != nil {
+		err := validateCBORAgainstSchema(paramMetadata.Name, decoded, paramMetadata.CompiledSchema)
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
+	}
+
+	return converted, nil
+}
+
+// ToString converts a value in the same way as ToBytes
+func (cs *CBORSerializer) ToString(result reflect.Value, resultType reflect.Type, returns *metadata.ReturnMetadata, components *metadata.ComponentMetadata) (string, error) {
+	bytes, err := cs.ToBytes(result, resultType, returns, components)
+
+	return string(bytes), err
+}
+
+// ToBytes takes a reflect value, the type of what the value originally was, and returns its CBOR encoding,
+// or no bytes for a nil value. If a non nil return metadata is supplied it will validate that the value
+// matches the rules set out by the compiled schema
+func (cs *CBORSerializer) ToBytes(result reflect.Value, resultType reflect.Type, returns *metadata.ReturnMetadata, components *metadata.ComponentMetadata) ([]byte, error) {
+	if isNillableType(result.Kind()) && result.IsNil() {
+		return nil, nil
+	}
+
+	value, err := toCBORValue(result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if returns != nil {
+		err := validateCBORAgainstSchema("return", value, returns.CompiledSchema)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bytes, err := cborEncMode.Marshal(value)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s as CBOR. %s", resultType.String(), err.Error())
+	}
+
+	return bytes, nil
+}
+
+// toCBORValue converts a value to the generic values encoded by the CBOR library, so that the
+// serializer rather than the library decides how structs, date-times and byte slices are represented
+func toCBORValue(value reflect.Value) (interface{}, error) {
+	if isNillableType(value.Kind()) && value.IsNil() {
+		return nil, nil
+	}
+
+	typ := value.Type()
+
+	if scalar, ok := getStringScalar(typ, false); ok {
+		return scalar.Format(value), nil
+	}
+
+	if typ == types.TimeType {
+		return cbor.Tag{Number: rfc3339Tag, Content: value.Interface().(time.Time).Format(time.RFC3339Nano)}, nil
+	}
+
+	if types.IsTextMarshaler(typ) {
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s. %s", typ.String(), err.Error())
+		}
+
+		return string(text), nil
+	}
+
+	if types.IsJSONMarshaler(typ) {
+		marshalled, err := json.Marshal(value.Interface())
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s. %s", typ.String(), err.Error())
+		}
+
+		jsonValue, _ := decodeJSON(marshalled)
+
+		return fromJSONNumbers(jsonValue), nil
+	}
+
+	if types.IsBytes(typ) {
+		bytes := make([]byte, value.Len())
+		reflect.Copy(reflect.ValueOf(bytes), value)
+		return bytes, nil
+	}
+
+	if types.IsProtoMessage(typ) {
+		messageJSON, err := protojson.Marshal(value.Interface().(proto.Message))
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s message. %s", typ.String(), err.Error())
+		}
+
+		var protoValue interface{}
+		_ = json.Unmarshal(messageJSON, &protoValue)
+
+		return protoValue, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.Interface, reflect.Pointer:
+		return toCBORValue(value.Elem())
+	case reflect.Array, reflect.Slice:
+		values := make([]interface{}, value.Len())
+
+		for i := range values {
+			var err error
+			values[i], err = toCBORValue(value.Index(i))
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return values, nil
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("cannot marshal map with keys of type %s as CBOR", typ.Key().String())
+		}
+
+		values := make(map[string]interface{})
+		iter := value.MapRange()
+
+		for iter.Next() {
+			var err error
+			values[iter.Key().String()], err = toCBORValue(iter.Value())
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return values, nil
+	case reflect.Struct:
+		values := make(map[string]interface{})
+
+		err := forEachCBORField(value, func(name string, optional bool, field reflect.Value) error {
+			if optional && isNillableType(field.Kind()) && field.IsNil() {
+				return nil
+			}
+
+			var err error
+			values[name], err = toCBORValue(field)
+
+			return err
+		})
+
+		return values, err
+	}
+
+	return nil, fmt.Errorf("cannot marshal value of type %s as CBOR", typ.String())
+}
+
+// fromJSONNumbers converts the numbers in JSON decoded keeping numbers as they were written to
+// integers where they are integers, so that they are encoded as CBOR integers rather than floats
+func fromJSONNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return u
+		}
+
+		f, _ := v.Float64()
+		return f
+	case []interface{}:
+		for i := range v {
+			v[i] = fromJSONNumbers(v[i])
+		}
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = fromJSONNumbers(v[key])
+		}
+	}
+
+	return value
+}
+
+// fromCBORValue converts a generic value decoded from CBOR to a value of the goal type
+func fromCBORValue(decoded interface{}, typ reflect.Type) (reflect.Value, error) {
+	converted := reflect.New(typ).Elem()
+
+	err := setCBORValue(converted, decoded)
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return converted, nil
+}
+
+func setCBORValue(value reflect.Value, decoded interface{}) error {
+	typ := value.Type()
+
+	if decoded == nil {
+		value.SetZero()
+		return nil
+	}
+
+	mismatch := fmt.Errorf("value %v was not passed in expected format %s", decoded, typ.String())
+
+	if scalar, ok := getStringScalar(typ, false); ok {
+		str, ok := decoded.(string)
+
+		if !ok {
+			return mismatch
+		}
+
+		converted, err := scalar.Convert(str)
+
+		if err != nil {
+			return err
+		}
+
+		value.Set(converted)
+		return nil
+	}
+
+	if typ == types.TimeType {
+		// tagged date-times are decoded to time by the CBOR library
+		switch t := decoded.(type) {
+		case time.Time:
+			value.Set(reflect.ValueOf(t))
+		case string:
+			parsed, err := time.Parse(time.RFC3339, t)
+
+			if err != nil {
+				return err
+			}
+
+			value.Set(reflect.ValueOf(parsed))
+		default:
+			return mismatch
+		}
+
+		return nil
+	}
+
+	if types.IsTextMarshaler(typ) || types.IsJSONMarshaler(typ) {
+		target := value.Addr()
+
+		if typ.Kind() == reflect.Pointer {
+			value.Set(reflect.New(typ.Elem()))
+			target = value
+		}
+
+		if text, ok := decoded.(string); ok && types.IsTextMarshaler(typ) {
+			if err := target.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+				return fmt.Errorf("cannot convert passed value %s to %s. %s", text, typ.String(), err.Error())
+			}
+
+			return nil
+		}
+
+		marshalled, err := json.Marshal(decoded)
+
+		if err != nil || types.IsTextMarshaler(typ) {
+			return mismatch
+		}
+
+		if err := json.Unmarshal(marshalled, target.Interface()); err != nil {
+			return mismatch
+		}
+
+		return nil
+	}
+
+	if types.IsBytes(typ) {
+		bytes, ok := decoded.([]byte)
+
+		if !ok || (typ.Kind() == reflect.Array && len(bytes) != typ.Len()) {
+			return mismatch
+		}
+
+		if typ.Kind() == reflect.Slice {
+			value.Set(reflect.ValueOf(bytes).Convert(typ))
+		} else {
+			reflect.Copy(value, reflect.ValueOf(bytes))
+		}
+
+		return nil
+	}
+
+	if types.IsProtoMessage(typ) {
+		messageJSON, err := json.Marshal(decoded)
+
+		if err != nil {
+			return mismatch
+		}
+
+		message := reflect.New(typ.Elem())
+
+		if err := protojson.Unmarshal(messageJSON, message.Interface().(proto.Message)); err != nil {
+			return mismatch
+		}
+
+		value.Set(message)
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool, reflect.String:
+		if reflect.TypeOf(decoded).Kind() != typ.Kind() {
+			return mismatch
+		}
+
+		value.Set(reflect.ValueOf(decoded).Convert(typ))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+
+		switch num := decoded.(type) {
+		case int64:
+			i = num
+		case uint64:
+			if num > math.MaxInt64 {
+				return mismatch
+			}
+			i = int64(num)
+		default:
+			return mismatch
+		}
+
+		if value.OverflowInt(i) {
+			return mismatch
+		}
+
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		num, ok := decoded.(uint64)
+
+		if !ok || value.OverflowUint(num) {
+			return mismatch
+		}
+
+		value.SetUint(num)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+
+		switch num := decoded.(type) {
+		case float64:
+			f = num
+		case int64:
+			f = float64(num)
+		case uint64:
+			f = float64(num)
+		default:
+			return mismatch
+		}
+
+		if value.OverflowFloat(f) {
+			return mismatch
+		}
+
+		value.SetFloat(f)
+	case reflect.Interface:
+		if !reflect.TypeOf(decoded).AssignableTo(typ) {
+			return mismatch
+		}
+
+		value.Set(reflect.ValueOf(decoded))
+	case reflect.Pointer:
+		elem := reflect.New(typ.Elem())
+
+		if err := setCBORValue(elem.Elem(), decoded); err != nil {
+			return err
+		}
+
+		value.Set(elem)
+	case reflect.Array, reflect.Slice:
+		values, ok := decoded.([]interface{})
+
+		if !ok || (typ.Kind() == reflect.Array && len(values) != typ.Len()) {
+			return mismatch
+		}
+
+		if typ.Kind() == reflect.Slice {
+			value.Set(reflect.MakeSlice(typ, len(values), len(values)))
+		}
+
+		for i, v := range values {
+			if err := setCBORValue(value.Index(i), v); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		values, ok := decoded.(map[string]interface{})
+
+		if !ok || typ.Key().Kind() != reflect.String {
+			return mismatch
+		}
+
+		value.Set(reflect.MakeMapWithSize(typ, len(values)))
+
+		for k, v := range values {
+			elem := reflect.New(typ.Elem()).Elem()
+
+			if err := setCBORValue(elem, v); err != nil {
+				return err
+			}
+
+			value.SetMapIndex(reflect.ValueOf(k).Convert(typ.Key()), elem)
+		}
+	case reflect.Struct:
+		values, ok := decoded.(map[string]interface{})
+
+		if !ok {
+			return mismatch
+		}
+
+		return forEachCBORField(value, func(name string, _ bool, field reflect.Value) error {
+			if v, ok := values[name]; ok {
+				return setCBORValue(field, v)
+			}
+
+			return nil
+		})
+	default:
+		return mismatch
+	}
+
+	return nil
+}
+
+// forEachCBORField calls the function for each exported field of the struct, including those
+// of embedded structs, with the name of its property and whether it is optional. Names are taken
+// as for metadata, from the metadata tag of the field, then its json tag, then the name of the
+// field. Fields with a metadata tag of - are skipped
+func forEachCBORField(value reflect.Value, fn func(string, bool, reflect.Value) error) error {
+	typ := value.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		if field.Anonymous {
+			if field.Type.Kind() == reflect.Struct {
+				if err := forEachCBORField(value.Field(i), fn); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("metadata"), ",")
+		optional := false
+
+		for _, option := range strings.Split(options, ",") {
+			optional = optional || strings.TrimSpace(option) == "optional"
+		}
+
+		if unicode.IsLower([]rune(field.Name)[0]) || name == "-" {
+			continue
+		} else if name == "" {
+			name, _, _ = strings.Cut(field.Tag.Get("json"), ",")
+		}
+
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+
+		if err := fn(name, optional, value.Field(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateCBORAgainstSchema validates a generic CBOR value against a schema. Tagged date-times are
+// validated as their strings and byte strings as their base64 encoding, as they are described in metadata
+func validateCBORAgainstSchema(propName string, value interface{}, schema *gojsonschema.Schema) error {
+	toValidate := map[string]interface{}{propName: untagCBORValue(value)}
+
+	result, _ := schema.Validate(gojsonschema.NewGoLoader(toValidate))
+
+	if !result.Valid() {
+		return fmt.Errorf("value did not match schema:\n%s", utils.ValidateErrorsToString(result.Errors()))
+	}
+
+	return nil
+}
+
+func untagCBORValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case cbor.Tag:
+		return untagCBORValue(v.Content)
+	case []interface{}:
+		values := make([]interface{}, len(v))
+
+		for i, elem := range v {
+			values[i] = untagCBORValue(elem)
+		}
+
+		return values
+	case map[string]interface{}:
+		values := make(map[string]interface{}, len(v))
+
+		for k, elem := range v {
+			values[k] = untagCBORValue(elem)
+		}
+
+		return values
+	}
+
+	return value
+}
@@ -0,0 +1,253 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package serializer
+
+import (
+	"encoding/hex"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// ================================
+// HELPERS
+// ================================
+
+type cborEmbedded struct {
+	Owner string `json:"owner"`
+}
+
+type cborStruct struct {
+	cborEmbedded
+	ID       string            `metadata:"id" json:"identifier"`
+	Value    int               `json:"value"`
+	Data     []byte            `json:"data"`
+	Created  time.Time         `json:"created"`
+	Tags     map[string]string `json:"tags"`
+	Next     *cborStruct       `metadata:"next,optional"`
+	Ignored  string            `metadata:"-"`
+	private  string
+	Untagged bool
+}
+
+func testCBORRoundTrip(t *testing.T, value interface{}) {
+	t.Helper()
+
+	serializer := new(CBORSerializer)
+	typ := reflect.TypeOf(value)
+
+	bytes, err := serializer.ToBytes(reflect.ValueOf(value), typ, nil, nil)
+	require.NoError(t, err, "should encode %s", typ.String())
+
+	converted, err := serializer.FromBytes(bytes, typ, nil, nil)
+	require.NoError(t, err, "should decode %s", typ.String())
+	assert.Equal(t, value, converted.Interface(), "should decode to encoded value for %s", typ.String())
+}
+
+func mustCBOR(t *testing.T, value interface{}) []byte {
+	t.Helper()
+
+	bytes, err := cbor.Marshal(value)
+	require.NoError(t, err)
+
+	return bytes
+}
+
+// ================================
+// TESTS
+// ================================
+
+func TestCBORRoundTrip(t *testing.T) {
+	created := time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC)
+
+	testCBORRoundTrip(t, "some string")
+	testCBORRoundTrip(t, true)
+	testCBORRoundTrip(t, -123)
+	testCBORRoundTrip(t, int8(-8))
+	testCBORRoundTrip(t, int16(16))
+	testCBORRoundTrip(t, int32(-32))
+	testCBORRoundTrip(t, int64(-64))
+	testCBORRoundTrip(t, uint(1))
+	testCBORRoundTrip(t, uint8(8))
+	testCBORRoundTrip(t, uint16(16))
+	testCBORRoundTrip(t, uint32(32))
+	testCBORRoundTrip(t, uint64(18446744073709551615))
+	testCBORRoundTrip(t, float32(1.5))
+	testCBORRoundTrip(t, 1.1)
+	testCBORRoundTrip(t, created)
+	testCBORRoundTrip(t, []byte("some bytes"))
+	testCBORRoundTrip(t, [3]byte{1, 2, 3})
+	testCBORRoundTrip(t, []string{"a", "b"})
+	testCBORRoundTrip(t, [2]int{1, 2})
+	testCBORRoundTrip(t, map[string]int{"a": 1, "b": 2})
+	testCBORRoundTrip(t, cborStruct{
+		cborEmbedded: cborEmbedded{Owner: "Alice"},
+		ID:           "asset1",
+		Value:        10,
+		Data:         []byte{0, 1},
+		Created:      created,
+		Tags:         map[string]string{"colour": "blue"},
+		Next:         &cborStruct{ID: "asset2", Data: []byte{}, Created: created},
+		Untagged:     true,
+	})
+	testCBORRoundTrip(t, &cborStruct{ID: "asset1", Data: []byte{}, Created: created})
+	testCBORRoundTrip(t, newChaincodeSpec())
+	testCBORRoundTrip(t, hexID{0x0a, 0x1b})
+	testCBORRoundTrip(t, &hexID{0x0a, 0x1b})
+	testCBORRoundTrip(t, net.ParseIP("10.0.0.1"))
+	testCBORRoundTrip(t, point{1, 2})
+	testCBORRoundTrip(t, map[string]*point{"origin": {0, 0}})
+}
+
+func TestCBORToBytes(t *testing.T) {
+	var bytes []byte
+	var err error
+
+	serializer := new(CBORSerializer)
+
+	bytes, err = serializer.ToBytes(reflect.ValueOf([]byte("abc")), reflect.TypeOf([]byte{}), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "43616263", hex.EncodeToString(bytes), "should encode byte slices as byte strings")
+
+	bytes, err = serializer.ToBytes(reflect.ValueOf(map[string]int{"bb": 2, "a": 1, "c": 3}), reflect.TypeOf(map[string]int{}), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "a3616101616303626262", hex.EncodeToString(bytes)[:20], "should sort map keys canonically")
+
+	created := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	bytes, err = serializer.ToBytes(reflect.ValueOf(created), reflect.TypeOf(created), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, mustCBOR(t, cbor.Tag{Number: 0, Content: "2024-05-06T07:08:09Z"}), bytes, "should encode date-times as tagged RFC3339 strings")
+
+	bytes, err = serializer.ToBytes(reflect.ValueOf(hexID{0x0a, 0x1b}), reflect.TypeOf(hexID{}), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, mustCBOR(t, "0a1b"), bytes, "should encode types marshalling themselves to text as strings")
+
+	bytes, err = serializer.ToBytes(reflect.ValueOf(point{1, 2}), reflect.TypeOf(point{}), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, mustCBOR(t, []int{1, 2}), bytes, "should encode types marshalling themselves to JSON in the form of their JSON")
+
+	_, err = serializer.ToBytes(reflect.ValueOf(point{-1, 2}), reflect.TypeOf(point{}), nil, nil)
+	assert.ErrorContains(t, err, "failed to marshal serializer.point. ", "should error when JSON cannot be marshalled")
+
+	value := cborStruct{ID: "asset1", Data: []byte{}, private: "secret", Ignored: "ignored"}
+	bytes, err = serializer.ToBytes(reflect.ValueOf(value), reflect.TypeOf(value), nil, nil)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, cbor.Unmarshal(bytes, &decoded))
+	names := []string{}
+	for name := range decoded {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{"owner", "id", "value", "data", "created", "tags", "Untagged"}, names, "should name properties as in metadata")
+
+	first, _ := serializer.ToBytes(reflect.ValueOf(value), reflect.TypeOf(value), nil, nil)
+	second, _ := serializer.ToBytes(reflect.ValueOf(value), reflect.TypeOf(value), nil, nil)
+	assert.Equal(t, first, second, "should produce identical bytes for identical values")
+
+	bytes, err = serializer.ToBytes(reflect.ValueOf((*cborStruct)(nil)), reflect.TypeOf(&cborStruct{}), nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, bytes, "should return no bytes for nil value")
+
+	var iface interface{} = 5
+	bytes, err = serializer.ToBytes(reflect.ValueOf(&iface).Elem(), reflect.TypeOf(&iface).Elem(), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, mustCBOR(t, 5), bytes, "should encode value of interface")
+
+	_, err = serializer.ToBytes(reflect.ValueOf(map[int]string{1: "a"}), reflect.TypeOf(map[int]string{}), nil, nil)
+	assert.EqualError(t, err, "cannot marshal map with keys of type int as CBOR", "should error for map with non string keys")
+
+	_, err = serializer.ToBytes(reflect.ValueOf(make(chan int)), reflect.TypeOf(make(chan int)), nil, nil)
+	assert.EqualError(t, err, "cannot marshal value of type chan int as CBOR", "should error for unsupported type")
+
+	returnMetadata, _ := protoMetadata(t, "return", reflect.TypeOf(int8(1)))
+	bytes, err = serializer.ToBytes(reflect.ValueOf(int8(1)), reflect.TypeOf(int8(1)), &metadata.ReturnMetadata{CompiledSchema: returnMetadata.CompiledSchema}, nil)
+	require.NoError(t, err, "should not error for value matching schema")
+	assert.Equal(t, []byte{1}, bytes)
+
+	structMetadata, _ := protoMetadata(t, "return", reflect.TypeOf(cborStruct{}))
+	_, err = serializer.ToBytes(reflect.ValueOf(cborStruct{ID: "asset1", Data: []byte{}}), reflect.TypeOf(cborStruct{}), &metadata.ReturnMetadata{CompiledSchema: structMetadata.CompiledSchema}, nil)
+	assert.ErrorContains(t, err, "value did not match schema", "should error for value missing required property")
+}
+
+func TestCBORFromBytes(t *testing.T) {
+	var value reflect.Value
+	var err error
+
+	serializer := new(CBORSerializer)
+
+	_, err = serializer.FromBytes([]byte{0xff}, reflect.TypeOf(1), nil, nil)
+	assert.ErrorContains(t, err, "conversion error. value was not passed in expected format int.", "should error for invalid CBOR")
+
+	_, err = serializer.FromBytes(mustCBOR(t, "abc"), reflect.TypeOf(1), nil, nil)
+	assert.EqualError(t, err, "conversion error. value abc was not passed in expected format int", "should error for wrong type")
+
+	_, err = serializer.FromBytes(mustCBOR(t, 300), reflect.TypeOf(int8(1)), nil, nil)
+	assert.EqualError(t, err, "conversion error. value 300 was not passed in expected format int8", "should error for overflowing value")
+
+	_, err = serializer.FromBytes(mustCBOR(t, -1), reflect.TypeOf(uint(1)), nil, nil)
+	assert.EqualError(t, err, "conversion error. value -1 was not passed in expected format uint", "should error for negative unsigned value")
+
+	_, err = serializer.FromBytes(mustCBOR(t, []byte{1, 2}), reflect.TypeOf([3]byte{}), nil, nil)
+	assert.ErrorContains(t, err, "was not passed in expected format [3]uint8", "should error for array of wrong length")
+
+	_, err = serializer.FromBytes(mustCBOR(t, "not a date"), reflect.TypeOf(time.Time{}), nil, nil)
+	assert.ErrorContains(t, err, "conversion error. parsing time", "should error for invalid date-time")
+
+	value, err = serializer.FromBytes(mustCBOR(t, "2024-05-06T07:08:09Z"), reflect.TypeOf(time.Time{}), nil, nil)
+	require.NoError(t, err, "should accept untagged date-time strings")
+	assert.Equal(t, time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC), value.Interface())
+
+	value, err = serializer.FromBytes(mustCBOR(t, 2), reflect.TypeOf(1.0), nil, nil)
+	require.NoError(t, err, "should accept integers for floats")
+	assert.Equal(t, 2.0, value.Interface())
+
+	value, err = serializer.FromBytes(mustCBOR(t, map[string]interface{}{"id": "asset1", "unknown": 1}), reflect.TypeOf(&cborStruct{}), nil, nil)
+	require.NoError(t, err, "should ignore unknown properties without metadata")
+	assert.Equal(t, &cborStruct{ID: "asset1"}, value.Interface())
+
+	value, err = serializer.FromBytes(mustCBOR(t, []interface{}{"a", 1}), reflect.TypeOf((*interface{})(nil)).Elem(), nil, nil)
+	require.NoError(t, err, "should decode generic values for interfaces")
+	assert.Equal(t, []interface{}{"a", uint64(1)}, value.Interface())
+
+	_, err = serializer.FromBytes(mustCBOR(t, map[string]interface{}{"a": "b"}), reflect.TypeOf(newChaincodeSpec()), nil, nil)
+	assert.ErrorContains(t, err, "was not passed in expected format *peer.ChaincodeSpec", "should error for invalid message")
+
+	paramMetadata, _ := protoMetadata(t, "param1", reflect.TypeOf(int8(1)))
+	value, err = serializer.FromBytes(mustCBOR(t, 5), reflect.TypeOf(int8(1)), paramMetadata, nil)
+	require.NoError(t, err, "should not error for value matching schema")
+	assert.Equal(t, int8(5), value.Interface())
+
+	structMetadata, _ := protoMetadata(t, "param1", reflect.TypeOf(cborStruct{}))
+	_, err = serializer.FromBytes(mustCBOR(t, map[string]interface{}{"id": "asset1", "unknown": 1}), reflect.TypeOf(cborStruct{}), structMetadata, nil)
+	assert.ErrorContains(t, err, "value did not match schema", "should validate decoded value against schema")
+
+	created := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	valid := cborStruct{cborEmbedded: cborEmbedded{Owner: "Bob"}, ID: "asset1", Data: []byte{1}, Created: created, Tags: map[string]string{}}
+	bytes, _ := serializer.ToBytes(reflect.ValueOf(valid), reflect.TypeOf(valid), nil, nil)
+	value, err = serializer.FromBytes(bytes, reflect.TypeOf(valid), structMetadata, nil)
+	require.NoError(t, err, "should validate tagged date-times and byte strings in their metadata form")
+	assert.Equal(t, valid, value.Interface())
+}
+
+func TestCBORString(t *testing.T) {
+	serializer := new(CBORSerializer)
+	message := newChaincodeSpec()
+
+	str, err := serializer.ToString(reflect.ValueOf(message), reflect.TypeOf(message), nil, nil)
+	require.NoError(t, err)
+
+	value, err := serializer.FromString(str, reflect.TypeOf(message), nil, nil)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(message, value.Interface().(*peer.ChaincodeSpec)), "should convert strings as bytes")
+
+	assert.Equal(t, "cbor", serializer.Encoding(), "should name CBOR encoding")
+}
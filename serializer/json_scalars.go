@@ -0,0 +1,411 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package serializer
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hyperledger/fabric-contract-api-go/v2/internal/types"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+)
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// stringScalar converts values of a type which are passed as strings
+type stringScalar interface {
+	Convert(string) (reflect.Value, error)
+	Format(reflect.Value) string
+}
+
+type int64Scalar struct {
+	typ reflect.Type
+}
+
+func (is int64Scalar) Convert(value string) (reflect.Value, error) {
+	converted, err := types.BasicTypes[is.typ.Kind()].Convert(value)
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return converted.Convert(is.typ), nil
+}
+
+func (is int64Scalar) Format(value reflect.Value) string {
+	if value.CanUint() {
+		return strconv.FormatUint(value.Uint(), 10)
+	}
+
+	return strconv.FormatInt(value.Int(), 10)
+}
+
+// getStringScalar returns the conversions of a type whose values are passed as strings, being
+// registered types, arbitrary precision numbers and, if int64AsString, the 64 bit integer types
+func getStringScalar(typ reflect.Type, int64AsString bool) (stringScalar, bool) {
+	if rt, ok := types.GetRegisteredType(typ); ok {
+		return rt, true
+	}
+
+	if bt, ok := types.BigNumberTypes[typ]; ok {
+		return bt, true
+	}
+
+	if int64AsString && types.IsInt64(typ) && !typ.Implements(jsonMarshalerType) && !typ.Implements(textMarshalerType) {
+		return int64Scalar{typ}, true
+	}
+
+	return nil, false
+}
+
+// containsStringScalars returns whether values of the type may contain values which are passed
+// as strings, so that the JSON of those values needs converting
+func containsStringScalars(typ reflect.Type, int64AsString bool, visited map[reflect.Type]bool) bool {
+	if _, ok := getStringScalar(typ, int64AsString); ok {
+		return true
+	}
+
+	if visited[typ] || typ.Implements(jsonMarshalerType) || typ.Implements(textMarshalerType) {
+		return false
+	}
+
+	visited[typ] = true
+
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Array, reflect.Slice, reflect.Map:
+		return containsStringScalars(typ.Elem(), int64AsString, visited)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if containsStringScalars(typ.Field(i).Type, int64AsString, visited) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// MarshalJSONWithOptions returns the JSON form of the value with the values within it which are
+// passed as strings, being registered types, arbitrary precision numbers and, if Int64AsString is
+// set in the options, 64 bit integers, formatted as those strings. The JSON then matches the
+// schema generated for the type of the value using metadata.GetSchemaWithOptions with the options
+func MarshalJSONWithOptions(value interface{}, options metadata.SchemaOptions) ([]byte, error) {
+	marshalled, err := json.Marshal(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	str, err := quoteJSONScalars(string(marshalled), reflect.ValueOf(value), options.Int64AsString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(str), nil
+}
+
+// quoteJSONScalars converts the JSON produced by the standard Go JSON marshaller for the value, for
+// values within it which are passed as strings, to those strings. The strings are formatted from
+// the value itself, as the JSON of a type registered with a converter may not describe its values
+func quoteJSONScalars(data string, value reflect.Value, int64AsString bool) (string, error) {
+	for value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	if !value.IsValid() || !containsStringScalars(value.Type(), int64AsString, make(map[reflect.Type]bool)) {
+		return data, nil
+	}
+
+	tree, err := decodeJSON([]byte(data))
+
+	if err != nil {
+		return "", err
+	}
+
+	return encodeJSON(quoteScalarsInTree(tree, value, int64AsString))
+}
+
+func quoteScalarsInTree(tree interface{}, value reflect.Value, int64AsString bool) interface{} {
+	if scalar, ok := getStringScalar(value.Type(), int64AsString); ok {
+		if isNillableType(value.Kind()) && value.IsNil() {
+			return tree
+		}
+
+		return scalar.Format(value)
+	}
+
+	if value.Type().Implements(jsonMarshalerType) || value.Type().Implements(textMarshalerType) {
+		return tree
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		if !value.IsNil() {
+			return quoteScalarsInTree(tree, value.Elem(), int64AsString)
+		}
+	case reflect.Array, reflect.Slice:
+		if elems, ok := tree.([]interface{}); ok {
+			for i := 0; i < len(elems) && i < value.Len(); i++ {
+				elems[i] = quoteScalarsInTree(elems[i], value.Index(i), int64AsString)
+			}
+		}
+	case reflect.Map:
+		if props, ok := tree.(map[string]interface{}); ok {
+			iter := value.MapRange()
+
+			for iter.Next() {
+				if key, ok := jsonMapKey(iter.Key()); ok {
+					if prop, ok := props[key]; ok {
+						props[key] = quoteScalarsInTree(prop, iter.Value(), int64AsString)
+					}
+				}
+			}
+		}
+	case reflect.Struct:
+		if props, ok := tree.(map[string]interface{}); ok {
+			forEachJSONField(value.Type(), func(index []int, name string) {
+				if prop, ok := props[name]; ok {
+					props[name] = quoteScalarsInTree(prop, value.FieldByIndex(index), int64AsString)
+				}
+			})
+		}
+	}
+
+	return tree
+}
+
+// unmarshalJSONScalars unmarshals JSON for a value of the type, converting the strings in the
+// place of values passed as strings. Those strings are removed from the JSON before it is passed
+// to the standard Go JSON unmarshaller and the values converted from them set afterwards, so
+// that the JSON of a type registered with a converter need not describe its values
+func unmarshalJSONScalars(data string, typ reflect.Type, int64AsString bool) (reflect.Value, error) {
+	tree, err := decodeJSON([]byte(data))
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	stripped, err := decodeJSON([]byte(data))
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	strippedJSON, err := encodeJSON(stripScalarsFromTree(stripped, typ, int64AsString))
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	obj := reflect.New(typ)
+
+	if err := json.Unmarshal([]byte(strippedJSON), obj.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+
+	if err := setScalarsFromTree(tree, obj.Elem(), int64AsString); err != nil {
+		return reflect.Value{}, err
+	}
+
+	return obj.Elem(), nil
+}
+
+func stripScalarsFromTree(tree interface{}, typ reflect.Type, int64AsString bool) interface{} {
+	if _, ok := getStringScalar(typ, int64AsString); ok {
+		if _, ok := tree.(string); ok {
+			return nil
+		}
+
+		return tree
+	}
+
+	if typ.Implements(jsonMarshalerType) || typ.Implements(textMarshalerType) {
+		return tree
+	}
+
+	switch typ.Kind() {
+	case reflect.Pointer:
+		return stripScalarsFromTree(tree, typ.Elem(), int64AsString)
+	case reflect.Array, reflect.Slice:
+		if elems, ok := tree.([]interface{}); ok {
+			for i := range elems {
+				elems[i] = stripScalarsFromTree(elems[i], typ.Elem(), int64AsString)
+			}
+		}
+	case reflect.Map:
+		if props, ok := tree.(map[string]interface{}); ok {
+			for key := range props {
+				props[key] = stripScalarsFromTree(props[key], typ.Elem(), int64AsString)
+			}
+		}
+	case reflect.Struct:
+		if props, ok := tree.(map[string]interface{}); ok {
+			forEachJSONField(typ, func(index []int, name string) {
+				if prop, ok := props[name]; ok {
+					props[name] = stripScalarsFromTree(prop, typ.FieldByIndex(index).Type, int64AsString)
+				}
+			})
+		}
+	}
+
+	return tree
+}
+
+func setScalarsFromTree(tree interface{}, value reflect.Value, int64AsString bool) error {
+	if scalar, ok := getStringScalar(value.Type(), int64AsString); ok {
+		str, ok := tree.(string)
+
+		if !ok {
+			return nil
+		}
+
+		converted, err := scalar.Convert(str)
+
+		if err != nil {
+			return err
+		}
+
+		value.Set(converted)
+		return nil
+	}
+
+	if value.Type().Implements(jsonMarshalerType) || value.Type().Implements(textMarshalerType) {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		if tree == nil {
+			return nil
+		}
+
+		// pointers to values passed as strings are left nil as their strings are removed
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+
+		return setScalarsFromTree(tree, value.Elem(), int64AsString)
+	case reflect.Array, reflect.Slice:
+		if elems, ok := tree.([]interface{}); ok {
+			for i := 0; i < len(elems) && i < value.Len(); i++ {
+				if err := setScalarsFromTree(elems[i], value.Index(i), int64AsString); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.Map:
+		if props, ok := tree.(map[string]interface{}); ok {
+			for _, key := range value.MapKeys() {
+				name, ok := jsonMapKey(key)
+
+				if !ok {
+					continue
+				}
+
+				if prop, ok := props[name]; ok {
+					// map elements cannot be set in place so are copied and put back
+					elem := reflect.New(value.Type().Elem()).Elem()
+					elem.Set(value.MapIndex(key))
+
+					if err := setScalarsFromTree(prop, elem, int64AsString); err != nil {
+						return err
+					}
+
+					value.SetMapIndex(key, elem)
+				}
+			}
+		}
+	case reflect.Struct:
+		if props, ok := tree.(map[string]interface{}); ok {
+			var err error
+
+			forEachJSONField(value.Type(), func(index []int, name string) {
+				if prop, ok := props[name]; ok && err == nil {
+					err = setScalarsFromTree(prop, value.FieldByIndex(index), int64AsString)
+				}
+			})
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeJSON decodes JSON keeping numbers as they were written
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	err := decoder.Decode(&value)
+
+	return value, err
+}
+
+func encodeJSON(value interface{}) (string, error) {
+	var encoded bytes.Buffer
+
+	if err := json.NewEncoder(&encoded).Encode(value); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(encoded.String(), "\n"), nil
+}
+
+// jsonMapKey returns the property name the standard Go JSON marshaller uses for a map key
+func jsonMapKey(key reflect.Value) (string, bool) {
+	if key.Kind() == reflect.String {
+		return key.String(), true
+	}
+
+	if marshaler, ok := key.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err == nil
+	}
+
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), true
+	}
+
+	return "", false
+}
+
+// forEachJSONField calls visit with the index and property name of each field of the struct type
+// named as by the standard Go JSON marshaller, including the fields of embedded structs. Fields
+// tagged to be marshalled as strings already are skipped
+func forEachJSONField(typ reflect.Type, visit func(index []int, name string)) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			forEachJSONField(field.Type, func(index []int, name string) {
+				visit(append([]int{i}, index...), name)
+			})
+
+			continue
+		}
+
+		if unicode.IsLower([]rune(field.Name)[0]) || name == "-" || strings.Contains(","+options+",", ",string,") {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		visit(field.Index, name)
+	}
+}
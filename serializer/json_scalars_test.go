@@ -0,0 +1,235 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package serializer
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ================================
+// HELPERS
+// ================================
+
+type Embedded struct {
+	Supply *big.Int `json:"supply"`
+}
+
+type quotedStruct struct {
+	Quoted int64 `json:"quoted,string"`
+}
+
+type tokenStruct struct {
+	Embedded
+	Balance  *big.Int            `json:"balance"`
+	Rate     *big.Rat            `json:"rate"`
+	Count    int64               `json:"count"`
+	Small    int32               `json:"small"`
+	Expiry   time.Duration       `json:"expiry"`
+	History  []*big.Int          `json:"history"`
+	Accounts map[string]*big.Int `json:"accounts"`
+	Next     *tokenStruct        `json:"next"`
+	Ignored  int64               `json:"-" metadata:"-"`
+}
+
+func newTokenStruct() *tokenStruct {
+	balance, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	return &tokenStruct{
+		Embedded: Embedded{Supply: big.NewInt(1000)},
+		Balance:  balance,
+		Rate:     big.NewRat(1, 3),
+		Count:    9007199254740993,
+		Small:    7,
+		Expiry:   time.Second,
+		History:  []*big.Int{big.NewInt(1), big.NewInt(2)},
+		Accounts: map[string]*big.Int{"alice": big.NewInt(3)},
+	}
+}
+
+// ================================
+// TESTS
+// ================================
+
+func TestContainsStringNumbers(t *testing.T) {
+	assert.True(t, containsStringScalars(reflect.TypeOf(new(big.Int)), false, map[reflect.Type]bool{}), "should be true for big int")
+	assert.True(t, containsStringScalars(reflect.TypeOf(tokenStruct{}), false, map[reflect.Type]bool{}), "should be true for struct containing big int")
+	assert.False(t, containsStringScalars(reflect.TypeOf([]int64{}), false, map[reflect.Type]bool{}), "should be false for int64 when not passed as strings")
+	assert.True(t, containsStringScalars(reflect.TypeOf([]int64{}), true, map[reflect.Type]bool{}), "should be true for int64 when passed as strings")
+	assert.False(t, containsStringScalars(reflect.TypeOf(simpleStruct{}), true, map[reflect.Type]bool{}), "should be false for struct without numbers")
+	assert.False(t, containsStringScalars(reflect.TypeOf(time.Time{}), true, map[reflect.Type]bool{}), "should be false for types with their own JSON")
+}
+
+func TestQuoteJSONScalars(t *testing.T) {
+	var str string
+	var err error
+
+	str, err = quoteJSONScalars(`{"prop1":"a"}`, reflect.ValueOf(simpleStruct{Prop1: "a"}), true)
+	require.NoError(t, err)
+	assert.Equal(t, `{"prop1":"a"}`, str, "should return JSON unchanged when type has no numbers to convert")
+
+	token := newTokenStruct()
+	token.Next = &tokenStruct{Balance: big.NewInt(3)}
+	marshalled, _ := json.Marshal(token)
+
+	str, err = quoteJSONScalars(string(marshalled), reflect.ValueOf(token), true)
+	require.NoError(t, err)
+	assert.Contains(t, str, `"balance":"123456789012345678901234567890"`, "should quote big ints")
+	assert.Contains(t, str, `"rate":"1/3"`, "should quote big rats")
+	assert.Contains(t, str, `"count":"9007199254740993"`, "should quote int64s")
+	assert.Contains(t, str, `"small":7`, "should not quote smaller integers")
+	assert.Contains(t, str, `"history":["1","2"]`, "should quote values in slices")
+	assert.Contains(t, str, `"accounts":{"alice":"3"}`, "should quote values in maps")
+	assert.Contains(t, str, `"supply":"1000"`, "should quote values in embedded structs")
+	assert.Contains(t, str, `"next":{"accounts":null,"balance":"3"`, "should quote values in nested structs leaving nil values")
+
+	var boxed interface{} = map[int]int64{1: 2}
+	str, err = quoteJSONScalars(`{"1":2}`, reflect.ValueOf(&boxed).Elem(), true)
+	require.NoError(t, err)
+	assert.Equal(t, `{"1":"2"}`, str, "should quote values of interfaces using map keys as marshalled")
+
+	str, err = quoteJSONScalars(`{"quoted":"5"}`, reflect.ValueOf(quotedStruct{Quoted: 5}), true)
+	require.NoError(t, err)
+	assert.Equal(t, `{"quoted":"5"}`, str, "should leave fields marshalled as strings")
+
+	_, err = quoteJSONScalars(`{`, reflect.ValueOf(tokenStruct{}), true)
+	assert.Error(t, err, "should error for invalid JSON")
+}
+
+func TestMarshalJSONWithOptions(t *testing.T) {
+	bytes, err := MarshalJSONWithOptions(map[string]int64{"count": 1}, metadata.SchemaOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"count":1}`, string(bytes), "should not quote int64s without option")
+
+	bytes, err = MarshalJSONWithOptions(map[string]int64{"count": 1}, metadata.SchemaOptions{Int64AsString: true})
+	require.NoError(t, err)
+	assert.Equal(t, `{"count":"1"}`, string(bytes), "should quote int64s with option")
+
+	bytes, err = MarshalJSONWithOptions(big.NewInt(3), metadata.SchemaOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, `"3"`, string(bytes), "should quote big numbers")
+
+	_, err = MarshalJSONWithOptions(make(chan int), metadata.SchemaOptions{})
+	assert.Error(t, err, "should error when value cannot be marshalled")
+}
+
+func TestUnmarshalJSONScalars(t *testing.T) {
+	value, err := unmarshalJSONScalars(`{"balance":"12345678901234567890","count":"1","small":2,"history":["1",2,null],"accounts":{"alice":"3"},"supply":"4"}`, reflect.TypeOf(tokenStruct{}), true)
+	require.NoError(t, err)
+	token := value.Interface().(tokenStruct)
+	assert.Equal(t, "12345678901234567890", token.Balance.String(), "should unquote big ints")
+	assert.Equal(t, int64(1), token.Count, "should unquote int64s")
+	assert.Equal(t, int32(2), token.Small, "should unmarshal other values")
+	assert.Equal(t, []*big.Int{big.NewInt(1), big.NewInt(2), nil}, token.History, "should unquote values in slices accepting numbers")
+	assert.Equal(t, map[string]*big.Int{"alice": big.NewInt(3)}, token.Accounts, "should unquote values in maps")
+	assert.Equal(t, big.NewInt(4), token.Supply, "should unquote values in embedded structs")
+
+	value, err = unmarshalJSONScalars(`{"1":"2","3":null}`, reflect.TypeOf(map[int]*int64{}), true)
+	require.NoError(t, err)
+	two := int64(2)
+	assert.Equal(t, map[int]*int64{1: &two, 3: nil}, value.Interface(), "should set values of pointers and use map keys as marshalled")
+
+	value, err = unmarshalJSONScalars(`{"quoted":"5"}`, reflect.TypeOf(quotedStruct{}), true)
+	require.NoError(t, err)
+	assert.Equal(t, quotedStruct{Quoted: 5}, value.Interface(), "should leave fields marshalled as strings")
+
+	_, err = unmarshalJSONScalars(`{"balance":"abc"}`, reflect.TypeOf(tokenStruct{}), false)
+	assert.Error(t, err, "should error for string which is not a number")
+
+	_, err = unmarshalJSONScalars(`{"small":"abc"}`, reflect.TypeOf(tokenStruct{}), false)
+	assert.Error(t, err, "should error for JSON which does not match type")
+
+	_, err = unmarshalJSONScalars(`{`, reflect.TypeOf(tokenStruct{}), false)
+	assert.Error(t, err, "should error for invalid JSON")
+}
+
+func TestBigNumbers(t *testing.T) {
+	var value reflect.Value
+	var str string
+	var err error
+
+	serializer := new(JSONSerializer)
+
+	expected := map[reflect.Type]string{
+		reflect.TypeOf(new(big.Int)):   "12345678901234567890",
+		reflect.TypeOf(new(big.Rat)):   "12345678901234567890",
+		reflect.TypeOf(new(big.Float)): "1.234567890123456789e+19",
+	}
+
+	for typ, expectedStr := range expected {
+		paramMetadata, _ := protoMetadata(t, "param", typ)
+
+		value, err = serializer.FromString("12345678901234567890", typ, paramMetadata, nil)
+		require.NoError(t, err, "should convert %s", typ.String())
+
+		str, err = serializer.ToString(value, typ, &metadata.ReturnMetadata{CompiledSchema: createGoJSONSchemaSchema("return", paramMetadata.Schema, nil)}, nil)
+		require.NoError(t, err, "should format %s", typ.String())
+		assert.Equal(t, expectedStr, str, "should format %s as string of number", typ.String())
+	}
+
+	_, err = serializer.FromString("1/3", reflect.TypeOf(new(big.Int)), nil, nil)
+	assert.EqualError(t, err, "conversion error. cannot convert passed value 1/3 to *big.Int", "should error for invalid big int")
+
+	token := newTokenStruct()
+	typ := reflect.TypeOf(token)
+	paramMetadata, components := protoMetadata(t, "param", typ)
+
+	str, err = serializer.ToString(reflect.ValueOf(token), typ, &metadata.ReturnMetadata{CompiledSchema: createGoJSONSchemaSchema("return", paramMetadata.Schema, components)}, components)
+	require.NoError(t, err, "should validate struct with big numbers as strings")
+	assert.Contains(t, str, `"balance":"123456789012345678901234567890"`, "should return big int in struct as string")
+	assert.Contains(t, str, `"count":9007199254740993`, "should return int64 in struct as number")
+
+	value, err = serializer.FromString(str, typ, paramMetadata, components)
+	require.NoError(t, err, "should convert struct with big numbers as strings")
+	assert.Equal(t, token, value.Interface(), "should convert struct with big numbers")
+
+	_, err = serializer.FromString(`{"balance":"abc"}`, typ, nil, nil)
+	assert.EqualError(t, err, `conversion error. value {"balance":"abc"} was not passed in expected format *serializer.tokenStruct`, "should error for big int in struct which is not a number")
+}
+
+func TestInt64AsString(t *testing.T) {
+	var value reflect.Value
+	var str string
+	var err error
+
+	serializer := &JSONSerializer{Int64AsString: true}
+	assert.Equal(t, metadata.SchemaOptions{Int64AsString: true}, serializer.SchemaOptions(), "should return schema options of serializer")
+
+	components := new(metadata.ComponentMetadata)
+	schema, _ := metadata.GetSchemaWithOptions(reflect.TypeOf(int64(1)), components, serializer.SchemaOptions())
+	paramMetadata := &metadata.ParameterMetadata{Name: "param", Schema: schema, CompiledSchema: createGoJSONSchemaSchema("param", schema, components)}
+
+	value, err = serializer.FromString("9007199254740993", reflect.TypeOf(int64(1)), paramMetadata, components)
+	require.NoError(t, err, "should validate int64 against string schema")
+	assert.Equal(t, int64(9007199254740993), value.Interface())
+
+	str, err = serializer.ToString(value, reflect.TypeOf(int64(1)), &metadata.ReturnMetadata{CompiledSchema: createGoJSONSchemaSchema("return", schema, components)}, components)
+	require.NoError(t, err, "should validate returned int64 against string schema")
+	assert.Equal(t, "9007199254740993", str)
+
+	_, err = new(JSONSerializer).FromString("1", reflect.TypeOf(int64(1)), paramMetadata, components)
+	assert.ErrorContains(t, err, "value did not match schema", "should validate int64 as number when not passed as string")
+
+	token := newTokenStruct()
+	typ := reflect.TypeOf(token)
+	schema, _ = metadata.GetSchemaWithOptions(typ, components, serializer.SchemaOptions())
+	compiledSchema := createGoJSONSchemaSchema("param", schema, components)
+
+	str, err = serializer.ToString(reflect.ValueOf(token), typ, &metadata.ReturnMetadata{CompiledSchema: createGoJSONSchemaSchema("return", schema, components)}, components)
+	require.NoError(t, err, "should validate struct with int64s as strings")
+	assert.Contains(t, str, `"count":"9007199254740993"`, "should return int64 in struct as string")
+	assert.Contains(t, str, `"expiry":"1000000000"`, "should return int64 kinds in struct as string")
+	assert.Contains(t, str, `"small":7`, "should return int32 in struct as number")
+
+	value, err = serializer.FromString(str, typ, &metadata.ParameterMetadata{Name: "param", Schema: schema, CompiledSchema: compiledSchema}, components)
+	require.NoError(t, err, "should convert struct with int64s as strings")
+	assert.Equal(t, token, value.Interface(), "should convert struct with int64s")
+}
@@ -4,9 +4,12 @@
 package serializer
 
 import (
+	"bytes"
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/hyperledger/fabric-contract-api-go/v2/internal/types"
@@ -14,12 +17,33 @@ import (
 	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
 
 	"github.com/xeipuuv/gojsonschema"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 // JSONSerializer an implementation of TransactionSerializer for handling conversion of to and from
 // JSON string formats into usable values for chaincode. It is the default serializer for chaincode
-// created by NewChaincode
-type JSONSerializer struct{}
+// created by NewChaincode. When Canonical is set the JSON and numbers returned by the serializer are
+// in the canonical form defined by RFC 8785, so that endorsing peers of different versions produce
+// identical bytes. Arbitrary precision numbers, *big.Int, *big.Rat and *big.Float, are passed as
+// strings, including within structs, slices and maps. When Int64AsString is set the same is true of
+// the 64 bit integer types int, int64, uint and uint64, whose schemas in the metadata of transactions
+// using the serializer describe strings, so that clients which parse JSON numbers as doubles do not
+// lose precision
+type JSONSerializer struct {
+	Canonical     bool
+	Int64AsString bool
+}
+
+// Encoding returns json, the name of the encoding produced by the serializer
+func (js *JSONSerializer) Encoding() string {
+	return "json"
+}
+
+// SchemaOptions returns the options for generating the schemas of values handled by the serializer
+func (js *JSONSerializer) SchemaOptions() metadata.SchemaOptions {
+	return metadata.SchemaOptions{Int64AsString: js.Int64AsString}
+}
 
 // FromString takes a parameter and converts it to a reflect value representing the goal data type. If
 // a parameter metadata is passed it will validate that the converted value meets the rules specified by that
@@ -29,16 +53,28 @@ type JSONSerializer struct{}
 // you use either of these in your struct and expect data passed in to use these then you should write
 // your own unmarshall function to handle this for your struct.
 // Docs on how the Go JSON Unmarshaller works: https://golang.org/pkg/encoding/json/
-// For date-time types strings should be passed in RFC3339 format.
+// For date-time types strings should be passed in RFC3339 format. Protobuf messages should be passed
+// in their protobuf JSON form.
 func (js *JSONSerializer) FromString(param string, fieldType reflect.Type, paramMetadata *metadata.ParameterMetadata, components *metadata.ComponentMetadata) (reflect.Value, error) {
-	converted, err := convertArg(fieldType, param)
+	var converted reflect.Value
+	var err error
 
-	if err != nil {
-		return reflect.Value{}, err
+	if _, ok := getStringScalar(fieldType, false); !ok && isMarshallingType(fieldType) && containsStringScalars(fieldType, js.Int64AsString, make(map[reflect.Type]bool)) {
+		converted, err = unmarshalJSONScalars(param, fieldType, js.Int64AsString)
+
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("conversion error. value %s was not passed in expected format %s", param, fieldType.String())
+		}
+	} else {
+		converted, err = convertArg(fieldType, param)
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
 	}
 
 	if paramMetadata != nil {
-		err := validateAgainstSchema(paramMetadata.Name, fieldType, param, converted.Interface(), paramMetadata.CompiledSchema)
+		err := validateAgainstSchema(paramMetadata.Name, fieldType, param, js.schemaValue(converted, fieldType), paramMetadata.CompiledSchema)
 
 		if err != nil {
 			return reflect.Value{}, err
@@ -56,24 +92,83 @@ func (js *JSONSerializer) FromString(param string, fieldType reflect.Type, param
 // tag value for the property name in the produced string by default. To include these within the string whilst using this serializer
 // you should write a custom Marshall function on your struct
 // Docs on how the Go JSON Marshaller works: https://golang.org/pkg/encoding/json/
-// For date-time types the resulting string will meet the RFC3339 format
+// For date-time types the resulting string will meet the RFC3339 format and protobuf messages are
+// returned in their protobuf JSON form. In canonical mode JSON is canonicalized and floats formatted
+// as described by RFC 8785
 func (js *JSONSerializer) ToString(result reflect.Value, resultType reflect.Type, returns *metadata.ReturnMetadata, components *metadata.ComponentMetadata) (string, error) {
 	var str string
 
 	if !isNillableType(result.Kind()) || !result.IsNil() {
-		if resultType == types.TimeType {
+		if scalar, ok := getStringScalar(resultType, false); ok {
+			str = scalar.Format(result)
+		} else if resultType == types.TimeType {
 			str = result.Interface().(time.Time).Format(time.RFC3339)
+		} else if types.IsTextMarshaler(resultType) {
+			text, err := result.Interface().(encoding.TextMarshaler).MarshalText()
+
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal %s. %s", resultType.String(), err.Error())
+			}
+
+			str = string(text)
+		} else if types.IsJSONMarshaler(resultType) {
+			marshalled, err := json.Marshal(result.Interface())
+
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal %s. %s", resultType.String(), err.Error())
+			}
+
+			str = string(marshalled)
+
+			if js.Canonical {
+				str, err = canonicalizeJSONString(str)
+
+				if err != nil {
+					return "", err
+				}
+			}
 		} else if types.IsBytes(resultType) {
 			str = fmt.Sprintf("%s", result.Interface())
+		} else if types.IsProtoMessage(resultType) {
+			var err error
+			str, err = marshalProtoJSON(result.Interface().(proto.Message))
+
+			if err == nil && js.Canonical {
+				str, err = canonicalizeJSONString(str)
+			}
+
+			if err != nil {
+				return "", err
+			}
 		} else if isMarshallingType(resultType) || resultType.Kind() == reflect.Interface && isMarshallingType(result.Type()) {
 			bytes, _ := json.Marshal(result.Interface())
-			str = string(bytes)
+
+			var err error
+			str, err = quoteJSONScalars(string(bytes), result, js.Int64AsString)
+
+			if err == nil && js.Canonical {
+				str, err = canonicalizeJSONString(str)
+			}
+
+			if err != nil {
+				return "", err
+			}
+		} else if js.Canonical && (result.Kind() == reflect.Float32 || result.Kind() == reflect.Float64) {
+			// float32 values are formatted as the shortest double which represents them, as in JSON
+			f, _ := strconv.ParseFloat(strconv.FormatFloat(result.Float(), 'g', -1, result.Type().Bits()), 64)
+
+			var err error
+			str, err = formatCanonicalNumber(f)
+
+			if err != nil {
+				return "", err
+			}
 		} else {
 			str = fmt.Sprint(result.Interface())
 		}
 
 		if returns != nil {
-			err := validateAgainstSchema("return", resultType, str, result.Interface(), returns.CompiledSchema)
+			err := validateAgainstSchema("return", resultType, str, js.schemaValue(result, resultType), returns.CompiledSchema)
 
 			if err != nil {
 				return "", err
@@ -84,6 +179,54 @@ func (js *JSONSerializer) ToString(result reflect.Value, resultType reflect.Type
 	return str, nil
 }
 
+// FromBytes converts a parameter in the same way as FromString. Parameters of byte slice types are
+// used without being copied
+func (js *JSONSerializer) FromBytes(param []byte, fieldType reflect.Type, paramMetadata *metadata.ParameterMetadata, components *metadata.ComponentMetadata) (reflect.Value, error) {
+	if !isByteSlice(fieldType) {
+		return js.FromString(string(param), fieldType, paramMetadata, components)
+	}
+
+	if paramMetadata != nil {
+		err := validateAgainstSchema(paramMetadata.Name, fieldType, "", param, paramMetadata.CompiledSchema)
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
+	}
+
+	return reflect.ValueOf(param), nil
+}
+
+// ToBytes converts a value in the same way as ToString. Values of byte slice types are returned without
+// being copied
+func (js *JSONSerializer) ToBytes(result reflect.Value, resultType reflect.Type, returns *metadata.ReturnMetadata, components *metadata.ComponentMetadata) ([]byte, error) {
+	if !isByteSlice(resultType) {
+		str, err := js.ToString(result, resultType, returns, components)
+
+		return []byte(str), err
+	}
+
+	if returns != nil && !result.IsNil() {
+		err := validateAgainstSchema("return", resultType, "", result.Interface(), returns.CompiledSchema)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result.Bytes(), nil
+}
+
+// schemaValue returns the value to validate against the schema of a type, which for values passed
+// as strings is their string
+func (js *JSONSerializer) schemaValue(value reflect.Value, typ reflect.Type) interface{} {
+	if scalar, ok := getStringScalar(typ, js.Int64AsString); ok {
+		return scalar.Format(value)
+	}
+
+	return value.Interface()
+}
+
 func createArraySliceMapOrStruct(param string, objType reflect.Type) (reflect.Value, error) {
 	obj := reflect.New(objType)
 
@@ -96,16 +239,72 @@ func createArraySliceMapOrStruct(param string, objType reflect.Type) (reflect.Va
 	return obj.Elem(), nil
 }
 
+func createTextUnmarshaler(param string, typ reflect.Type) (reflect.Value, error) {
+	obj := reflect.New(typ)
+
+	if typ.Kind() == reflect.Pointer {
+		obj.Elem().Set(reflect.New(typ.Elem()))
+		obj = obj.Elem()
+	}
+
+	err := obj.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(param))
+
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("cannot convert passed value %s to %s. %s", param, typ.String(), err.Error())
+	}
+
+	if typ.Kind() == reflect.Pointer {
+		return obj, nil
+	}
+
+	return obj.Elem(), nil
+}
+
+func createProtoMessage(param string, messageType reflect.Type) (reflect.Value, error) {
+	message := reflect.New(messageType.Elem())
+
+	err := protojson.Unmarshal([]byte(param), message.Interface().(proto.Message))
+
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("value %s was not passed in expected format %s", param, messageType.String())
+	}
+
+	return message, nil
+}
+
+// marshalProtoJSON returns the protobuf JSON form of a message. The output of protojson is
+// deliberately unstable in its whitespace so is compacted
+func marshalProtoJSON(message proto.Message) (string, error) {
+	messageJSON, err := protojson.Marshal(message)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s message. %s", message.ProtoReflect().Descriptor().FullName(), err.Error())
+	}
+
+	var compacted bytes.Buffer
+	_ = json.Compact(&compacted, messageJSON)
+
+	return compacted.String(), nil
+}
+
 func convertArg(fieldType reflect.Type, paramValue string) (reflect.Value, error) {
 	var converted reflect.Value
 
 	var err error
-	if fieldType == types.TimeType {
+	if scalar, ok := getStringScalar(fieldType, false); ok {
+		converted, err = scalar.Convert(paramValue)
+	} else if fieldType == types.TimeType {
 		var t time.Time
 		t, err = time.Parse(time.RFC3339, paramValue)
 		converted = reflect.ValueOf(t)
+	} else if types.IsTextMarshaler(fieldType) {
+		converted, err = createTextUnmarshaler(paramValue, fieldType)
+	} else if types.IsJSONMarshaler(fieldType) {
+		converted, err = createArraySliceMapOrStruct(paramValue, fieldType)
 	} else if types.IsBytes(fieldType) {
 		converted = reflect.ValueOf([]byte(paramValue))
+	} else if types.IsProtoMessage(fieldType) {
+		converted, err = createProtoMessage(paramValue, fieldType)
 	} else if fieldType.Kind() == reflect.Array || fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Map || fieldType.Kind() == reflect.Struct || (fieldType.Kind() == reflect.Pointer && fieldType.Elem().Kind() == reflect.Struct) {
 		converted, err = createArraySliceMapOrStruct(paramValue, fieldType)
 	} else {
@@ -122,8 +321,17 @@ func convertArg(fieldType reflect.Type, paramValue string) (reflect.Value, error
 func validateAgainstSchema(propName string, typ reflect.Type, stringValue string, obj interface{}, schema *gojsonschema.Schema) error {
 	toValidate := make(map[string]interface{})
 
-	if typ == reflect.TypeOf(time.Time{}) {
+	if _, ok := getStringScalar(typ, false); ok {
+		toValidate[propName] = obj
+	} else if typ == reflect.TypeOf(time.Time{}) || types.IsTextMarshaler(typ) {
 		toValidate[propName] = stringValue
+	} else if types.IsProtoMessage(typ) || types.IsJSONMarshaler(typ) {
+		// protobuf messages and types marshalling themselves are validated in their JSON form, which is not always an object
+		var value interface{}
+		if err := json.Unmarshal([]byte(stringValue), &value); err != nil {
+			return err
+		}
+		toValidate[propName] = value
 	} else if typ.Kind() == reflect.Struct || (typ.Kind() == reflect.Pointer && typ.Elem().Kind() == reflect.Struct) {
 		// use a map for structs as schema seems to like that
 		structMap := make(map[string]interface{})
@@ -150,6 +358,11 @@ func isNillableType(kind reflect.Kind) bool {
 	return kind == reflect.Pointer || kind == reflect.Interface || kind == reflect.Map || kind == reflect.Slice || kind == reflect.Chan || kind == reflect.Func
 }
 
+func isByteSlice(typ reflect.Type) bool {
+	_, registered := types.GetRegisteredType(typ)
+	return !registered && typ.Kind() == reflect.Slice && types.IsBytes(typ) && !types.IsTextMarshaler(typ) && !types.IsJSONMarshaler(typ)
+}
+
 func isMarshallingType(typ reflect.Type) bool {
 	return !types.IsBytes(typ) &&
 		(typ.Kind() == reflect.Array || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map || typ.Kind() == reflect.Struct || (typ.Kind() == reflect.Pointer && isMarshallingType(typ.Elem())))
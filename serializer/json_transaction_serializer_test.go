@@ -4,8 +4,12 @@
 package serializer
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net"
 	"reflect"
 	"testing"
 	"time"
@@ -13,9 +17,11 @@ import (
 	"github.com/go-openapi/spec"
 	"github.com/hyperledger/fabric-contract-api-go/v2/internal/types"
 	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/xeipuuv/gojsonschema"
+	"google.golang.org/protobuf/proto"
 )
 
 // ================================
@@ -46,6 +52,45 @@ func (us usefulStruct) DoNothing() string {
 	return "nothing"
 }
 
+type hexID [2]byte
+
+func (id hexID) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(id[:])), nil
+}
+
+func (id *hexID) UnmarshalText(text []byte) error {
+	if hex.DecodedLen(len(text)) != len(id) {
+		return errors.New("hex IDs are two bytes")
+	}
+
+	_, err := hex.Decode(id[:], text)
+	return err
+}
+
+type point struct {
+	X int
+	Y int
+}
+
+func (p point) MarshalJSON() ([]byte, error) {
+	if p.X < 0 {
+		return nil, errors.New("points are not negative")
+	}
+
+	return json.Marshal([]int{p.X, p.Y})
+}
+
+func (p *point) UnmarshalJSON(data []byte) error {
+	var coords [2]int
+
+	if err := json.Unmarshal(data, &coords); err != nil {
+		return err
+	}
+
+	p.X, p.Y = coords[0], coords[1]
+	return nil
+}
+
 func createGoJSONSchemaSchema(propName string, schema *spec.Schema, components *metadata.ComponentMetadata) *gojsonschema.Schema {
 	combined := make(map[string]interface{})
 	combined["components"] = components
@@ -266,6 +311,18 @@ func TestFromString(t *testing.T) {
 	value, err = serializer.FromString("{\"prop1\":\"hello\"}", reflect.TypeOf(expectedStruct), &paramMetadata, components)
 	require.NoError(t, err, "should not error when convert args passes and schema passes")
 	assert.Equal(t, reflect.ValueOf(expectedStruct).Interface(), value.Interface(), "should reflect value for converted arg when arg and schema passes")
+
+	expectedMessage := &peer.ChaincodeID{Name: "basic", Version: "1.0"}
+	components = new(metadata.ComponentMetadata)
+	schema, _ = metadata.GetSchema(reflect.TypeOf(expectedMessage), components)
+	compiledSchema = createGoJSONSchemaSchema("param1", schema, components)
+	paramMetadata = metadata.ParameterMetadata{Name: "param1", Schema: schema, CompiledSchema: compiledSchema}
+	value, err = serializer.FromString("{\"name\":\"basic\",\"version\":\"1.0\"}", reflect.TypeOf(expectedMessage), &paramMetadata, components)
+	require.NoError(t, err, "should not error for protobuf JSON form of message")
+	assert.True(t, proto.Equal(expectedMessage, value.Interface().(proto.Message)), "should reflect value for message")
+
+	_, err = serializer.FromString("{\"unknown\":\"basic\"}", reflect.TypeOf(expectedMessage), &paramMetadata, components)
+	assert.ErrorContains(t, err, "conversion error.", "should error for invalid protobuf JSON")
 }
 
 func TestToString(t *testing.T) {
@@ -317,4 +374,162 @@ func TestToString(t *testing.T) {
 	value, err = serializer.ToString(reflect.ValueOf(expectedStruct), reflect.TypeOf(expectedStruct), &returnMetadata, components)
 	require.NoError(t, err, "should not error when making a string passes and schema passes")
 	assert.JSONEqf(t, "{\"prop1\":\"hello\"}", value, "should return string value when schema passes")
+
+	message := &peer.ChaincodeInput{Args: [][]byte{[]byte("hello")}, IsInit: true}
+	components = new(metadata.ComponentMetadata)
+	schema, _ = metadata.GetSchema(reflect.TypeOf(message), components)
+	compiledSchema = createGoJSONSchemaSchema("return", schema, components)
+	returnMetadata = metadata.ReturnMetadata{Schema: schema, CompiledSchema: compiledSchema}
+	value, err = serializer.ToString(reflect.ValueOf(message), reflect.TypeOf(message), &returnMetadata, components)
+	require.NoError(t, err, "should not error for message")
+	assert.Equal(t, "{\"args\":[\"aGVsbG8=\"],\"isInit\":true}", value, "should return compact protobuf JSON form of message")
+}
+
+func TestToStringCanonical(t *testing.T) {
+	var value string
+	var err error
+
+	serializer := &JSONSerializer{Canonical: true}
+
+	value, err = serializer.ToString(reflect.ValueOf(map[string]interface{}{"b": "<b>", "a": 1e21}), reflect.TypeOf(map[string]interface{}{}), nil, nil)
+	require.NoError(t, err, "should not error for map")
+	assert.Equal(t, `{"a":1e+21,"b":"<b>"}`, value, "should return canonical JSON")
+
+	message := &peer.ChaincodeInput{Args: [][]byte{[]byte("hello")}, IsInit: true}
+	value, err = serializer.ToString(reflect.ValueOf(message), reflect.TypeOf(message), nil, nil)
+	require.NoError(t, err, "should not error for message")
+	assert.Equal(t, `{"args":["aGVsbG8="],"isInit":true}`, value, "should return canonical protobuf JSON form of message")
+
+	value, err = serializer.ToString(reflect.ValueOf(1e-7), reflect.TypeOf(1.0), nil, nil)
+	require.NoError(t, err, "should not error for float")
+	assert.Equal(t, "1e-7", value, "should format float as canonical number")
+
+	value, err = serializer.ToString(reflect.ValueOf(float32(1.1)), reflect.TypeOf(float32(1)), nil, nil)
+	require.NoError(t, err, "should not error for float32")
+	assert.Equal(t, "1.1", value, "should format float32 as shortest representation")
+
+	_, err = serializer.ToString(reflect.ValueOf(math.NaN()), reflect.TypeOf(1.0), nil, nil)
+	assert.EqualError(t, err, "number NaN is not valid JSON", "should error for float not valid in JSON")
+
+	value, err = serializer.ToString(reflect.ValueOf("<b>"), reflect.TypeOf(""), nil, nil)
+	require.NoError(t, err, "should not error for string")
+	assert.Equal(t, "<b>", value, "should return strings unchanged")
+}
+
+func TestMarshalers(t *testing.T) {
+	var value reflect.Value
+	var str string
+	var err error
+
+	serializer := new(JSONSerializer)
+
+	idType := reflect.TypeOf(hexID{})
+	idSchema, _ := metadata.GetSchema(idType, nil)
+	idMetadata := &metadata.ParameterMetadata{Name: "param", Schema: idSchema, CompiledSchema: createGoJSONSchemaSchema("param", idSchema, nil)}
+
+	value, err = serializer.FromString("0a1b", idType, idMetadata, nil)
+	require.NoError(t, err, "should convert type marshalling itself to text")
+	assert.Equal(t, hexID{0x0a, 0x1b}, value.Interface(), "should unmarshal text")
+
+	value, err = serializer.FromString("0a1b", reflect.PointerTo(idType), nil, nil)
+	require.NoError(t, err, "should convert pointer to type marshalling itself to text")
+	assert.Equal(t, &hexID{0x0a, 0x1b}, value.Interface(), "should unmarshal text into new value")
+
+	value, err = serializer.FromBytes([]byte("10.0.0.1"), reflect.TypeOf(net.IP{}), nil, nil)
+	require.NoError(t, err, "should convert byte slice type marshalling itself to text")
+	assert.Equal(t, net.ParseIP("10.0.0.1"), value.Interface(), "should not treat byte slice type marshalling itself to text as bytes")
+
+	_, err = serializer.FromString("0a", idType, nil, nil)
+	assert.EqualError(t, err, "conversion error. cannot convert passed value 0a to serializer.hexID. hex IDs are two bytes", "should error when text cannot be unmarshalled")
+
+	_, err = serializer.FromString("0a1b", idType, &metadata.ParameterMetadata{Name: "param", CompiledSchema: createGoJSONSchemaSchema("param", spec.StringProperty().WithPattern("^ff"), nil)}, nil)
+	assert.ErrorContains(t, err, "value did not match schema", "should validate text against schema")
+
+	str, err = serializer.ToString(reflect.ValueOf(hexID{0x0a, 0x1b}), idType, &metadata.ReturnMetadata{CompiledSchema: createGoJSONSchemaSchema("return", idSchema, nil)}, nil)
+	require.NoError(t, err, "should convert type marshalling itself to text to string")
+	assert.Equal(t, "0a1b", str, "should return marshalled text")
+
+	pointType := reflect.TypeOf(point{})
+	pointSchema, _ := metadata.GetSchema(pointType, nil)
+	pointMetadata := &metadata.ParameterMetadata{Name: "param", Schema: pointSchema, CompiledSchema: createGoJSONSchemaSchema("param", pointSchema, nil)}
+
+	value, err = serializer.FromString("[1,2]", pointType, pointMetadata, nil)
+	require.NoError(t, err, "should convert type marshalling itself to JSON")
+	assert.Equal(t, point{1, 2}, value.Interface(), "should unmarshal JSON")
+
+	_, err = serializer.FromString(`{"X":1,"Y":2}`, pointType, pointMetadata, nil)
+	assert.EqualError(t, err, `conversion error. value {"X":1,"Y":2} was not passed in expected format serializer.point`, "should error when JSON cannot be unmarshalled")
+
+	_, err = serializer.FromString("[1,2]", pointType, &metadata.ParameterMetadata{Name: "param", CompiledSchema: createGoJSONSchemaSchema("param", spec.ArrayProperty(nil).WithMaxItems(1), nil)}, nil)
+	assert.ErrorContains(t, err, "value did not match schema", "should validate JSON against schema")
+
+	str, err = serializer.ToString(reflect.ValueOf(&point{1, 2}), reflect.TypeOf(&point{}), &metadata.ReturnMetadata{CompiledSchema: createGoJSONSchemaSchema("return", pointSchema, nil)}, nil)
+	require.NoError(t, err, "should convert type marshalling itself to JSON to string")
+	assert.Equal(t, "[1,2]", str, "should return marshalled JSON")
+
+	_, err = serializer.ToString(reflect.ValueOf(point{-1, 2}), pointType, nil, nil)
+	assert.ErrorContains(t, err, "failed to marshal serializer.point. ", "should error when JSON cannot be marshalled")
+
+	str, err = serializer.ToString(reflect.ValueOf(map[string]interface{}{"id": hexID{0x0a, 0x1b}, "at": point{1, 2}}), reflect.TypeOf(map[string]interface{}{}), nil, nil)
+	require.NoError(t, err, "should convert values marshalling themselves within maps")
+	assert.Equal(t, `{"at":[1,2],"id":"0a1b"}`, str, "should use marshalled values within maps")
+}
+
+func TestFromBytes(t *testing.T) {
+	var err error
+	var value reflect.Value
+
+	serializer := new(JSONSerializer)
+
+	value, err = serializer.FromBytes([]byte("1234"), reflect.TypeOf(1), nil, nil)
+	require.NoError(t, err, "should not error for valid arg")
+	assert.Equal(t, 1234, value.Interface(), "should convert types other than byte slices as from string")
+
+	_, err = serializer.FromBytes([]byte("some string"), reflect.TypeOf(1), nil, nil)
+	require.Error(t, err, "should error when arg cannot be converted")
+
+	param := []byte("some bytes")
+	value, err = serializer.FromBytes(param, reflect.TypeOf([]byte{}), nil, nil)
+	require.NoError(t, err, "should not error for byte slice")
+	assert.Same(t, &param[0], &value.Interface().([]byte)[0], "should not copy byte slice")
+
+	schema := spec.StrFmtProperty("byte")
+	maxLength := int64(2)
+	schema.MaxLength = &maxLength
+	paramMetadata := metadata.ParameterMetadata{Name: "param1", Schema: schema, CompiledSchema: createGoJSONSchemaSchema("param1", schema, nil)}
+	_, err = serializer.FromBytes(param, reflect.TypeOf([]byte{}), &paramMetadata, nil)
+	assert.ErrorContains(t, err, "value did not match schema", "should validate byte slice")
+}
+
+func TestToBytes(t *testing.T) {
+	var err error
+	var value []byte
+
+	serializer := new(JSONSerializer)
+
+	value, err = serializer.ToBytes(reflect.ValueOf(1), reflect.TypeOf(1), nil, nil)
+	require.NoError(t, err, "should not error for int")
+	assert.Equal(t, []byte("1"), value, "should convert types other than byte slices as to string")
+
+	result := []byte("some bytes")
+	value, err = serializer.ToBytes(reflect.ValueOf(result), reflect.TypeOf(result), nil, nil)
+	require.NoError(t, err, "should not error for byte slice")
+	assert.Same(t, &result[0], &value[0], "should not copy byte slice")
+
+	var nilResult []byte
+	value, err = serializer.ToBytes(reflect.ValueOf(nilResult), reflect.TypeOf(nilResult), &metadata.ReturnMetadata{}, nil)
+	require.NoError(t, err, "should not validate nil byte slice")
+	assert.Empty(t, value)
+
+	schema := spec.StrFmtProperty("byte")
+	maxLength := int64(2)
+	schema.MaxLength = &maxLength
+	returnMetadata := metadata.ReturnMetadata{Schema: schema, CompiledSchema: createGoJSONSchemaSchema("return", schema, nil)}
+	_, err = serializer.ToBytes(reflect.ValueOf(result), reflect.TypeOf(result), &returnMetadata, nil)
+	assert.ErrorContains(t, err, "value did not match schema", "should validate byte slice")
+}
+
+func TestEncoding(t *testing.T) {
+	assert.Equal(t, "json", new(JSONSerializer).Encoding(), "should name JSON encoding")
+	assert.Equal(t, "protobuf", new(ProtobufSerializer).Encoding(), "should name protobuf encoding")
 }
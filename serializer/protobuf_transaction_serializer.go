@@ -0,0 +1,118 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package serializer
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hyperledger/fabric-contract-api-go/v2/internal/types"
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/xeipuuv/gojsonschema"
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtobufSerializer an implementation of TransactionSerializer for chaincode using protobuf
+// messages as parameters and return values. Messages are passed in and returned in the protobuf
+// binary wire format. Values of other types, such as the ID of an asset, are handled in the same
+// way as by the JSONSerializer
+type ProtobufSerializer struct {
+	jsonSerializer JSONSerializer
+}
+
+// Encoding returns protobuf, the name of the encoding produced by the serializer for protobuf messages
+func (ps *ProtobufSerializer) Encoding() string {
+	return "protobuf"
+}
+
+// FromString takes a parameter and converts it to a reflect value representing the goal data type. For
+// protobuf messages the string value passed should be the binary encoding of the message. If a parameter
+// metadata is passed it will validate that the protobuf JSON form of the message meets the rules specified
+// by that parameter's compiled schema. Other types are converted as by the JSONSerializer
+func (ps *ProtobufSerializer) FromString(param string, fieldType reflect.Type, paramMetadata *metadata.ParameterMetadata, components *metadata.ComponentMetadata) (reflect.Value, error) {
+	if !types.IsProtoMessage(fieldType) {
+		return ps.jsonSerializer.FromString(param, fieldType, paramMetadata, components)
+	}
+
+	return ps.FromBytes([]byte(param), fieldType, paramMetadata, components)
+}
+
+// FromBytes converts a parameter in the same way as FromString without first converting it to a string
+func (ps *ProtobufSerializer) FromBytes(param []byte, fieldType reflect.Type, paramMetadata *metadata.ParameterMetadata, components *metadata.ComponentMetadata) (reflect.Value, error) {
+	if !types.IsProtoMessage(fieldType) {
+		return ps.jsonSerializer.FromBytes(param, fieldType, paramMetadata, components)
+	}
+
+	converted := reflect.New(fieldType.Elem())
+	message := converted.Interface().(proto.Message)
+
+	err := proto.Unmarshal(param, message)
+
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("conversion error. value was not passed in expected format %s. %s", fieldType.String(), err.Error())
+	}
+
+	if paramMetadata != nil {
+		err := validateProtoAgainstSchema(paramMetadata.Name, message, paramMetadata.CompiledSchema)
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
+	}
+
+	return converted, nil
+}
+
+// ToString takes a reflect value, the type of what the value originally was. For protobuf messages it returns
+// the deterministic binary encoding of the message, or an empty string for a nil message. If a non nil return
+// metadata is supplied it will validate that the protobuf JSON form of the message matches the rules set out
+// by the compiled schema. Other types are converted as by the JSONSerializer
+func (ps *ProtobufSerializer) ToString(result reflect.Value, resultType reflect.Type, returns *metadata.ReturnMetadata, components *metadata.ComponentMetadata) (string, error) {
+	if !types.IsProtoMessage(resultType) {
+		return ps.jsonSerializer.ToString(result, resultType, returns, components)
+	}
+
+	bytes, err := ps.ToBytes(result, resultType, returns, components)
+
+	return string(bytes), err
+}
+
+// ToBytes converts a value in the same way as ToString without converting the result to a string
+func (ps *ProtobufSerializer) ToBytes(result reflect.Value, resultType reflect.Type, returns *metadata.ReturnMetadata, components *metadata.ComponentMetadata) ([]byte, error) {
+	if !types.IsProtoMessage(resultType) {
+		return ps.jsonSerializer.ToBytes(result, resultType, returns, components)
+	}
+
+	if result.IsNil() {
+		return nil, nil
+	}
+
+	message := result.Interface().(proto.Message)
+
+	if returns != nil {
+		err := validateProtoAgainstSchema("return", message, returns.CompiledSchema)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s message. %s", message.ProtoReflect().Descriptor().FullName(), err.Error())
+	}
+
+	return bytes, nil
+}
+
+func validateProtoAgainstSchema(propName string, message proto.Message, schema *gojsonschema.Schema) error {
+	messageJSON, err := marshalProtoJSON(message)
+
+	if err != nil {
+		return err
+	}
+
+	return validateAgainstSchema(propName, reflect.TypeOf(message), messageJSON, message, schema)
+}
@@ -0,0 +1,134 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package serializer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/fabric-contract-api-go/v2/metadata"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ================================
+// HELPERS
+// ================================
+
+func newChaincodeSpec() *peer.ChaincodeSpec {
+	return &peer.ChaincodeSpec{
+		Type:        peer.ChaincodeSpec_GOLANG,
+		ChaincodeId: &peer.ChaincodeID{Name: "basic"},
+		Input:       &peer.ChaincodeInput{Args: [][]byte{[]byte("Read"), []byte("asset1")}},
+	}
+}
+
+func protoMetadata(t *testing.T, propName string, typ reflect.Type) (*metadata.ParameterMetadata, *metadata.ComponentMetadata) {
+	t.Helper()
+
+	components := new(metadata.ComponentMetadata)
+	schema, err := metadata.GetSchema(typ, components)
+	require.NoError(t, err)
+
+	return &metadata.ParameterMetadata{Name: propName, Schema: schema, CompiledSchema: createGoJSONSchemaSchema(propName, schema, components)}, components
+}
+
+// ================================
+// TESTS
+// ================================
+
+func TestProtobufFromString(t *testing.T) {
+	var err error
+	var value reflect.Value
+
+	serializer := new(ProtobufSerializer)
+	specType := reflect.TypeOf(new(peer.ChaincodeSpec))
+	paramMetadata, components := protoMetadata(t, "param1", specType)
+
+	value, err = serializer.FromString("1234", reflect.TypeOf(1), nil, nil)
+	require.NoError(t, err, "should convert types other than messages as JSON serializer")
+	assert.Equal(t, 1234, value.Interface())
+
+	_, err = serializer.FromString("\xff", specType, nil, nil)
+	assert.ErrorContains(t, err, "conversion error. value was not passed in expected format *peer.ChaincodeSpec.", "should error for invalid message")
+
+	invalid := newChaincodeSpec()
+	invalid.Type = 99
+	invalidBytes, _ := proto.Marshal(invalid)
+	_, err = serializer.FromString(string(invalidBytes), specType, paramMetadata, components)
+	assert.ErrorContains(t, err, "value did not match schema", "should error when message does not match schema")
+
+	expected := newChaincodeSpec()
+	expectedBytes, _ := proto.Marshal(expected)
+	value, err = serializer.FromString(string(expectedBytes), specType, paramMetadata, components)
+	require.NoError(t, err, "should not error for valid message")
+	assert.True(t, proto.Equal(expected, value.Interface().(proto.Message)), "should return unmarshalled message")
+
+	timestamp := timestamppb.Now()
+	timestampBytes, _ := proto.Marshal(timestamp)
+	timestampMetadata, components := protoMetadata(t, "param1", reflect.TypeOf(timestamp))
+	value, err = serializer.FromString(string(timestampBytes), reflect.TypeOf(timestamp), timestampMetadata, components)
+	require.NoError(t, err, "should not error for well known message")
+	assert.True(t, proto.Equal(timestamp, value.Interface().(proto.Message)), "should return unmarshalled well known message")
+}
+
+func TestProtobufToString(t *testing.T) {
+	var err error
+	var value string
+
+	serializer := new(ProtobufSerializer)
+	specType := reflect.TypeOf(new(peer.ChaincodeSpec))
+	paramMetadata, components := protoMetadata(t, "return", specType)
+	returnMetadata := &metadata.ReturnMetadata{Schema: paramMetadata.Schema, CompiledSchema: paramMetadata.CompiledSchema}
+
+	value, err = serializer.ToString(reflect.ValueOf(1), reflect.TypeOf(1), nil, nil)
+	require.NoError(t, err, "should convert types other than messages as JSON serializer")
+	assert.Equal(t, "1", value)
+
+	var nilSpec *peer.ChaincodeSpec
+	value, err = serializer.ToString(reflect.ValueOf(nilSpec), specType, returnMetadata, components)
+	require.NoError(t, err, "should not error for nil message")
+	assert.Empty(t, value, "should return blank string for nil message")
+
+	invalid := newChaincodeSpec()
+	invalid.Type = 99
+	_, err = serializer.ToString(reflect.ValueOf(invalid), specType, returnMetadata, components)
+	assert.ErrorContains(t, err, "value did not match schema", "should error when message does not match schema")
+
+	expected := newChaincodeSpec()
+	value, err = serializer.ToString(reflect.ValueOf(expected), specType, returnMetadata, components)
+	require.NoError(t, err, "should not error for valid message")
+	expectedBytes, _ := proto.MarshalOptions{Deterministic: true}.Marshal(expected)
+	assert.Equal(t, string(expectedBytes), value, "should return binary encoding of message")
+}
+
+func TestProtobufBytes(t *testing.T) {
+	serializer := new(ProtobufSerializer)
+	specType := reflect.TypeOf(new(peer.ChaincodeSpec))
+
+	expected := newChaincodeSpec()
+	expectedBytes, _ := proto.MarshalOptions{Deterministic: true}.Marshal(expected)
+
+	value, err := serializer.FromBytes(expectedBytes, specType, nil, nil)
+	require.NoError(t, err, "should not error for valid message")
+	assert.True(t, proto.Equal(expected, value.Interface().(proto.Message)), "should return unmarshalled message")
+
+	_, err = serializer.FromBytes([]byte("\xff"), specType, nil, nil)
+	assert.ErrorContains(t, err, "conversion error.", "should error for invalid message")
+
+	value, err = serializer.FromBytes([]byte("1234"), reflect.TypeOf(1), nil, nil)
+	require.NoError(t, err, "should convert types other than messages as JSON serializer")
+	assert.Equal(t, 1234, value.Interface())
+
+	bytes, err := serializer.ToBytes(reflect.ValueOf(expected), specType, nil, nil)
+	require.NoError(t, err, "should not error for message")
+	assert.Equal(t, expectedBytes, bytes, "should return binary encoding of message")
+
+	bytes, err = serializer.ToBytes(reflect.ValueOf(1), reflect.TypeOf(1), nil, nil)
+	require.NoError(t, err, "should convert types other than messages as JSON serializer")
+	assert.Equal(t, []byte("1"), bytes)
+}
@@ -27,3 +27,41 @@ type TransactionSerializer interface {
 	// The function should produce a string which represents the original value
 	ToString(reflect.Value, reflect.Type, *metadata.ReturnMetadata, *metadata.ComponentMetadata) (string, error)
 }
+
+// BytesTransactionSerializer defines the functions of a transaction serializer which converts
+// values to and from bytes directly. When the serializer used by a chaincode implements this
+// interface the arguments of a call are read from the stub as bytes and passed to FromBytes,
+// and the bytes from ToBytes are used as the payload of the success response, avoiding
+// converting them through strings. FromBytes and ToBytes receive the same arguments as
+// FromString and ToString
+type BytesTransactionSerializer interface {
+	TransactionSerializer
+
+	// FromBytes receives the value in its original byte form and should produce a reflect
+	// value which matches the goal type
+	FromBytes([]byte, reflect.Type, *metadata.ParameterMetadata, *metadata.ComponentMetadata) (reflect.Value, error)
+
+	// ToBytes receives a reflected value and should produce bytes which represent the
+	// original value
+	ToBytes(reflect.Value, reflect.Type, *metadata.ReturnMetadata, *metadata.ComponentMetadata) ([]byte, error)
+}
+
+// EncodingTransactionSerializer defines the function of a transaction serializer which names the
+// encoding it produces. The name is recorded in the metadata of transactions which use a serializer
+// other than that of the chaincode
+type EncodingTransactionSerializer interface {
+	TransactionSerializer
+
+	// Encoding returns the name of the encoding produced by the serializer e.g. json
+	Encoding() string
+}
+
+// SchemaTransactionSerializer defines the function of a transaction serializer which represents values
+// differently to how their schemas are described by default. The options it returns are used when
+// generating the metadata of transactions which use the serializer in place of that of the chaincode
+type SchemaTransactionSerializer interface {
+	TransactionSerializer
+
+	// SchemaOptions returns the options used to generate schemas for the values the serializer handles
+	SchemaOptions() metadata.SchemaOptions
+}